@@ -7,7 +7,7 @@ import (
 	"time"
 )
 
-//go:generate go tool mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit
+//go:generate go tool mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,BatteryPowerController,Circuit
 
 // Meter provides total active power in W
 type Meter interface {
@@ -73,6 +73,12 @@ type BatteryController interface {
 	SetBatteryMode(BatteryMode) error
 }
 
+// BatteryPowerController optionally allows to control home battery modes with power setpoint (W).
+// The setpoint applies to BatteryDischarge (discharge power) and BatteryChargeLimit (maximum charge power).
+type BatteryPowerController interface {
+	SetBatteryModePower(BatteryMode, float64) error
+}
+
 // Charger provides current charging status and enable/disable charging
 type Charger interface {
 	ChargeState
@@ -115,6 +121,11 @@ type Identifier interface {
 	Identify() (string, error)
 }
 
+// SignedMeterValues provides calibration law compliant signed meter values (e.g. OCMF) of the current or last transaction
+type SignedMeterValues interface {
+	SignedMeterValues() (start, stop string, err error)
+}
+
 // Authorizer authorizes a charging session by supplying RFID credentials
 type Authorizer interface {
 	Authorize(key string) error
@@ -219,6 +230,11 @@ type CircuitMeasurements interface {
 	GetMaxPhaseCurrent() float64
 }
 
+// CircuitPhaseMeasurements provides the per-phase currents of a circuit or load, ordered by grid phase
+type CircuitPhaseMeasurements interface {
+	GetPhaseCurrents() (float64, float64, float64)
+}
+
 // CircuitLoad represents a loadpoint attached to a circuit
 type CircuitLoad interface {
 	CircuitMeasurements
@@ -228,6 +244,7 @@ type CircuitLoad interface {
 // Circuit defines the load control domain
 type Circuit interface {
 	CircuitMeasurements
+	CircuitPhaseMeasurements
 	GetTitle() string
 	SetTitle(string)
 	GetParent() Circuit
@@ -236,10 +253,13 @@ type Circuit interface {
 	HasMeter() bool
 	GetMaxPower() float64
 	GetMaxCurrent() float64
+	GetMaxImbalance() float64
 	SetMaxPower(float64)
 	SetMaxCurrent(float64)
+	SetMaxImbalance(float64)
 	Update([]CircuitLoad) error
 	ValidateCurrent(old, new float64) float64
+	ValidatePhaseCurrent(old, new float64, phases [3]bool) float64
 	ValidatePower(old, new float64) float64
 }
 
@@ -1,12 +1,14 @@
 package api
 
-// BatteryMode is the home battery operation mode. Valid values are normal, locked and charge
+// BatteryMode is the home battery operation mode. Valid values are normal, locked, charge, discharge and chargelimit
 type BatteryMode int
 
 //go:generate go tool enumer -type BatteryMode -trimprefix Battery -transform=lower
 const (
-	BatteryUnknown BatteryMode = iota
-	BatteryNormal
-	BatteryHold
-	BatteryCharge
+	BatteryUnknown     BatteryMode = iota
+	BatteryNormal                  // self-consumption
+	BatteryHold                    // no discharge
+	BatteryCharge                  // forced charge from grid
+	BatteryDischarge               // forced discharge to grid
+	BatteryChargeLimit             // self-consumption with limited charge power
 )
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/ocpp"
 	"github.com/evcc-io/evcc/plugin/mqtt"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server/eebus"
@@ -31,6 +32,7 @@ type All struct {
 	Go           []Go
 	Influx       Influx
 	EEBus        eebus.Config
+	Ocpp         ocpp.Config
 	HEMS         Hems
 	Messaging    Messaging
 	Meters       []config.Named
@@ -55,9 +57,19 @@ type Go struct {
 type ModbusProxy struct {
 	Port            int
 	ReadOnly        string
+	Rules           []ModbusProxyRule
 	modbus.Settings `mapstructure:",squash"`
 }
 
+// ModbusProxyRule is a proxy access rule for a register range
+type ModbusProxyRule struct {
+	Registers string        // single register or inclusive range like 1000-1010
+	Type      string        // holding (default), input, coil or discrete
+	Write     string        // allow, deny or ignore writes, defaults to readonly mode
+	Min, Max  *int          // clamp written holding register values, negative min treats values as signed
+	Cache     time.Duration // serve reads from cache
+}
+
 var _ api.Redactor = (*Hems)(nil)
 
 type Hems config.Typed
@@ -75,23 +87,26 @@ var _ api.Redactor = (*Mqtt)(nil)
 type Mqtt struct {
 	mqtt.Config `mapstructure:",squash"`
 	Topic       string `json:"topic"`
+	Discovery   string `json:"discovery,omitempty"` // Home Assistant discovery prefix, empty disables discovery
 }
 
 // Redacted implements the redactor interface used by the tee publisher
 func (m Mqtt) Redacted() any {
 	// TODO add masked password
 	return struct {
-		Broker   string `json:"broker"`
-		Topic    string `json:"topic"`
-		User     string `json:"user,omitempty"`
-		ClientID string `json:"clientID,omitempty"`
-		Insecure bool   `json:"insecure,omitempty"`
+		Broker    string `json:"broker"`
+		Topic     string `json:"topic"`
+		User      string `json:"user,omitempty"`
+		ClientID  string `json:"clientID,omitempty"`
+		Insecure  bool   `json:"insecure,omitempty"`
+		Discovery string `json:"discovery,omitempty"`
 	}{
-		Broker:   m.Broker,
-		Topic:    m.Topic,
-		User:     m.User,
-		ClientID: m.ClientID,
-		Insecure: m.Insecure,
+		Broker:    m.Broker,
+		Topic:     m.Topic,
+		User:      m.User,
+		ClientID:  m.ClientID,
+		Insecure:  m.Insecure,
+		Discovery: m.Discovery,
 	}
 }
 
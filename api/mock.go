@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit)
+// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,BatteryPowerController,Circuit)
 //
 // Generated by this command:
 //
-//	mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit
+//	mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,BatteryPowerController,Circuit
 //
 
 // Package api is a generated GoMock package.
@@ -768,6 +768,44 @@ func (mr *MockBatteryControllerMockRecorder) SetBatteryMode(arg0 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBatteryMode", reflect.TypeOf((*MockBatteryController)(nil).SetBatteryMode), arg0)
 }
 
+// MockBatteryPowerController is a mock of BatteryPowerController interface.
+type MockBatteryPowerController struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatteryPowerControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockBatteryPowerControllerMockRecorder is the mock recorder for MockBatteryPowerController.
+type MockBatteryPowerControllerMockRecorder struct {
+	mock *MockBatteryPowerController
+}
+
+// NewMockBatteryPowerController creates a new mock instance.
+func NewMockBatteryPowerController(ctrl *gomock.Controller) *MockBatteryPowerController {
+	mock := &MockBatteryPowerController{ctrl: ctrl}
+	mock.recorder = &MockBatteryPowerControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatteryPowerController) EXPECT() *MockBatteryPowerControllerMockRecorder {
+	return m.recorder
+}
+
+// SetBatteryModePower mocks base method.
+func (m *MockBatteryPowerController) SetBatteryModePower(arg0 BatteryMode, arg1 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBatteryModePower", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBatteryModePower indicates an expected call of SetBatteryModePower.
+func (mr *MockBatteryPowerControllerMockRecorder) SetBatteryModePower(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBatteryModePower", reflect.TypeOf((*MockBatteryPowerController)(nil).SetBatteryModePower), arg0, arg1)
+}
+
 // MockCircuit is a mock of Circuit interface.
 type MockCircuit struct {
 	ctrl     *gomock.Controller
@@ -820,6 +858,20 @@ func (mr *MockCircuitMockRecorder) GetMaxCurrent() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxCurrent", reflect.TypeOf((*MockCircuit)(nil).GetMaxCurrent))
 }
 
+// GetMaxImbalance mocks base method.
+func (m *MockCircuit) GetMaxImbalance() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxImbalance")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetMaxImbalance indicates an expected call of GetMaxImbalance.
+func (mr *MockCircuitMockRecorder) GetMaxImbalance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxImbalance", reflect.TypeOf((*MockCircuit)(nil).GetMaxImbalance))
+}
+
 // GetMaxPhaseCurrent mocks base method.
 func (m *MockCircuit) GetMaxPhaseCurrent() float64 {
 	m.ctrl.T.Helper()
@@ -862,6 +914,22 @@ func (mr *MockCircuitMockRecorder) GetParent() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParent", reflect.TypeOf((*MockCircuit)(nil).GetParent))
 }
 
+// GetPhaseCurrents mocks base method.
+func (m *MockCircuit) GetPhaseCurrents() (float64, float64, float64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhaseCurrents")
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(float64)
+	return ret0, ret1, ret2
+}
+
+// GetPhaseCurrents indicates an expected call of GetPhaseCurrents.
+func (mr *MockCircuitMockRecorder) GetPhaseCurrents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhaseCurrents", reflect.TypeOf((*MockCircuit)(nil).GetPhaseCurrents))
+}
+
 // GetTitle mocks base method.
 func (m *MockCircuit) GetTitle() string {
 	m.ctrl.T.Helper()
@@ -914,6 +982,18 @@ func (mr *MockCircuitMockRecorder) SetMaxCurrent(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxCurrent", reflect.TypeOf((*MockCircuit)(nil).SetMaxCurrent), arg0)
 }
 
+// SetMaxImbalance mocks base method.
+func (m *MockCircuit) SetMaxImbalance(arg0 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMaxImbalance", arg0)
+}
+
+// SetMaxImbalance indicates an expected call of SetMaxImbalance.
+func (mr *MockCircuitMockRecorder) SetMaxImbalance(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxImbalance", reflect.TypeOf((*MockCircuit)(nil).SetMaxImbalance), arg0)
+}
+
 // SetMaxPower mocks base method.
 func (m *MockCircuit) SetMaxPower(arg0 float64) {
 	m.ctrl.T.Helper()
@@ -966,6 +1046,20 @@ func (mr *MockCircuitMockRecorder) ValidateCurrent(old, new any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCurrent", reflect.TypeOf((*MockCircuit)(nil).ValidateCurrent), old, new)
 }
 
+// ValidatePhaseCurrent mocks base method.
+func (m *MockCircuit) ValidatePhaseCurrent(old, new float64, phases [3]bool) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePhaseCurrent", old, new, phases)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// ValidatePhaseCurrent indicates an expected call of ValidatePhaseCurrent.
+func (mr *MockCircuitMockRecorder) ValidatePhaseCurrent(old, new, phases any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePhaseCurrent", reflect.TypeOf((*MockCircuit)(nil).ValidatePhaseCurrent), old, new, phases)
+}
+
 // ValidatePower mocks base method.
 func (m *MockCircuit) ValidatePower(old, new float64) float64 {
 	m.ctrl.T.Helper()
@@ -44,6 +44,30 @@ func (r Rates) Current(now time.Time) (Rate, error) {
 		now.Local().Format(time.RFC3339), len(r), r[0].Start.Local().Format(time.RFC3339), r[len(r)-1].End.Local().Format(time.RFC3339))
 }
 
+// Average returns the time-weighted average price between start and end.
+// Periods not covered by any rate count as zero.
+func (r Rates) Average(start, end time.Time) float64 {
+	if !end.After(start) {
+		return 0
+	}
+
+	var sum float64
+	for _, rr := range r {
+		from, to := rr.Start, rr.End
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if to.After(from) {
+			sum += rr.Price * to.Sub(from).Hours()
+		}
+	}
+
+	return sum / end.Sub(start).Hours()
+}
+
 // MarshalMQTT implements server.MQTTMarshaler
 func (r Rates) MarshalMQTT() ([]byte, error) {
 	return json.Marshal(r)
@@ -19,14 +19,17 @@ package charger
 
 import (
 	"cmp"
+	"context"
 	"fmt"
 	"math"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/charger/ocpp"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
@@ -45,16 +48,29 @@ type OCPP struct {
 
 	stackLevelZero bool
 	lp             loadpoint.API
+
+	sendMu          sync.Mutex // serializes sending and storing the setpoint
+	mu              sync.Mutex
+	schedule        bool         // send charging plan as charging schedule
+	failsafeCurrent float64      // current applied when evcc stops updating the schedule
+	setpoint        float64      // last current sent to the charger
+	setpointSent    bool         // setpoint has been sent at least once
+	fallback        bool         // fallback profile installed
+	chargingPlan    planner.Plan // active charging plan pushed by the loadpoint
+
+	restrictedCurrent float64 // current limit for unknown id tags
+
+	upstream *ocpp.Upstream // third-party central system in proxy mode
 }
 
 const defaultIdTag = "evcc" // RemoteStartTransaction only
 
 func init() {
-	registry.Add("ocpp", NewOCPPFromConfig)
+	registry.AddCtx("ocpp", NewOCPPFromConfig)
 }
 
 // NewOCPPFromConfig creates a OCPP charger from generic config
-func NewOCPPFromConfig(other map[string]interface{}) (api.Charger, error) {
+func NewOCPPFromConfig(ctx context.Context, other map[string]interface{}) (api.Charger, error) {
 	cc := struct {
 		StationId      string
 		IdTag          string
@@ -70,12 +86,20 @@ func NewOCPPFromConfig(other map[string]interface{}) (api.Charger, error) {
 		AutoStart        bool                       // TODO deprecated
 		NoStop           bool                       // TODO deprecated
 
-		StackLevelZero *bool
-		RemoteStart    bool
+		StackLevelZero  *bool
+		RemoteStart     bool
+		Schedule        bool
+		FailsafeCurrent float64
+		Authorization   ocppAuthConfig
+		Upstream        ocppUpstreamConfig
 	}{
-		Connector:      1,
-		MeterInterval:  10 * time.Second,
-		ConnectTimeout: 5 * time.Minute,
+		Connector:       1,
+		MeterInterval:   10 * time.Second,
+		ConnectTimeout:  5 * time.Minute,
+		FailsafeCurrent: 6,
+		Authorization: ocppAuthConfig{
+			RestrictedCurrent: 6,
+		},
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -96,6 +120,18 @@ func NewOCPPFromConfig(other map[string]interface{}) (api.Charger, error) {
 		return nil, api.ErrSponsorRequired
 	}
 
+	if err := c.enableAuthorization(ctx, cc.Authorization); err != nil {
+		return nil, err
+	}
+
+	if cc.Upstream.URI != "" {
+		c.enableUpstream(ctx, cc.Upstream)
+	}
+
+	if cc.Schedule {
+		c.enableSchedule(ctx, cc.FailsafeCurrent)
+	}
+
 	var (
 		powerG, totalEnergyG, socG func() (float64, error)
 		currentsG, voltagesG       func() (float64, float64, float64, error)
@@ -298,18 +334,51 @@ func (c *OCPP) Enable(enable bool) error {
 
 // setCurrent sets the TxDefaultChargingProfile with given current
 func (c *OCPP) setCurrent(current float64) error {
-	err := c.conn.SetChargingProfileRequest(c.createTxDefaultChargingProfile(math.Trunc(10*current) / 10))
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	return c.sendCurrent(current)
+}
+
+// updateCurrent re-sends the last setpoint after limits or the schedule changed
+func (c *OCPP) updateCurrent() error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	c.mu.Lock()
+	setpoint := c.setpoint
+	c.mu.Unlock()
+
+	return c.sendCurrent(setpoint)
+}
+
+// sendCurrent sends the charging profile for the given current and stores the setpoint (no send mutex)
+func (c *OCPP) sendCurrent(current float64) error {
+	current = math.Trunc(10*current) / 10
+	limit := c.upstreamCurrent(c.restrictCurrent(current))
+
+	profile := c.createTxDefaultChargingProfile(limit)
+	if c.schedule {
+		profile = c.createScheduleChargingProfile(time.Now(), limit, c.plan())
+	}
+
+	err := c.conn.SetChargingProfileRequest(profile)
 	if err != nil {
-		err = fmt.Errorf("set charging profile: %w", err)
+		return fmt.Errorf("set charging profile: %w", err)
 	}
 
-	return err
+	c.mu.Lock()
+	c.setpoint = current
+	c.setpointSent = true
+	c.mu.Unlock()
+
+	return nil
 }
 
-// createTxDefaultChargingProfile returns a TxDefaultChargingProfile with given current
-func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingProfile {
+// chargingSchedulePeriod returns a schedule period starting at given offset (s) with given current
+func (c *OCPP) chargingSchedulePeriod(start int, current float64) types.ChargingSchedulePeriod {
 	phases := c.phases
-	period := types.NewChargingSchedulePeriod(0, current)
+	period := types.NewChargingSchedulePeriod(start, current)
 	if c.cp.ChargingRateUnit == types.ChargingRateUnitWatts {
 		// get (expectedly) active phases from loadpoint
 		if c.lp != nil {
@@ -318,7 +387,7 @@ func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingPr
 		if phases == 0 {
 			phases = 3
 		}
-		period = types.NewChargingSchedulePeriod(0, math.Trunc(230.0*current*float64(phases)))
+		period = types.NewChargingSchedulePeriod(start, math.Trunc(230.0*current*float64(phases)))
 	}
 
 	// OCPP assumes phases == 3 if not set
@@ -326,6 +395,13 @@ func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingPr
 		period.NumberPhases = &phases
 	}
 
+	return period
+}
+
+// createTxDefaultChargingProfile returns a TxDefaultChargingProfile with given current
+func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingProfile {
+	period := c.chargingSchedulePeriod(0, current)
+
 	res := &types.ChargingProfile{
 		ChargingProfileId:      c.cp.ChargingProfileId,
 		ChargingProfilePurpose: types.ChargingProfilePurposeTxDefaultProfile,
@@ -384,6 +460,18 @@ func (c *OCPP) Identify() (string, error) {
 	return c.conn.IdTag(), nil
 }
 
+var _ api.SignedMeterValues = (*OCPP)(nil)
+
+// SignedMeterValues implements the api.SignedMeterValues interface
+func (c *OCPP) SignedMeterValues() (string, string, error) {
+	start, stop := c.conn.SignedMeterValues()
+	if start == "" && stop == "" {
+		return "", "", api.ErrNotAvailable
+	}
+
+	return start, stop, nil
+}
+
 var _ api.Diagnosis = (*OCPP)(nil)
 
 // Diagnose implements the api.Diagnosis interface
@@ -398,6 +486,16 @@ func (c *OCPP) Diagnose() {
 		fmt.Printf("\t\tFirmwareVersion: %s\n", c.cp.BootNotificationResult.FirmwareVersion)
 	}
 
+	if m := c.cp.Maintenance(); m.Firmware != "" || m.Diagnostics != "" {
+		fmt.Printf("\tMaintenance:\n")
+		if m.Firmware != "" {
+			fmt.Printf("\t\tFirmware: %s (%s)\n", m.Firmware, m.FirmwareUpdated.Round(time.Second))
+		}
+		if m.Diagnostics != "" {
+			fmt.Printf("\t\tDiagnostics: %s %s (%s)\n", m.Diagnostics, m.DiagnosticsFile, m.DiagnosticsUpdated.Round(time.Second))
+		}
+	}
+
 	fmt.Printf("\tConfiguration:\n")
 	if resp, err := c.cp.GetConfigurationRequest(); err == nil {
 		// sort configuration keys for printing
@@ -0,0 +1,90 @@
+package ocpp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+)
+
+// AuthorizationMode determines how unknown id tags are handled
+type AuthorizationMode string
+
+const (
+	AuthorizationAccept     AuthorizationMode = ""           // accept all id tags
+	AuthorizationReject     AuthorizationMode = "reject"     // reject unknown id tags
+	AuthorizationRestricted AuthorizationMode = "restricted" // accept unknown id tags in restricted mode
+)
+
+// ParseAuthorizationMode parses the authorization mode
+func ParseAuthorizationMode(s string) (AuthorizationMode, error) {
+	switch mode := AuthorizationMode(strings.ToLower(s)); mode {
+	case AuthorizationAccept, AuthorizationReject, AuthorizationRestricted:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid authorization mode: %s", s)
+	}
+}
+
+// Authorization validates id tags against a whitelist of known identifiers
+type Authorization struct {
+	Unknown     AuthorizationMode
+	Identifiers func() []string // known id tags, may contain * placeholders
+}
+
+// Authorize returns the authorization status of the id tag and if charging is restricted
+func (a *Authorization) Authorize(idTag string) (types.AuthorizationStatus, bool) {
+	if a == nil || a.Unknown == AuthorizationAccept || a.Identifiers == nil || matchIdTag(idTag, a.Identifiers()) {
+		return types.AuthorizationStatusAccepted, false
+	}
+
+	if a.Unknown == AuthorizationRestricted {
+		return types.AuthorizationStatusAccepted, true
+	}
+
+	return types.AuthorizationStatusInvalid, false
+}
+
+// LocalList returns the known id tags suitable for the charge point's local authorization list
+func (a *Authorization) LocalList() []string {
+	if a == nil || a.Identifiers == nil {
+		return nil
+	}
+
+	var res []string
+	for _, id := range a.Identifiers() {
+		// placeholders cannot be matched by charge point, max length as per OCPP 1.6
+		if id != "" && !strings.Contains(id, "*") && len(id) <= 20 {
+			res = append(res, id)
+		}
+	}
+
+	return res
+}
+
+// matchIdTag checks if the id tag matches any identifier. Identifiers are matched case-insensitive and may contain * placeholders.
+func matchIdTag(idTag string, ids []string) bool {
+	if idTag == "" {
+		return false
+	}
+
+	for _, id := range ids {
+		if strings.EqualFold(idTag, id) {
+			return true
+		}
+	}
+
+	for _, id := range ids {
+		if !strings.Contains(id, "*") {
+			continue
+		}
+
+		re, err := regexp.Compile("(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(id), `\*`, ".*?") + "$")
+		if err == nil && re.MatchString(idTag) {
+			return true
+		}
+	}
+
+	return false
+}
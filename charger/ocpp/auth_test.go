@@ -0,0 +1,87 @@
+package ocpp
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+	ids := func() []string {
+		return []string{"known", "fleet-*", "0123456789abcdef0123456789"}
+	}
+
+	for _, tc := range []struct {
+		mode       AuthorizationMode
+		idTag      string
+		status     types.AuthorizationStatus
+		restricted bool
+	}{
+		{AuthorizationAccept, "unknown", types.AuthorizationStatusAccepted, false},
+		{AuthorizationReject, "KNOWN", types.AuthorizationStatusAccepted, false},
+		{AuthorizationReject, "fleet-42", types.AuthorizationStatusAccepted, false},
+		{AuthorizationReject, "unknown", types.AuthorizationStatusInvalid, false},
+		{AuthorizationReject, "", types.AuthorizationStatusInvalid, false},
+		{AuthorizationRestricted, "known", types.AuthorizationStatusAccepted, false},
+		{AuthorizationRestricted, "unknown", types.AuthorizationStatusAccepted, true},
+	} {
+		auth := &Authorization{Unknown: tc.mode, Identifiers: ids}
+
+		status, restricted := auth.Authorize(tc.idTag)
+		assert.Equal(t, tc.status, status, tc)
+		assert.Equal(t, tc.restricted, restricted, tc)
+	}
+
+	// no whitelist configured
+	status, restricted := (*Authorization)(nil).Authorize("unknown")
+	assert.Equal(t, types.AuthorizationStatusAccepted, status)
+	assert.False(t, restricted)
+}
+
+func TestAuthorizationLocalList(t *testing.T) {
+	auth := &Authorization{
+		Unknown: AuthorizationReject,
+		Identifiers: func() []string {
+			return []string{"known", "fleet-*", "", "0123456789abcdef0123456789"}
+		},
+	}
+
+	assert.Equal(t, []string{"known"}, auth.LocalList())
+}
+
+func TestParseAuthorizationMode(t *testing.T) {
+	mode, err := ParseAuthorizationMode("Restricted")
+	assert.NoError(t, err)
+	assert.Equal(t, AuthorizationRestricted, mode)
+
+	_, err = ParseAuthorizationMode("foo")
+	assert.Error(t, err)
+}
+
+func TestAuthorizeRemoteIdTag(t *testing.T) {
+	Instance()
+	cp := NewChargePoint(util.NewLogger("foo"), "abc")
+	cp.SetAuthorization(&Authorization{
+		Unknown:     AuthorizationReject,
+		Identifiers: func() []string { return nil },
+	})
+
+	_, err := NewConnector(util.NewLogger("foo"), 1, cp, "evcc")
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		idTag  string
+		status types.AuthorizationStatus
+	}{
+		{"evcc", types.AuthorizationStatusAccepted},
+		{"unknown", types.AuthorizationStatusInvalid},
+		{"", types.AuthorizationStatusInvalid},
+	} {
+		res, err := cp.OnAuthorize(&core.AuthorizeRequest{IdTag: tc.idTag})
+		assert.NoError(t, err)
+		assert.Equal(t, tc.status, res.IdTagInfo.Status, tc)
+	}
+}
@@ -27,8 +27,12 @@ type Connector struct {
 	meterUpdated time.Time
 	measurements map[types.Measurand]types.SampledValue
 
-	txnId int
-	idTag string
+	signedStart, signedStop string // signed meter values of the current or last transaction
+
+	txnId      int
+	idTag      string
+	restricted bool          // transaction authorized in restricted mode
+	restrictC  chan struct{} // signals restricted state changes
 
 	remoteIdTag string
 }
@@ -40,6 +44,7 @@ func NewConnector(log *util.Logger, id int, cp *CP, idTag string) (*Connector, e
 		id:           id,
 		clock:        clock.New(),
 		statusC:      make(chan struct{}, 1),
+		restrictC:    make(chan struct{}, 1),
 		measurements: make(map[types.Measurand]types.SampledValue),
 
 		remoteIdTag: idTag,
@@ -83,6 +88,25 @@ func (conn *Connector) IdTag() string {
 	return conn.idTag
 }
 
+// Restricted returns true if the current transaction was authorized in restricted mode
+func (conn *Connector) Restricted() bool {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	return conn.restricted
+}
+
+// SignedMeterValues returns the signed meter values (e.g. OCMF) of the current or last transaction
+func (conn *Connector) SignedMeterValues() (string, string) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	return conn.signedStart, conn.signedStop
+}
+
+// RestrictC signals start and end of restricted transactions
+func (conn *Connector) RestrictC() <-chan struct{} {
+	return conn.restrictC
+}
+
 // getScheduleLimit queries the current or power limit the charge point is currently set to offer
 func (conn *Connector) GetScheduleLimit(duration int) (float64, error) {
 	schedule, err := conn.cp.GetCompositeScheduleRequest(conn.id, duration)
@@ -78,6 +78,11 @@ func (conn *Connector) OnMeterValues(request *core.MeterValuesRequest) (*core.Me
 		// ignore old meter value requests
 		if !meterValue.Timestamp.Time.Before(conn.meterUpdated) {
 			for _, sample := range meterValue.SampledValue {
+				if sample.Format == types.ValueFormatSignedData {
+					conn.updateSignedMeterValue(sample)
+					continue
+				}
+
 				sample.Value = strings.TrimSpace(sample.Value)
 				conn.measurements[getSampleKey(sample)] = sample
 				conn.meterUpdated = meterValue.Timestamp.Time
@@ -89,15 +94,31 @@ func (conn *Connector) OnMeterValues(request *core.MeterValuesRequest) (*core.Me
 }
 
 func (conn *Connector) OnStartTransaction(request *core.StartTransactionRequest) (*core.StartTransactionConfirmation, error) {
+	// authorization may involve the upstream central system, resolve before locking
+	status, restricted := types.AuthorizationStatusAccepted, false
+	if request.IdTag == "" || request.IdTag != conn.remoteIdTag {
+		status, restricted = conn.cp.authorize(request.IdTag)
+	}
+
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 
+	// transaction id is required even if rejected, charge point will stop the transaction
 	conn.txnId = int(instance.txnId.Add(1))
-	conn.idTag = request.IdTag
+	conn.restricted = restricted
+	conn.signedStart, conn.signedStop = "", ""
+
+	if status == types.AuthorizationStatusAccepted {
+		conn.idTag = request.IdTag
+	}
+
+	if restricted {
+		conn.signalRestricted()
+	}
 
 	res := &core.StartTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
-			Status: types.AuthorizationStatusAccepted,
+			Status: status,
 		},
 		TransactionId: conn.txnId,
 	}
@@ -105,6 +126,29 @@ func (conn *Connector) OnStartTransaction(request *core.StartTransactionRequest)
 	return res, nil
 }
 
+// updateSignedMeterValue records signed transaction begin and end meter values.
+// Intermediate readings are not suitable for verifying the transaction and are ignored.
+func (conn *Connector) updateSignedMeterValue(sample types.SampledValue) {
+	if sample.Measurand != "" && sample.Measurand != types.MeasurandEnergyActiveImportRegister {
+		return
+	}
+
+	switch sample.Context {
+	case types.ReadingContextTransactionBegin:
+		conn.signedStart = sample.Value
+	case types.ReadingContextTransactionEnd:
+		conn.signedStop = sample.Value
+	}
+}
+
+// signalRestricted notifies about restricted state changes
+func (conn *Connector) signalRestricted() {
+	select {
+	case conn.restrictC <- struct{}{}:
+	default:
+	}
+}
+
 func (conn *Connector) assumeMeterStopped() {
 	conn.meterUpdated = conn.clock.Now()
 
@@ -129,9 +173,22 @@ func (conn *Connector) OnStopTransaction(request *core.StopTransactionRequest) (
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 
+	if conn.restricted {
+		conn.restricted = false
+		conn.signalRestricted()
+	}
+
 	conn.txnId = 0
 	conn.idTag = ""
 
+	for _, meterValue := range request.TransactionData {
+		for _, sample := range meterValue.SampledValue {
+			if sample.Format == types.ValueFormatSignedData {
+				conn.updateSignedMeterValue(sample)
+			}
+		}
+	}
+
 	res := &core.StopTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
 			Status: types.AuthorizationStatusAccepted, // accept
@@ -7,6 +7,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 	"github.com/stretchr/testify/suite"
 )
@@ -151,3 +152,51 @@ func (suite *connTestSuite) TestConnectorMeasurementsRunningTxn() {
 	_, _, _, err = suite.conn.Voltages()
 	suite.NoError(err, "Voltages")
 }
+
+func (suite *connTestSuite) TestConnectorSignedMeterValues() {
+	_, err := suite.conn.OnStartTransaction(&core.StartTransactionRequest{ConnectorId: 1, IdTag: "tag"})
+	suite.Require().NoError(err)
+
+	_, err = suite.conn.OnMeterValues(&core.MeterValuesRequest{
+		ConnectorId: 1,
+		MeterValue: []types.MeterValue{{
+			Timestamp: types.NewDateTime(suite.clock.Now()),
+			SampledValue: []types.SampledValue{
+				{Measurand: types.MeasurandEnergyActiveImportRegister, Value: "1.2", Unit: types.UnitOfMeasureKWh},
+				{Measurand: types.MeasurandEnergyActiveImportRegister, Value: "OCMF|begin", Format: types.ValueFormatSignedData, Context: types.ReadingContextTransactionBegin},
+				{Measurand: types.MeasurandEnergyActiveImportRegister, Value: "OCMF|periodic", Format: types.ValueFormatSignedData, Context: types.ReadingContextSamplePeriodic},
+			},
+		}},
+	})
+	suite.Require().NoError(err)
+
+	// signed data does not replace the plain reading
+	energy, err := suite.conn.TotalEnergy()
+	suite.Require().NoError(err)
+	suite.Equal(1.2, energy)
+
+	// intermediate readings are not recorded as stop value
+	_, stop := suite.conn.SignedMeterValues()
+	suite.Empty(stop)
+
+	_, err = suite.conn.OnStopTransaction(&core.StopTransactionRequest{
+		TransactionData: []types.MeterValue{{
+			SampledValue: []types.SampledValue{
+				{Value: "OCMF|end", Format: types.ValueFormatSignedData, Context: types.ReadingContextTransactionEnd},
+			},
+		}},
+	})
+	suite.Require().NoError(err)
+
+	start, stop := suite.conn.SignedMeterValues()
+	suite.Equal("OCMF|begin", start)
+	suite.Equal("OCMF|end", stop)
+
+	// reset on next transaction
+	_, err = suite.conn.OnStartTransaction(&core.StartTransactionRequest{ConnectorId: 1, IdTag: "tag"})
+	suite.Require().NoError(err)
+
+	start, stop = suite.conn.SignedMeterValues()
+	suite.Empty(start)
+	suite.Empty(stop)
+}
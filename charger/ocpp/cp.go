@@ -1,6 +1,7 @@
 package ocpp
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -29,9 +30,15 @@ type CP struct {
 	ChargingRateUnit        types.ChargingRateUnitType
 	ChargingProfileId       int
 	StackLevel              int
+	MaxPeriods              int
 	NumberOfConnectors      int
 	IdTag                   string
 
+	auth *Authorization // id tag whitelist
+
+	maintenance Maintenance // firmware and diagnostics state
+	upstream    *Upstream   // proxy connection to third-party central system
+
 	meterValuesSample        string
 	bootNotificationRequestC chan *core.BootNotificationRequest
 	BootNotificationResult   *core.BootNotificationRequest
@@ -105,6 +112,89 @@ func (cp *CP) RegisterID(id string) {
 	cp.id = id
 }
 
+// SetAuthorization configures id tag validation
+func (cp *CP) SetAuthorization(auth *Authorization) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.auth = auth
+}
+
+// EnableUpstream connects the charge point to a third-party central system unless already connected.
+// The connection is removed once the context is cancelled.
+func (cp *CP) EnableUpstream(ctx context.Context, uri, id, password string) *Upstream {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if cp.upstream == nil {
+		u := NewUpstream(ctx, cp, uri, id, password)
+		cp.upstream = u
+
+		go func() {
+			<-u.Done()
+
+			cp.mu.Lock()
+			if cp.upstream == u {
+				cp.upstream = nil
+			}
+			cp.mu.Unlock()
+		}()
+	}
+
+	return cp.upstream
+}
+
+// Upstream returns the third-party central system connection if configured
+func (cp *CP) Upstream() *Upstream {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.upstream
+}
+
+// isRemoteIdTag returns true if the id tag is used by evcc for remote starting transactions
+func (cp *CP) isRemoteIdTag(idTag string) bool {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	for _, conn := range cp.connectors {
+		if idTag != "" && idTag == conn.remoteIdTag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authorize validates the id tag. Upstream authorization takes precedence unless unavailable.
+func (cp *CP) authorize(idTag string) (types.AuthorizationStatus, bool) {
+	cp.mu.RLock()
+	auth := cp.auth
+	upstream := cp.upstream
+	cp.mu.RUnlock()
+
+	if upstream != nil {
+		status, err := upstream.Authorize(idTag)
+		if err == nil {
+			if status != types.AuthorizationStatusAccepted {
+				cp.log.WARN.Printf("upstream rejected id tag: %s", idTag)
+			}
+			return status, false
+		}
+
+		cp.log.WARN.Printf("upstream authorization failed, using local authorization: %v", err)
+	}
+
+	status, restricted := auth.Authorize(idTag)
+	if status != types.AuthorizationStatusAccepted {
+		cp.log.WARN.Printf("rejected unknown id tag: %s", idTag)
+	} else if restricted {
+		cp.log.DEBUG.Printf("restricted unknown id tag: %s", idTag)
+	}
+
+	return status, restricted
+}
+
 func (cp *CP) connect(connect bool) {
 	cp.mu.Lock()
 	defer cp.mu.Unlock()
@@ -0,0 +1,285 @@
+package ocpp
+
+import (
+	"errors"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	localauth201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	smartcharging201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/samber/lo"
+)
+
+// isV201 returns true if the charge point connected using OCPP 2.0.1
+func (cp *CP) isV201() bool {
+	return Instance().Protocol(cp.ID()) == types201.V201Subprotocol
+}
+
+// evse201 returns the EVSE addressed by connector id, zero addresses the charging station
+func evse201(connectorId int) *types201.EVSE {
+	if connectorId > 0 {
+		return &types201.EVSE{ID: connectorId}
+	}
+	return nil
+}
+
+func (cp *CP) changeAvailability201(connectorId int, availabilityType core.AvailabilityType) error {
+	status := availability.OperationalStatusOperative
+	if availabilityType == core.AvailabilityTypeInoperative {
+		status = availability.OperationalStatusInoperative
+	}
+
+	rc := make(chan error, 1)
+
+	err := Instance().csms.ChangeAvailability(cp.id, func(request *availability.ChangeAvailabilityResponse, err error) {
+		if err == nil && request != nil && request.Status != availability.ChangeAvailabilityStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, status, func(request *availability.ChangeAvailabilityRequest) {
+		request.Evse = evse201(connectorId)
+	})
+
+	return wait(err, rc)
+}
+
+func (cp *CP) getCompositeSchedule201(connectorId int, duration int) (*smartcharging.GetCompositeScheduleConfirmation, error) {
+	var res *smartcharging.GetCompositeScheduleConfirmation
+	rc := make(chan error, 1)
+
+	err := Instance().csms.GetCompositeSchedule(cp.id, func(request *smartcharging201.GetCompositeScheduleResponse, err error) {
+		if err == nil && request != nil && request.Status != smartcharging201.GetCompositeScheduleStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		if err == nil && request != nil {
+			res = &smartcharging.GetCompositeScheduleConfirmation{
+				Status:      smartcharging.GetCompositeScheduleStatusAccepted,
+				ConnectorId: &request.EvseID,
+			}
+
+			if schedule := request.Schedule; schedule != nil && schedule.ChargingSchedule != nil {
+				res.ChargingSchedule = chargingSchedule16(schedule.ChargingSchedule)
+				if schedule.StartDateTime != nil {
+					res.ScheduleStart = types.NewDateTime(schedule.StartDateTime.Time)
+				}
+			}
+		}
+
+		rc <- err
+	}, duration, connectorId)
+
+	return res, wait(err, rc)
+}
+
+func (cp *CP) remoteStartTransaction201(connectorId int, idTag string) error {
+	rc := make(chan error, 1)
+
+	idToken := types201.IdToken{
+		IdToken: idTag,
+		Type:    types201.IdTokenTypeCentral,
+	}
+
+	err := Instance().csms.RequestStartTransaction(cp.id, func(request *remotecontrol.RequestStartTransactionResponse, err error) {
+		if err == nil && request != nil && request.Status != remotecontrol.RequestStartStopStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, int(Instance().txnId.Add(1)), idToken, func(request *remotecontrol.RequestStartTransactionRequest) {
+		if connectorId > 0 {
+			request.EvseID = &connectorId
+		}
+	})
+
+	return wait(err, rc)
+}
+
+func (cp *CP) setChargingProfile201(connectorId int, profile *types.ChargingProfile) error {
+	rc := make(chan error, 1)
+
+	err := Instance().csms.SetChargingProfile(cp.id, func(request *smartcharging201.SetChargingProfileResponse, err error) {
+		if err == nil && request != nil && request.Status != smartcharging201.ChargingProfileStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, connectorId, chargingProfile201(profile))
+
+	return wait(err, rc)
+}
+
+func (cp *CP) triggerMessage201(connectorId int, requestedMessage remotetrigger.MessageTrigger) error {
+	rc := make(chan error, 1)
+
+	err := Instance().csms.TriggerMessage(cp.id, func(request *remotecontrol.TriggerMessageResponse, err error) {
+		if err == nil && request != nil && request.Status != remotecontrol.TriggerMessageStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, remotecontrol.MessageTrigger(requestedMessage), func(request *remotecontrol.TriggerMessageRequest) {
+		request.Evse = evse201(connectorId)
+
+		// status notification is triggered per connector, evcc treats every evse as single connector
+		if request.Evse != nil && requestedMessage == core.StatusNotificationFeatureName {
+			request.Evse.ConnectorID = lo.ToPtr(1)
+		}
+	})
+
+	return wait(err, rc)
+}
+
+func (cp *CP) GetVariablesRequest(data []provisioning.GetVariableData) ([]provisioning.GetVariableResult, error) {
+	var res []provisioning.GetVariableResult
+	rc := make(chan error, 1)
+
+	err := Instance().csms.GetVariables(cp.id, func(request *provisioning.GetVariablesResponse, err error) {
+		if err == nil && request != nil {
+			res = request.GetVariableResult
+		}
+
+		rc <- err
+	}, data)
+
+	return res, wait(err, rc)
+}
+
+func (cp *CP) SetVariablesRequest(data []provisioning.SetVariableData) ([]provisioning.SetVariableResult, error) {
+	var res []provisioning.SetVariableResult
+	rc := make(chan error, 1)
+
+	err := Instance().csms.SetVariables(cp.id, func(request *provisioning.SetVariablesResponse, err error) {
+		if err == nil && request != nil {
+			res = request.SetVariableResult
+		}
+
+		rc <- err
+	}, data)
+
+	return res, wait(err, rc)
+}
+
+// SetVariableRequest sets a single component variable
+func (cp *CP) SetVariableRequest(component, variable, value string) error {
+	res, err := cp.SetVariablesRequest([]provisioning.SetVariableData{{
+		Component:      types201.Component{Name: component},
+		Variable:       types201.Variable{Name: variable},
+		AttributeValue: value,
+	}})
+
+	if err == nil && len(res) > 0 && res[0].AttributeStatus != provisioning.SetVariableStatusAccepted {
+		err = errors.New(string(res[0].AttributeStatus))
+	}
+
+	return err
+}
+
+func (cp *CP) getLocalListVersion201() (int, error) {
+	var res int
+	rc := make(chan error, 1)
+
+	err := Instance().csms.GetLocalListVersion(cp.id, func(request *localauth201.GetLocalListVersionResponse, err error) {
+		if err == nil && request != nil {
+			res = request.VersionNumber
+		}
+
+		rc <- err
+	})
+
+	return res, wait(err, rc)
+}
+
+func (cp *CP) sendLocalList201(version int, idTags []string) error {
+	rc := make(chan error, 1)
+
+	err := Instance().csms.SendLocalList(cp.id, func(request *localauth201.SendLocalListResponse, err error) {
+		if err == nil && request != nil && request.Status != localauth201.SendLocalListStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, version, localauth201.UpdateTypeFull, func(request *localauth201.SendLocalListRequest) {
+		for _, idTag := range idTags {
+			request.LocalAuthorizationList = append(request.LocalAuthorizationList, localauth201.AuthorizationData{
+				IdToken:     types201.IdToken{IdToken: idTag, Type: types201.IdTokenTypeISO14443},
+				IdTokenInfo: types201.NewIdTokenInfo(types201.AuthorizationStatusAccepted),
+			})
+		}
+	})
+
+	return wait(err, rc)
+}
+
+// chargingProfile201 converts a charging profile to OCPP 2.0.1
+func chargingProfile201(profile *types.ChargingProfile) *types201.ChargingProfile {
+	res := &types201.ChargingProfile{
+		ID:                     profile.ChargingProfileId,
+		StackLevel:             profile.StackLevel,
+		ChargingProfilePurpose: types201.ChargingProfilePurposeType(profile.ChargingProfilePurpose),
+		ChargingProfileKind:    types201.ChargingProfileKindType(profile.ChargingProfileKind),
+		RecurrencyKind:         types201.RecurrencyKindType(profile.RecurrencyKind),
+	}
+
+	if profile.ValidFrom != nil {
+		res.ValidFrom = types201.NewDateTime(profile.ValidFrom.Time)
+	}
+	if profile.ValidTo != nil {
+		res.ValidTo = types201.NewDateTime(profile.ValidTo.Time)
+	}
+
+	if s := profile.ChargingSchedule; s != nil {
+		schedule := types201.ChargingSchedule{
+			ID:               profile.ChargingProfileId,
+			Duration:         s.Duration,
+			ChargingRateUnit: types201.ChargingRateUnitType(s.ChargingRateUnit),
+			MinChargingRate:  s.MinChargingRate,
+		}
+
+		if s.StartSchedule != nil {
+			schedule.StartSchedule = types201.NewDateTime(s.StartSchedule.Time)
+		}
+
+		for _, p := range s.ChargingSchedulePeriod {
+			schedule.ChargingSchedulePeriod = append(schedule.ChargingSchedulePeriod, types201.ChargingSchedulePeriod{
+				StartPeriod:  p.StartPeriod,
+				Limit:        p.Limit,
+				NumberPhases: p.NumberPhases,
+			})
+		}
+
+		res.ChargingSchedule = []types201.ChargingSchedule{schedule}
+	}
+
+	return res
+}
+
+// chargingSchedule16 converts a charging schedule to OCPP 1.6
+func chargingSchedule16(s *types201.ChargingSchedule) *types.ChargingSchedule {
+	res := &types.ChargingSchedule{
+		Duration:         s.Duration,
+		ChargingRateUnit: types.ChargingRateUnitType(s.ChargingRateUnit),
+		MinChargingRate:  s.MinChargingRate,
+	}
+
+	if s.StartSchedule != nil {
+		res.StartSchedule = types.NewDateTime(s.StartSchedule.Time)
+	}
+
+	for _, p := range s.ChargingSchedulePeriod {
+		res.ChargingSchedulePeriod = append(res.ChargingSchedulePeriod, types.ChargingSchedulePeriod{
+			StartPeriod:  p.StartPeriod,
+			Limit:        p.Limit,
+			NumberPhases: p.NumberPhases,
+		})
+	}
+
+	return res
+}
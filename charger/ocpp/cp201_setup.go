@@ -0,0 +1,145 @@
+package ocpp
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// OCPP 2.0.1 device model components and variables
+const (
+	ComponentSmartChargingCtrlr = "SmartChargingCtrlr"
+	ComponentSampledDataCtrlr   = "SampledDataCtrlr"
+	ComponentAlignedDataCtrlr   = "AlignedDataCtrlr"
+	ComponentOCPPCommCtrlr      = "OCPPCommCtrlr"
+
+	VariableProfileStackLevel         = "ProfileStackLevel"
+	VariablePeriodsPerSchedule        = "PeriodsPerSchedule"
+	VariableRateUnit                  = "RateUnit"
+	VariableACPhaseSwitchingSupported = "ACPhaseSwitchingSupported"
+	VariableTxUpdatedMeasurands       = "TxUpdatedMeasurands"
+	VariableTxUpdatedInterval         = "TxUpdatedInterval"
+	VariableMeasurands                = "Measurands"
+	VariableInterval                  = "Interval"
+	VariableWebSocketPingInterval     = "WebSocketPingInterval"
+)
+
+// setup201 configures an OCPP 2.0.1 charging station using its device model
+func (cp *CP) setup201(meterValues string, meterInterval time.Duration) error {
+	if err := cp.ChangeAvailabilityRequest(0, core.AvailabilityTypeOperative); err != nil {
+		cp.log.DEBUG.Printf("failed configuring availability: %v", err)
+	}
+
+	// auto configuration
+	desiredMeasurands, meterValues := autoMeasurands(meterValues)
+
+	var data []provisioning.GetVariableData
+	for _, variable := range []string{
+		VariableProfileStackLevel, VariablePeriodsPerSchedule, VariableRateUnit, VariableACPhaseSwitchingSupported,
+	} {
+		data = append(data, provisioning.GetVariableData{
+			Component: types201.Component{Name: ComponentSmartChargingCtrlr},
+			Variable:  types201.Variable{Name: variable},
+		})
+	}
+
+	res, err := cp.GetVariablesRequest(data)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range res {
+		if v.AttributeStatus != provisioning.GetVariableStatusAccepted {
+			continue
+		}
+
+		switch val := v.AttributeValue; v.Variable.Name {
+		case VariableProfileStackLevel:
+			if val, err := strconv.Atoi(val); err == nil {
+				cp.StackLevel = val
+			}
+
+		case VariablePeriodsPerSchedule:
+			if val, err := strconv.Atoi(val); err == nil {
+				cp.MaxPeriods = val
+			}
+
+		case VariableRateUnit:
+			if !hasProperty(val, string(types201.ChargingRateUnitAmperes)) && hasProperty(val, string(types201.ChargingRateUnitWatts)) {
+				cp.ChargingRateUnit = types.ChargingRateUnitWatts
+			}
+
+		case VariableACPhaseSwitchingSupported:
+			if val, err := strconv.ParseBool(val); err == nil {
+				cp.PhaseSwitching = val
+			}
+		}
+	}
+
+	// see who's there
+	if err := cp.TriggerMessageRequest(0, core.BootNotificationFeatureName); err != nil {
+		cp.log.DEBUG.Printf("failed triggering BootNotification: %v", err)
+	}
+
+	select {
+	case <-time.After(Timeout):
+		cp.log.DEBUG.Printf("BootNotification timeout")
+	case res := <-cp.bootNotificationRequestC:
+		cp.BootNotificationResult = res
+	}
+
+	// autodetect measurands
+	if meterValues == "" {
+		var accepted []string
+		for _, m := range strings.Split(desiredMeasurands, ",") {
+			if err := cp.SetVariableRequest(ComponentSampledDataCtrlr, VariableTxUpdatedMeasurands, m); err == nil {
+				accepted = append(accepted, m)
+			}
+		}
+		meterValues = strings.Join(accepted, ",")
+	}
+
+	// configure measurands
+	if meterValues != "" {
+		if err := cp.SetVariableRequest(ComponentSampledDataCtrlr, VariableTxUpdatedMeasurands, meterValues); err != nil {
+			cp.log.WARN.Printf("failed configuring %s: %v", VariableTxUpdatedMeasurands, err)
+		}
+		if err := cp.SetVariableRequest(ComponentAlignedDataCtrlr, VariableMeasurands, meterValues); err != nil {
+			cp.log.DEBUG.Printf("failed configuring %s: %v", VariableMeasurands, err)
+		}
+		cp.meterValuesSample = meterValues
+	}
+
+	// trigger initial meter values
+	if err := cp.TriggerMessageRequest(0, core.MeterValuesFeatureName); err == nil {
+		// wait for meter values
+		select {
+		case <-time.After(Timeout):
+			cp.log.WARN.Println("meter timeout")
+		case <-cp.meterC:
+		}
+	}
+
+	// configure sample rate
+	if meterInterval > 0 {
+		interval := strconv.Itoa(int(meterInterval.Seconds()))
+		if err := cp.SetVariableRequest(ComponentSampledDataCtrlr, VariableTxUpdatedInterval, interval); err != nil {
+			cp.log.WARN.Printf("failed configuring %s: %v", VariableTxUpdatedInterval, err)
+		}
+		if err := cp.SetVariableRequest(ComponentAlignedDataCtrlr, VariableInterval, interval); err != nil {
+			cp.log.DEBUG.Printf("failed configuring %s: %v", VariableInterval, err)
+		}
+	}
+
+	// configure websocket ping interval
+	if err := cp.SetVariableRequest(ComponentOCPPCommCtrlr, VariableWebSocketPingInterval, "30"); err != nil {
+		cp.log.DEBUG.Printf("failed configuring %s: %v", VariableWebSocketPingInterval, err)
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ var (
 	ErrInvalidRequest     = errors.New("invalid request")
 	ErrInvalidConnector   = errors.New("invalid connector")
 	ErrInvalidTransaction = errors.New("invalid transaction")
+	ErrNotSupported       = errors.New("not supported")
 )
 
 func (cp *CP) OnBootNotification(request *core.BootNotificationRequest) (*core.BootNotificationConfirmation, error) {
@@ -24,6 +25,28 @@ func (cp *CP) OnBootNotification(request *core.BootNotificationRequest) (*core.B
 		cp.bootNotificationRequestC <- request
 	})
 
+	cp.Upstream().BootNotification(request)
+
+	return res, nil
+}
+
+func (cp *CP) OnAuthorize(request *core.AuthorizeRequest) (*core.AuthorizeConfirmation, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	// accept remote start using evcc's own id tag
+	status := types.AuthorizationStatusAccepted
+	if !cp.isRemoteIdTag(request.IdTag) {
+		status, _ = cp.authorize(request.IdTag)
+	}
+
+	res := &core.AuthorizeConfirmation{
+		IdTagInfo: &types.IdTagInfo{
+			Status: status,
+		},
+	}
+
 	return res, nil
 }
 
@@ -32,6 +55,8 @@ func (cp *CP) OnStatusNotification(request *core.StatusNotificationRequest) (*co
 		return nil, ErrInvalidRequest
 	}
 
+	cp.Upstream().StatusNotification(request)
+
 	if conn := cp.connectorByID(request.ConnectorId); conn != nil {
 		return conn.OnStatusNotification(request)
 	}
@@ -50,6 +75,8 @@ func (cp *CP) OnMeterValues(request *core.MeterValuesRequest) (*core.MeterValues
 	default:
 	}
 
+	cp.Upstream().MeterValues(request)
+
 	if conn := cp.connectorByID(request.ConnectorId); conn != nil {
 		conn.OnMeterValues(request)
 	}
@@ -63,7 +90,12 @@ func (cp *CP) OnStartTransaction(request *core.StartTransactionRequest) (*core.S
 	}
 
 	if conn := cp.connectorByID(request.ConnectorId); conn != nil {
-		return conn.OnStartTransaction(request)
+		res, err := conn.OnStartTransaction(request)
+		if err == nil {
+			cp.Upstream().StartTransaction(request, res.TransactionId)
+		}
+
+		return res, err
 	}
 
 	res := &core.StartTransactionConfirmation{
@@ -80,6 +112,8 @@ func (cp *CP) OnStopTransaction(request *core.StopTransactionRequest) (*core.Sto
 		return nil, ErrInvalidRequest
 	}
 
+	cp.Upstream().StopTransaction(request)
+
 	if conn := cp.connectorByTransactionID(request.TransactionId); conn != nil {
 		return conn.OnStopTransaction(request)
 	}
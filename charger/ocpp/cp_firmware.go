@@ -0,0 +1,146 @@
+package ocpp
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	firmware201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/firmware"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// Maintenance is the charge point's firmware update and diagnostics upload state
+type Maintenance struct {
+	Firmware           string    `json:"firmware,omitempty"`          // last firmware status
+	FirmwareUpdated    time.Time `json:"firmwareUpdated,omitzero"`    // last firmware status timestamp
+	Diagnostics        string    `json:"diagnostics,omitempty"`       // last diagnostics status
+	DiagnosticsFile    string    `json:"diagnosticsFile,omitempty"`   // requested diagnostics file name
+	DiagnosticsUpdated time.Time `json:"diagnosticsUpdated,omitzero"` // last diagnostics status timestamp
+}
+
+// Maintenance returns the charge point's firmware update and diagnostics upload state
+func (cp *CP) Maintenance() Maintenance {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.maintenance
+}
+
+// firmwareStatus updates the firmware status
+func (cp *CP) firmwareStatus(status string) {
+	cp.log.DEBUG.Printf("firmware status: %s", status)
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.maintenance.Firmware = status
+	cp.maintenance.FirmwareUpdated = time.Now()
+}
+
+// diagnosticsStatus updates the diagnostics status
+func (cp *CP) diagnosticsStatus(status string) {
+	cp.log.DEBUG.Printf("diagnostics status: %s", status)
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.maintenance.Diagnostics = status
+	cp.maintenance.DiagnosticsUpdated = time.Now()
+}
+
+func (cp *CP) OnFirmwareStatusNotification(request *firmware.FirmwareStatusNotificationRequest) (*firmware.FirmwareStatusNotificationConfirmation, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	cp.firmwareStatus(string(request.Status))
+
+	return new(firmware.FirmwareStatusNotificationConfirmation), nil
+}
+
+func (cp *CP) OnDiagnosticsStatusNotification(request *firmware.DiagnosticsStatusNotificationRequest) (*firmware.DiagnosticsStatusNotificationConfirmation, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	cp.diagnosticsStatus(string(request.Status))
+
+	return new(firmware.DiagnosticsStatusNotificationConfirmation), nil
+}
+
+// UpdateFirmwareRequest instructs the charge point to download and install firmware from location
+func (cp *CP) UpdateFirmwareRequest(location string, retrieveDate time.Time) error {
+	if retrieveDate.IsZero() {
+		retrieveDate = time.Now()
+	}
+
+	rc := make(chan error, 1)
+
+	var err error
+	if cp.isV201() {
+		fw := firmware201.Firmware{
+			Location:         location,
+			RetrieveDateTime: types201.NewDateTime(retrieveDate),
+		}
+
+		err = Instance().csms.UpdateFirmware(cp.id, func(request *firmware201.UpdateFirmwareResponse, err error) {
+			if err == nil && request != nil && request.Status != firmware201.UpdateFirmwareStatusAccepted {
+				err = errors.New(string(request.Status))
+			}
+
+			rc <- err
+		}, int(Instance().txnId.Add(1)), fw)
+	} else {
+		err = Instance().UpdateFirmware(cp.id, func(request *firmware.UpdateFirmwareConfirmation, err error) {
+			rc <- err
+		}, location, types.NewDateTime(retrieveDate))
+	}
+
+	if err = wait(err, rc); err == nil {
+		cp.firmwareStatus("Requested")
+	}
+
+	return err
+}
+
+// GetDiagnosticsRequest instructs the charge point to upload diagnostics to location and returns the file name
+func (cp *CP) GetDiagnosticsRequest(location string) (string, error) {
+	var res string
+	rc := make(chan error, 1)
+
+	var err error
+	if cp.isV201() {
+		err = Instance().csms.GetLog(cp.id, func(request *diagnostics.GetLogResponse, err error) {
+			if err == nil && request != nil {
+				if request.Status == diagnostics.LogStatusRejected {
+					err = errors.New(string(request.Status))
+				}
+				res = request.Filename
+			}
+
+			rc <- err
+		}, diagnostics.LogTypeDiagnostics, int(Instance().txnId.Add(1)), diagnostics.LogParameters{
+			RemoteLocation: location,
+		})
+	} else {
+		err = Instance().GetDiagnostics(cp.id, func(request *firmware.GetDiagnosticsConfirmation, err error) {
+			if err == nil && request != nil {
+				res = request.FileName
+			}
+
+			rc <- err
+		}, location)
+	}
+
+	if err = wait(err, rc); err == nil {
+		cp.diagnosticsStatus("Requested")
+
+		cp.mu.Lock()
+		cp.maintenance.DiagnosticsFile = res
+		cp.mu.Unlock()
+	}
+
+	return res, err
+}
@@ -4,12 +4,17 @@ import (
 	"errors"
 
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 )
 
 func (cp *CP) ChangeAvailabilityRequest(connectorId int, availabilityType core.AvailabilityType) error {
+	if cp.isV201() {
+		return cp.changeAvailability201(connectorId, availabilityType)
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().ChangeAvailability(cp.id, func(request *core.ChangeAvailabilityConfirmation, err error) {
@@ -24,6 +29,10 @@ func (cp *CP) ChangeAvailabilityRequest(connectorId int, availabilityType core.A
 }
 
 func (cp *CP) GetCompositeScheduleRequest(connectorId int, duration int) (*smartcharging.GetCompositeScheduleConfirmation, error) {
+	if cp.isV201() {
+		return cp.getCompositeSchedule201(connectorId, duration)
+	}
+
 	var res *smartcharging.GetCompositeScheduleConfirmation
 	rc := make(chan error, 1)
 
@@ -41,6 +50,10 @@ func (cp *CP) GetCompositeScheduleRequest(connectorId int, duration int) (*smart
 }
 
 func (cp *CP) RemoteStartTransactionRequest(connectorId int, idTag string) error {
+	if cp.isV201() {
+		return cp.remoteStartTransaction201(connectorId, idTag)
+	}
+
 	rc := make(chan error, 1)
 	err := Instance().RemoteStartTransaction(cp.id, func(request *core.RemoteStartTransactionConfirmation, err error) {
 		if err == nil && request != nil && request.Status != types.RemoteStartStopStatusAccepted {
@@ -58,6 +71,10 @@ func (cp *CP) RemoteStartTransactionRequest(connectorId int, idTag string) error
 }
 
 func (cp *CP) SetChargingProfileRequest(connectorId int, profile *types.ChargingProfile) error {
+	if cp.isV201() {
+		return cp.setChargingProfile201(connectorId, profile)
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().SetChargingProfile(cp.id, func(request *smartcharging.SetChargingProfileConfirmation, err error) {
@@ -72,6 +89,10 @@ func (cp *CP) SetChargingProfileRequest(connectorId int, profile *types.Charging
 }
 
 func (cp *CP) TriggerMessageRequest(connectorId int, requestedMessage remotetrigger.MessageTrigger) error {
+	if cp.isV201() {
+		return cp.triggerMessage201(connectorId, requestedMessage)
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().TriggerMessage(cp.id, func(request *remotetrigger.TriggerMessageConfirmation, err error) {
@@ -90,6 +111,10 @@ func (cp *CP) TriggerMessageRequest(connectorId int, requestedMessage remotetrig
 }
 
 func (cp *CP) ChangeConfigurationRequest(key, value string) error {
+	if cp.isV201() {
+		return ErrNotSupported
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().ChangeConfiguration(cp.id, func(request *core.ChangeConfigurationConfirmation, err error) {
@@ -104,6 +129,10 @@ func (cp *CP) ChangeConfigurationRequest(key, value string) error {
 }
 
 func (cp *CP) GetConfigurationRequest() (*core.GetConfigurationConfirmation, error) {
+	if cp.isV201() {
+		return nil, ErrNotSupported
+	}
+
 	rc := make(chan error, 1)
 
 	var res *core.GetConfigurationConfirmation
@@ -115,3 +144,57 @@ func (cp *CP) GetConfigurationRequest() (*core.GetConfigurationConfirmation, err
 
 	return res, wait(err, rc)
 }
+
+func (cp *CP) GetLocalListVersionRequest() (int, error) {
+	if cp.isV201() {
+		return cp.getLocalListVersion201()
+	}
+
+	var res int
+	rc := make(chan error, 1)
+
+	err := Instance().GetLocalListVersion(cp.id, func(request *localauth.GetLocalListVersionConfirmation, err error) {
+		if err == nil && request != nil {
+			res = request.ListVersion
+		}
+
+		rc <- err
+	})
+
+	return res, wait(err, rc)
+}
+
+func (cp *CP) SendLocalListRequest(version int, idTags []string) error {
+	if cp.isV201() {
+		return cp.sendLocalList201(version, idTags)
+	}
+
+	rc := make(chan error, 1)
+
+	err := Instance().SendLocalList(cp.id, func(request *localauth.SendLocalListConfirmation, err error) {
+		if err == nil && request != nil && request.Status != localauth.UpdateStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, version, localauth.UpdateTypeFull, func(request *localauth.SendLocalListRequest) {
+		for _, idTag := range idTags {
+			request.LocalAuthorizationList = append(request.LocalAuthorizationList, localauth.AuthorizationData{
+				IdTag:     idTag,
+				IdTagInfo: types.NewIdTagInfo(types.AuthorizationStatusAccepted),
+			})
+		}
+	})
+
+	return wait(err, rc)
+}
+
+// UpdateLocalList replaces the charge point's local authorization list with the given id tags
+func (cp *CP) UpdateLocalList(idTags []string) error {
+	version, err := cp.GetLocalListVersionRequest()
+	if err != nil {
+		return err
+	}
+
+	return cp.SendLocalListRequest(max(version, 0)+1, idTags)
+}
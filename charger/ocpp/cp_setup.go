@@ -12,19 +12,20 @@ import (
 	"github.com/samber/lo"
 )
 
+// desiredMeasurands are the measurands configured by auto configuration
+const desiredMeasurands = "Power.Active.Import,Energy.Active.Import.Register,Current.Import,Voltage,Current.Offered,Power.Offered,SoC"
+
 func (cp *CP) Setup(meterValues string, meterInterval time.Duration) error {
+	if cp.isV201() {
+		return cp.setup201(meterValues, meterInterval)
+	}
+
 	if err := cp.ChangeAvailabilityRequest(0, core.AvailabilityTypeOperative); err != nil {
 		cp.log.DEBUG.Printf("failed configuring availability: %v", err)
 	}
 
 	// auto configuration
-	desiredMeasurands := "Power.Active.Import,Energy.Active.Import.Register,Current.Import,Voltage,Current.Offered,Power.Offered,SoC"
-
-	// remove offending measurands from desired values
-	if remove, ok := strings.CutPrefix(meterValues, "-"); ok {
-		desiredMeasurands = strings.Join(lo.Without(strings.Split(desiredMeasurands, ","), strings.Split(remove, ",")...), ",")
-		meterValues = ""
-	}
+	desiredMeasurands, meterValues := autoMeasurands(meterValues)
 
 	meterValuesSampledDataMaxLength := len(strings.Split(desiredMeasurands, ","))
 
@@ -48,6 +49,11 @@ func (cp *CP) Setup(meterValues string, meterInterval time.Duration) error {
 				cp.StackLevel = val
 			}
 
+		case match(KeyChargingScheduleMaxPeriods):
+			if val, err := strconv.Atoi(*opt.Value); err == nil {
+				cp.MaxPeriods = val
+			}
+
 		case match(KeyChargingScheduleAllowedChargingRateUnit):
 			if *opt.Value == "Power" || *opt.Value == "W" { // "W" is not allowed by spec but used by some CPs
 				cp.ChargingRateUnit = types.ChargingRateUnitWatts
@@ -158,6 +164,19 @@ func (cp *CP) Setup(meterValues string, meterInterval time.Duration) error {
 	return nil
 }
 
+// autoMeasurands returns the desired measurands for auto configuration, excluding measurands prefixed by "-"
+func autoMeasurands(meterValues string) (string, string) {
+	desired := desiredMeasurands
+
+	// remove offending measurands from desired values
+	if remove, ok := strings.CutPrefix(meterValues, "-"); ok {
+		desired = strings.Join(lo.Without(strings.Split(desired, ","), strings.Split(remove, ",")...), ",")
+		meterValues = ""
+	}
+
+	return desired, meterValues
+}
+
 // HasMeasurement checks if meterValuesSample contains given measurement
 func (cp *CP) HasMeasurement(val types.Measurand) bool {
 	return hasProperty(cp.meterValuesSample, string(val))
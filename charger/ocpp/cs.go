@@ -9,6 +9,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
 )
 
 type registration struct {
@@ -24,6 +25,8 @@ func newRegistration() *registration {
 
 type CS struct {
 	ocpp16.CentralSystem
+	csms  ocpp201.CSMS
+	mux   *protocolMux
 	mu    sync.Mutex
 	log   *util.Logger
 	regs  map[string]*registration // guarded by mu mutex
@@ -37,6 +40,11 @@ func (cs *CS) errorHandler(errC <-chan error) {
 	}
 }
 
+// Protocol returns the websocket subprotocol negotiated by the charge point
+func (cs *CS) Protocol(id string) string {
+	return cs.mux.Protocol(id)
+}
+
 func (cs *CS) ChargepointByID(id string) (*CP, error) {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
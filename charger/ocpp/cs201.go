@@ -0,0 +1,166 @@
+package ocpp
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// cs201 translates OCPP 2.0.1 charging station messages into the central system's OCPP 1.6 based state.
+// EVSEs are mapped to connectors, transactions to numeric transaction ids.
+type cs201 struct {
+	cs   *CS
+	mu   sync.Mutex
+	txns map[txnKey201]txn201 // guarded by mu mutex
+}
+
+type txnKey201 struct {
+	station, id string
+}
+
+type txn201 struct {
+	evse, id int
+	started  bool
+}
+
+func newCS201(cs *CS) *cs201 {
+	return &cs201{
+		cs:   cs,
+		txns: make(map[txnKey201]txn201),
+	}
+}
+
+// NewChargingStation implements ocpp201.ChargingStationConnectionHandler
+func (h *cs201) NewChargingStation(chargingStation ocpp201.ChargingStationConnection) {
+	h.cs.NewChargePoint(chargingStation)
+}
+
+// ChargingStationDisconnected implements ocpp201.ChargingStationConnectionHandler
+func (h *cs201) ChargingStationDisconnected(chargingStation ocpp201.ChargingStationConnection) {
+	h.cs.ChargePointDisconnected(chargingStation)
+}
+
+// statusNotification updates the connector status
+func (h *cs201) statusNotification(id string, evse int, status core.ChargePointStatus, timestamp time.Time) {
+	errorCode := core.NoError
+	if status == core.ChargePointStatusFaulted {
+		errorCode = core.OtherError
+	}
+
+	_, _ = h.cs.OnStatusNotification(id, &core.StatusNotificationRequest{
+		ConnectorId: evse,
+		ErrorCode:   errorCode,
+		Status:      status,
+		Timestamp:   types.NewDateTime(timestamp),
+	})
+}
+
+// connectorStatus returns the last known connector status
+func (h *cs201) connectorStatus(id string, evse int) core.ChargePointStatus {
+	var res core.ChargePointStatus
+	h.cs.WithConnectorStatus(id, evse, func(status *core.StatusNotificationRequest) {
+		res = status.Status
+	})
+	return res
+}
+
+// connectorStatus201 maps the connector status. Occupied connectors retain their detailed charging status.
+func connectorStatus201(status availability.ConnectorStatus, prev core.ChargePointStatus) core.ChargePointStatus {
+	switch status {
+	case availability.ConnectorStatusOccupied:
+		switch prev {
+		case core.ChargePointStatusPreparing,
+			core.ChargePointStatusCharging,
+			core.ChargePointStatusSuspendedEV,
+			core.ChargePointStatusSuspendedEVSE,
+			core.ChargePointStatusFinishing:
+			return prev
+		}
+		return core.ChargePointStatusPreparing
+	case availability.ConnectorStatusReserved:
+		return core.ChargePointStatusReserved
+	case availability.ConnectorStatusUnavailable:
+		return core.ChargePointStatusUnavailable
+	case availability.ConnectorStatusFaulted:
+		return core.ChargePointStatusFaulted
+	default:
+		return core.ChargePointStatusAvailable
+	}
+}
+
+// chargingStatus201 maps the transaction's charging state
+func chargingStatus201(state transactions.ChargingState, ended bool) core.ChargePointStatus {
+	switch {
+	case state == transactions.ChargingStateIdle:
+		return core.ChargePointStatusAvailable
+	case ended:
+		return core.ChargePointStatusFinishing
+	case state == transactions.ChargingStateCharging:
+		return core.ChargePointStatusCharging
+	case state == transactions.ChargingStateSuspendedEV:
+		return core.ChargePointStatusSuspendedEV
+	case state == transactions.ChargingStateSuspendedEVSE:
+		return core.ChargePointStatusSuspendedEVSE
+	default:
+		return core.ChargePointStatusPreparing
+	}
+}
+
+// meterValues201 converts meter values including unit multipliers
+func meterValues201(values []types201.MeterValue) []types.MeterValue {
+	res := make([]types.MeterValue, 0, len(values))
+
+	for _, mv := range values {
+		samples := make([]types.SampledValue, 0, len(mv.SampledValue))
+
+		for _, sv := range mv.SampledValue {
+			value := sv.Value
+
+			var unit types.UnitOfMeasure
+			if sv.UnitOfMeasure != nil {
+				unit = types.UnitOfMeasure(sv.UnitOfMeasure.Unit)
+				if sv.UnitOfMeasure.Multiplier != nil {
+					value *= math.Pow10(*sv.UnitOfMeasure.Multiplier)
+				}
+			}
+
+			measurand := types.Measurand(sv.Measurand)
+			if measurand == "" {
+				measurand = types.MeasurandEnergyActiveImportRegister
+			}
+
+			samples = append(samples, types.SampledValue{
+				Value:     strconv.FormatFloat(value, 'f', -1, 64),
+				Context:   types.ReadingContext(sv.Context),
+				Measurand: measurand,
+				Phase:     types.Phase(sv.Phase),
+				Location:  types.Location(sv.Location),
+				Unit:      unit,
+			})
+
+			if sv.SignedMeterValue != nil {
+				samples = append(samples, types.SampledValue{
+					Value:     sv.SignedMeterValue.SignedMeterData,
+					Context:   types.ReadingContext(sv.Context),
+					Format:    types.ValueFormatSignedData,
+					Measurand: measurand,
+				})
+			}
+		}
+
+		res = append(res, types.MeterValue{
+			Timestamp:    types.NewDateTime(mv.Timestamp.Time),
+			SampledValue: samples,
+		})
+	}
+
+	return res
+}
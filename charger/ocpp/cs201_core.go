@@ -0,0 +1,205 @@
+package ocpp
+
+import (
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// cp actions
+
+func (h *cs201) OnAuthorize(id string, request *authorization.AuthorizeRequest) (*authorization.AuthorizeResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	conf, err := h.cs.OnAuthorize(id, &core.AuthorizeRequest{
+		IdTag: request.IdToken.IdToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &authorization.AuthorizeResponse{
+		IdTokenInfo: *types201.NewIdTokenInfo(types201.AuthorizationStatus(conf.IdTagInfo.Status)),
+	}
+
+	return res, nil
+}
+
+func (h *cs201) OnBootNotification(id string, request *provisioning.BootNotificationRequest) (*provisioning.BootNotificationResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	conf, err := h.cs.OnBootNotification(id, &core.BootNotificationRequest{
+		ChargePointModel:        request.ChargingStation.Model,
+		ChargePointSerialNumber: request.ChargingStation.SerialNumber,
+		ChargePointVendor:       request.ChargingStation.VendorName,
+		FirmwareVersion:         request.ChargingStation.FirmwareVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &provisioning.BootNotificationResponse{
+		CurrentTime: types201.Now(),
+		Interval:    conf.Interval,
+		Status:      provisioning.RegistrationStatus(conf.Status),
+	}
+
+	return res, nil
+}
+
+func (h *cs201) OnNotifyReport(id string, request *provisioning.NotifyReportRequest) (*provisioning.NotifyReportResponse, error) {
+	// no cp handler
+
+	return new(provisioning.NotifyReportResponse), nil
+}
+
+func (h *cs201) OnHeartbeat(id string, request *availability.HeartbeatRequest) (*availability.HeartbeatResponse, error) {
+	// no cp handler
+
+	res := &availability.HeartbeatResponse{
+		CurrentTime: *types201.Now(),
+	}
+
+	return res, nil
+}
+
+func (h *cs201) OnStatusNotification(id string, request *availability.StatusNotificationRequest) (*availability.StatusNotificationResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	timestamp := time.Now()
+	if request.Timestamp != nil {
+		timestamp = request.Timestamp.Time
+	}
+
+	status := connectorStatus201(request.ConnectorStatus, h.connectorStatus(id, request.EvseID))
+	h.statusNotification(id, request.EvseID, status, timestamp)
+
+	return new(availability.StatusNotificationResponse), nil
+}
+
+func (h *cs201) OnMeterValues(id string, request *meter.MeterValuesRequest) (*meter.MeterValuesResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	_, _ = h.cs.OnMeterValues(id, &core.MeterValuesRequest{
+		ConnectorId: request.EvseID,
+		MeterValue:  meterValues201(request.MeterValue),
+	})
+
+	return new(meter.MeterValuesResponse), nil
+}
+
+// OnTransactionEvent maps the transaction lifecycle to start/stop transaction, status and meter values.
+// Transactions are started once authorized since evcc treats unauthorized transactions as waiting for authorization.
+func (h *cs201) OnTransactionEvent(id string, request *transactions.TransactionEventRequest) (*transactions.TransactionEventResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	res := new(transactions.TransactionEventResponse)
+
+	timestamp := time.Now()
+	if request.Timestamp != nil {
+		timestamp = request.Timestamp.Time
+	}
+
+	key := txnKey201{id, request.TransactionInfo.TransactionID}
+	ended := request.EventType == transactions.TransactionEventEnded
+	state := request.TransactionInfo.ChargingState
+
+	h.mu.Lock()
+	txn, ok := h.txns[key]
+	if request.Evse != nil {
+		txn.evse = request.Evse.ID
+	}
+	if txn.evse > 0 && !ok {
+		h.txns[key] = txn
+	}
+	h.mu.Unlock()
+
+	if txn.evse == 0 {
+		h.cs.log.DEBUG.Printf("ignoring transaction event without evse: %s", key.id)
+		return res, nil
+	}
+
+	if !txn.started && !ended && (request.IDToken != nil || state == transactions.ChargingStateCharging) {
+		var idTag string
+		if request.IDToken != nil {
+			idTag = request.IDToken.IdToken
+		}
+
+		conf, err := h.cs.OnStartTransaction(id, &core.StartTransactionRequest{
+			ConnectorId: txn.evse,
+			IdTag:       idTag,
+			Timestamp:   types.NewDateTime(timestamp),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		txn.id = conf.TransactionId
+		txn.started = true
+
+		h.mu.Lock()
+		h.txns[key] = txn
+		h.mu.Unlock()
+
+		if request.IDToken != nil {
+			res.IDTokenInfo = types201.NewIdTokenInfo(types201.AuthorizationStatus(conf.IdTagInfo.Status))
+		}
+	} else if request.IDToken != nil {
+		conf, err := h.cs.OnAuthorize(id, &core.AuthorizeRequest{
+			IdTag: request.IDToken.IdToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res.IDTokenInfo = types201.NewIdTokenInfo(types201.AuthorizationStatus(conf.IdTagInfo.Status))
+	}
+
+	if state != "" || ended {
+		h.statusNotification(id, txn.evse, chargingStatus201(state, ended), timestamp)
+	}
+
+	if len(request.MeterValue) > 0 {
+		req := &core.MeterValuesRequest{
+			ConnectorId: txn.evse,
+			MeterValue:  meterValues201(request.MeterValue),
+		}
+		if txn.id > 0 {
+			req.TransactionId = &txn.id
+		}
+
+		_, _ = h.cs.OnMeterValues(id, req)
+	}
+
+	if ended {
+		h.mu.Lock()
+		delete(h.txns, key)
+		h.mu.Unlock()
+
+		if txn.started {
+			_, _ = h.cs.OnStopTransaction(id, &core.StopTransactionRequest{
+				TransactionId: txn.id,
+				Timestamp:     types.NewDateTime(timestamp),
+			})
+		}
+	}
+
+	return res, nil
+}
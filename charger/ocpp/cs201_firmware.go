@@ -0,0 +1,70 @@
+package ocpp
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	firmware201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/firmware"
+)
+
+// uploadStatus201 maps the log upload status to the OCPP 1.6 diagnostics status
+func uploadStatus201(status diagnostics.UploadLogStatus) firmware.DiagnosticsStatus {
+	switch status {
+	case diagnostics.UploadLogStatusIdle:
+		return firmware.DiagnosticsStatusIdle
+	case diagnostics.UploadLogStatusUploaded:
+		return firmware.DiagnosticsStatusUploaded
+	case diagnostics.UploadLogStatusUploading:
+		return firmware.DiagnosticsStatusUploading
+	default:
+		return firmware.DiagnosticsStatusUploadFailed
+	}
+}
+
+func (h *cs201) OnFirmwareStatusNotification(id string, request *firmware201.FirmwareStatusNotificationRequest) (*firmware201.FirmwareStatusNotificationResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	// status is passed unmapped since OCPP 2.0.1 reports more detailed states
+	if cp, err := h.cs.ChargepointByID(id); err == nil {
+		cp.firmwareStatus(string(request.Status))
+	}
+
+	return new(firmware201.FirmwareStatusNotificationResponse), nil
+}
+
+func (h *cs201) OnPublishFirmwareStatusNotification(id string, request *firmware201.PublishFirmwareStatusNotificationRequest) (*firmware201.PublishFirmwareStatusNotificationResponse, error) {
+	// no cp handler
+
+	return new(firmware201.PublishFirmwareStatusNotificationResponse), nil
+}
+
+func (h *cs201) OnLogStatusNotification(id string, request *diagnostics.LogStatusNotificationRequest) (*diagnostics.LogStatusNotificationResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	_, _ = h.cs.OnDiagnosticsStatusNotification(id, &firmware.DiagnosticsStatusNotificationRequest{
+		Status: uploadStatus201(request.Status),
+	})
+
+	return new(diagnostics.LogStatusNotificationResponse), nil
+}
+
+func (h *cs201) OnNotifyCustomerInformation(id string, request *diagnostics.NotifyCustomerInformationRequest) (*diagnostics.NotifyCustomerInformationResponse, error) {
+	// no cp handler
+
+	return new(diagnostics.NotifyCustomerInformationResponse), nil
+}
+
+func (h *cs201) OnNotifyEvent(id string, request *diagnostics.NotifyEventRequest) (*diagnostics.NotifyEventResponse, error) {
+	// no cp handler
+
+	return new(diagnostics.NotifyEventResponse), nil
+}
+
+func (h *cs201) OnNotifyMonitoringReport(id string, request *diagnostics.NotifyMonitoringReportRequest) (*diagnostics.NotifyMonitoringReportResponse, error) {
+	// no cp handler
+
+	return new(diagnostics.NotifyMonitoringReportResponse), nil
+}
@@ -0,0 +1,82 @@
+package ocpp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectorStatus201(t *testing.T) {
+	for _, tc := range []struct {
+		status availability.ConnectorStatus
+		prev   core.ChargePointStatus
+		res    core.ChargePointStatus
+	}{
+		{availability.ConnectorStatusAvailable, core.ChargePointStatusCharging, core.ChargePointStatusAvailable},
+		{availability.ConnectorStatusOccupied, core.ChargePointStatusAvailable, core.ChargePointStatusPreparing},
+		{availability.ConnectorStatusOccupied, core.ChargePointStatusSuspendedEV, core.ChargePointStatusSuspendedEV},
+		{availability.ConnectorStatusUnavailable, core.ChargePointStatusAvailable, core.ChargePointStatusUnavailable},
+		{availability.ConnectorStatusFaulted, core.ChargePointStatusCharging, core.ChargePointStatusFaulted},
+	} {
+		assert.Equal(t, tc.res, connectorStatus201(tc.status, tc.prev), tc)
+	}
+}
+
+func TestChargingStatus201(t *testing.T) {
+	assert.Equal(t, core.ChargePointStatusCharging, chargingStatus201(transactions.ChargingStateCharging, false))
+	assert.Equal(t, core.ChargePointStatusSuspendedEVSE, chargingStatus201(transactions.ChargingStateSuspendedEVSE, false))
+	assert.Equal(t, core.ChargePointStatusPreparing, chargingStatus201(transactions.ChargingStateEVConnected, false))
+	assert.Equal(t, core.ChargePointStatusFinishing, chargingStatus201(transactions.ChargingStateEVConnected, true))
+	assert.Equal(t, core.ChargePointStatusAvailable, chargingStatus201(transactions.ChargingStateIdle, true))
+}
+
+func TestMeterValues201(t *testing.T) {
+	ts := time.UnixMilli(1)
+	multiplier := 3
+
+	res := meterValues201([]types201.MeterValue{{
+		Timestamp: types201.DateTime{Time: ts},
+		SampledValue: []types201.SampledValue{
+			{Value: 1.5, UnitOfMeasure: &types201.UnitOfMeasure{Unit: "Wh", Multiplier: &multiplier}},
+			{Value: 16, Measurand: types201.MeasurandCurrentImport, Phase: types201.PhaseL1, UnitOfMeasure: &types201.UnitOfMeasure{Unit: "A"}},
+		},
+	}})
+
+	assert.Equal(t, []types.MeterValue{{
+		Timestamp: types.NewDateTime(ts),
+		SampledValue: []types.SampledValue{
+			{Value: "1500", Measurand: types.MeasurandEnergyActiveImportRegister, Unit: "Wh"},
+			{Value: "16", Measurand: types.MeasurandCurrentImport, Phase: types.PhaseL1, Unit: "A"},
+		},
+	}}, res)
+}
+
+func TestChargingProfile201(t *testing.T) {
+	duration := 600
+	profile := &types.ChargingProfile{
+		ChargingProfileId:      1,
+		StackLevel:             2,
+		ChargingProfilePurpose: types.ChargingProfilePurposeTxDefaultProfile,
+		ChargingProfileKind:    types.ChargingProfileKindAbsolute,
+		ChargingSchedule: &types.ChargingSchedule{
+			Duration:         &duration,
+			ChargingRateUnit: types.ChargingRateUnitAmperes,
+			ChargingSchedulePeriod: []types.ChargingSchedulePeriod{
+				{StartPeriod: 0, Limit: 16},
+				{StartPeriod: 300, Limit: 6},
+			},
+		},
+	}
+
+	res := chargingProfile201(profile)
+	assert.Equal(t, types201.ChargingProfilePurposeTxDefaultProfile, res.ChargingProfilePurpose)
+	assert.Equal(t, types201.ChargingProfileKindAbsolute, res.ChargingProfileKind)
+	assert.Len(t, res.ChargingSchedule, 1)
+	assert.Equal(t, profile.ChargingSchedule, chargingSchedule16(&res.ChargingSchedule[0]))
+}
@@ -2,13 +2,16 @@ package ocpp
 
 import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 )
 
 // cp actions
 
 func (cs *CS) OnAuthorize(id string, request *core.AuthorizeRequest) (*core.AuthorizeConfirmation, error) {
-	// no cp handler
+	if cp, err := cs.ChargepointByID(id); err == nil {
+		return cp.OnAuthorize(request)
+	}
 
 	res := &core.AuthorizeConfirmation{
 		IdTagInfo: &types.IdTagInfo{
@@ -105,3 +108,19 @@ func (cs *CS) OnStopTransaction(id string, request *core.StopTransactionRequest)
 
 	return res, nil
 }
+
+func (cs *CS) OnFirmwareStatusNotification(id string, request *firmware.FirmwareStatusNotificationRequest) (*firmware.FirmwareStatusNotificationConfirmation, error) {
+	if cp, err := cs.ChargepointByID(id); err == nil {
+		return cp.OnFirmwareStatusNotification(request)
+	}
+
+	return new(firmware.FirmwareStatusNotificationConfirmation), nil
+}
+
+func (cs *CS) OnDiagnosticsStatusNotification(id string, request *firmware.DiagnosticsStatusNotificationRequest) (*firmware.DiagnosticsStatusNotificationConfirmation, error) {
+	if cp, err := cs.ChargepointByID(id); err == nil {
+		return cp.OnDiagnosticsStatusNotification(request)
+	}
+
+	return new(firmware.DiagnosticsStatusNotificationConfirmation), nil
+}
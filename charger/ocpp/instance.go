@@ -1,6 +1,7 @@
 package ocpp
 
 import (
+	"crypto/tls"
 	"net/http"
 	"sync"
 	"time"
@@ -9,8 +10,23 @@ import (
 	"github.com/lorenzodonini/ocpp-go/ocpp"
 	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	firmware201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/firmware"
+	localauth201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	smartcharging201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
 	"github.com/lorenzodonini/ocpp-go/ocppj"
 	"github.com/lorenzodonini/ocpp-go/ws"
 )
@@ -24,40 +40,86 @@ func Instance() *CS {
 	once.Do(func() {
 		log := util.NewLogger("ocpp")
 
-		server := ws.NewServer()
+		// plain listener and optional tls listener (security profile 2)
+		ls := []listener{{WsServer: ws.NewServer(), port: conf.Port}}
+		if conf.Cert != "" {
+			ls = append(ls, listener{
+				WsServer: ws.NewTLSServer(conf.Cert, conf.Key, &tls.Config{MinVersion: tls.VersionTLS12}),
+				port:     conf.TLSPort,
+			})
+		}
+
+		server := newListeners(ls...)
 		server.SetCheckOriginHandler(func(r *http.Request) bool { return true })
 
-		dispatcher := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
-		dispatcher.SetTimeout(Timeout)
+		// share websocket server between protocol versions
+		mux := newProtocolMux(server)
 
-		endpoint := ocppj.NewServer(server, dispatcher, nil, core.Profile, remotetrigger.Profile, smartcharging.Profile)
-		endpoint.SetInvalidMessageHook(func(client ws.Channel, err *ocpp.Error, rawMessage string, parsedFields []interface{}) *ocpp.Error {
+		invalidMessageHook := func(client ws.Channel, err *ocpp.Error, rawMessage string, parsedFields []interface{}) *ocpp.Error {
 			log.ERROR.Printf("%v (%s)", err, rawMessage)
 			return nil
-		})
+		}
+
+		// ocpp 1.6
+		dispatcher := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
+		dispatcher.SetTimeout(Timeout)
+
+		server16 := mux.Server(types.V16Subprotocol)
+		endpoint := ocppj.NewServer(server16, dispatcher, nil, core.Profile, firmware.Profile, localauth.Profile, remotetrigger.Profile, smartcharging.Profile)
+		endpoint.SetInvalidMessageHook(invalidMessageHook)
+
+		cs := ocpp16.NewCentralSystem(endpoint, server16)
+
+		// ocpp 2.0.1
+		dispatcher201 := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
+		dispatcher201.SetTimeout(Timeout)
 
-		cs := ocpp16.NewCentralSystem(endpoint, server)
+		server201 := mux.Server(types201.V201Subprotocol)
+		endpoint201 := ocppj.NewServer(server201, dispatcher201, nil,
+			authorization.Profile, availability.Profile, diagnostics.Profile, firmware201.Profile, localauth201.Profile, meter.Profile, provisioning.Profile,
+			remotecontrol.Profile, smartcharging201.Profile, transactions.Profile)
+		endpoint201.SetInvalidMessageHook(invalidMessageHook)
+
+		csms := ocpp201.NewCSMS(endpoint201, server201)
 
 		instance = &CS{
 			log:           log,
 			regs:          make(map[string]*registration),
+			mux:           mux,
 			CentralSystem: cs,
+			csms:          csms,
 		}
 
+		mux.SetAuthHandler(instance.checkClient)
+
 		instance.txnId.Store(time.Now().UTC().Unix())
 
 		ocppj.SetLogger(instance)
 
 		cs.SetCoreHandler(instance)
+		cs.SetFirmwareManagementHandler(instance)
 		cs.SetNewChargePointHandler(instance.NewChargePoint)
 		cs.SetChargePointDisconnectedHandler(instance.ChargePointDisconnected)
 
+		handler := newCS201(instance)
+		csms.SetAuthorizationHandler(handler)
+		csms.SetAvailabilityHandler(handler)
+		csms.SetDiagnosticsHandler(handler)
+		csms.SetFirmwareHandler(handler)
+		csms.SetMeterHandler(handler)
+		csms.SetProvisioningHandler(handler)
+		csms.SetTransactionsHandler(handler)
+		csms.SetNewChargingStationHandler(handler.NewChargingStation)
+		csms.SetChargingStationDisconnectedHandler(handler.ChargingStationDisconnected)
+
 		go instance.errorHandler(cs.Errors())
-		go cs.Start(8887, "/{ws}")
+		go instance.errorHandler(csms.Errors())
+		go cs.Start(conf.Port, "/{ws}")
+		go csms.Start(conf.Port, "/{ws}")
 
 		// wait for server to start
 		for range time.Tick(10 * time.Millisecond) {
-			if dispatcher.IsRunning() {
+			if dispatcher.IsRunning() && dispatcher201.IsRunning() {
 				break
 			}
 		}
@@ -0,0 +1,153 @@
+package ocpp
+
+import (
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+// listener is a websocket server listening on its own port
+type listener struct {
+	ws.WsServer
+	port int
+}
+
+// listeners serves charge points on multiple websocket listeners, e.g. plain and tls.
+// Writes are routed to the listener the charge point is connected to.
+type listeners struct {
+	ls      []listener
+	mu      sync.RWMutex
+	clients map[string]ws.WsServer // listener by connected charge point id
+	once    sync.Once
+	errC    chan error
+}
+
+func newListeners(ls ...listener) *listeners {
+	return &listeners{
+		ls:      ls,
+		clients: make(map[string]ws.WsServer),
+	}
+}
+
+// Start starts all listeners on their configured ports and blocks until the first listener stops
+func (s *listeners) Start(_ int, listenPath string) {
+	for _, l := range s.ls[1:] {
+		go l.Start(l.port, listenPath)
+	}
+
+	s.ls[0].Start(s.ls[0].port, listenPath)
+}
+
+func (s *listeners) Stop() {
+	for _, l := range s.ls {
+		l.Stop()
+	}
+}
+
+// client returns the listener the charge point is connected to
+func (s *listeners) client(id string) ws.WsServer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if l, ok := s.clients[id]; ok {
+		return l
+	}
+
+	return s.ls[0]
+}
+
+func (s *listeners) StopConnection(id string, closeError websocket.CloseError) error {
+	return s.client(id).StopConnection(id, closeError)
+}
+
+func (s *listeners) Write(id string, data []byte) error {
+	return s.client(id).Write(id, data)
+}
+
+// Errors merges the listeners' errors
+func (s *listeners) Errors() <-chan error {
+	s.once.Do(func() {
+		s.errC = make(chan error, 1)
+
+		for _, l := range s.ls {
+			go func(errC <-chan error) {
+				for err := range errC {
+					s.errC <- err
+				}
+			}(l.Errors())
+		}
+	})
+
+	return s.errC
+}
+
+func (s *listeners) SetMessageHandler(handler func(ws ws.Channel, data []byte) error) {
+	for _, l := range s.ls {
+		l.SetMessageHandler(handler)
+	}
+}
+
+func (s *listeners) SetNewClientHandler(handler func(ws ws.Channel)) {
+	for _, l := range s.ls {
+		l.SetNewClientHandler(func(ch ws.Channel) {
+			s.mu.Lock()
+			s.clients[ch.ID()] = l.WsServer
+			s.mu.Unlock()
+
+			handler(ch)
+		})
+	}
+}
+
+func (s *listeners) SetDisconnectedClientHandler(handler func(ws ws.Channel)) {
+	for _, l := range s.ls {
+		l.SetDisconnectedClientHandler(func(ch ws.Channel) {
+			// charge point may have reconnected on other listener
+			s.mu.Lock()
+			if s.clients[ch.ID()] == l.WsServer {
+				delete(s.clients, ch.ID())
+			}
+			s.mu.Unlock()
+
+			handler(ch)
+		})
+	}
+}
+
+func (s *listeners) SetTimeoutConfig(config ws.ServerTimeoutConfig) {
+	for _, l := range s.ls {
+		l.SetTimeoutConfig(config)
+	}
+}
+
+func (s *listeners) AddSupportedSubprotocol(subProto string) {
+	for _, l := range s.ls {
+		l.AddSupportedSubprotocol(subProto)
+	}
+}
+
+func (s *listeners) SetBasicAuthHandler(handler func(username string, password string) bool) {
+	for _, l := range s.ls {
+		l.SetBasicAuthHandler(handler)
+	}
+}
+
+func (s *listeners) SetCheckOriginHandler(handler func(r *http.Request) bool) {
+	for _, l := range s.ls {
+		l.SetCheckOriginHandler(handler)
+	}
+}
+
+func (s *listeners) SetCheckClientHandler(handler func(id string, r *http.Request) bool) {
+	for _, l := range s.ls {
+		l.SetCheckClientHandler(handler)
+	}
+}
+
+// Addr returns the address of the first listener
+func (s *listeners) Addr() *net.TCPAddr {
+	return s.ls[0].Addr()
+}
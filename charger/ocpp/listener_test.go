@@ -0,0 +1,63 @@
+package ocpp
+
+import (
+	"testing"
+
+	"github.com/lorenzodonini/ocpp-go/ws"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChannel struct {
+	ws.Channel
+	id string
+}
+
+func (ch fakeChannel) ID() string {
+	return ch.id
+}
+
+// fakeServer records writes and connection handlers
+type fakeServer struct {
+	ws.WsServer
+	newClient, disconnected func(ws.Channel)
+	writes                  []string
+}
+
+func (s *fakeServer) SetNewClientHandler(handler func(ws.Channel)) {
+	s.newClient = handler
+}
+
+func (s *fakeServer) SetDisconnectedClientHandler(handler func(ws.Channel)) {
+	s.disconnected = handler
+}
+
+func (s *fakeServer) Write(id string, _ []byte) error {
+	s.writes = append(s.writes, id)
+	return nil
+}
+
+func TestListenersRouting(t *testing.T) {
+	plain, secure := new(fakeServer), new(fakeServer)
+
+	s := newListeners(listener{WsServer: plain}, listener{WsServer: secure})
+
+	var connected []string
+	s.SetNewClientHandler(func(ch ws.Channel) { connected = append(connected, ch.ID()) })
+	s.SetDisconnectedClientHandler(func(ws.Channel) {})
+
+	plain.newClient(fakeChannel{id: "a"})
+	secure.newClient(fakeChannel{id: "b"})
+	assert.Equal(t, []string{"a", "b"}, connected)
+
+	_ = s.Write("a", nil)
+	_ = s.Write("b", nil)
+	assert.Equal(t, []string{"a"}, plain.writes)
+	assert.Equal(t, []string{"b"}, secure.writes)
+
+	// reconnect on other listener before disconnect
+	plain.newClient(fakeChannel{id: "b"})
+	secure.disconnected(fakeChannel{id: "b"})
+
+	_ = s.Write("b", nil)
+	assert.Equal(t, []string{"a", "b"}, plain.writes)
+}
@@ -0,0 +1,198 @@
+package ocpp
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+// protocolMux shares a single websocket server between central systems of different OCPP versions.
+// Each charge point is routed to the central system matching its negotiated websocket subprotocol.
+type protocolMux struct {
+	ws.WsServer
+	started   atomic.Bool
+	mu        sync.RWMutex
+	protocols []string                   // supported protocols
+	servers   map[string]*protocolServer // server by protocol
+	clients   map[string]string          // negotiated protocol by charge point id
+	auth      ws.CheckClientHandler      // authenticates charge points regardless of protocol
+}
+
+func newProtocolMux(server ws.WsServer) *protocolMux {
+	mux := &protocolMux{
+		WsServer: server,
+		servers:  make(map[string]*protocolServer),
+		clients:  make(map[string]string),
+	}
+
+	server.SetCheckClientHandler(mux.checkClient)
+	server.SetNewClientHandler(mux.newClient)
+	server.SetDisconnectedClientHandler(mux.disconnectedClient)
+	server.SetMessageHandler(mux.message)
+
+	return mux
+}
+
+// Server returns the websocket server for the given protocol
+func (mux *protocolMux) Server(protocol string) ws.WsServer {
+	mux.mu.Lock()
+	defer mux.mu.Unlock()
+
+	server := &protocolServer{WsServer: mux.WsServer, mux: mux}
+	mux.servers[protocol] = server
+	mux.protocols = append(mux.protocols, protocol)
+
+	return server
+}
+
+// SetAuthHandler sets the handler authenticating charge points before protocol negotiation
+func (mux *protocolMux) SetAuthHandler(handler ws.CheckClientHandler) {
+	mux.mu.Lock()
+	defer mux.mu.Unlock()
+
+	mux.auth = handler
+}
+
+// Protocol returns the negotiated protocol of the charge point
+func (mux *protocolMux) Protocol(id string) string {
+	mux.mu.RLock()
+	defer mux.mu.RUnlock()
+
+	return mux.clients[id]
+}
+
+// negotiate selects the protocol in order of client preference like the websocket upgrader
+func (mux *protocolMux) negotiate(r *http.Request) string {
+	mux.mu.RLock()
+	defer mux.mu.RUnlock()
+
+	for _, header := range r.Header.Values("Sec-Websocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			if protocol = strings.TrimSpace(protocol); slices.Contains(mux.protocols, protocol) {
+				return protocol
+			}
+		}
+	}
+
+	return ""
+}
+
+// server returns the server for the charge point's protocol
+func (mux *protocolMux) server(id string) *protocolServer {
+	mux.mu.RLock()
+	defer mux.mu.RUnlock()
+
+	return mux.servers[mux.clients[id]]
+}
+
+func (mux *protocolMux) checkClient(id string, r *http.Request) bool {
+	mux.mu.RLock()
+	auth := mux.auth
+	mux.mu.RUnlock()
+
+	if auth != nil && !auth(id, r) {
+		return false
+	}
+
+	protocol := mux.negotiate(r)
+	if protocol == "" {
+		// unsupported protocol, connection is rejected by websocket server
+		return true
+	}
+
+	mux.mu.Lock()
+	server := mux.servers[protocol]
+	mux.mu.Unlock()
+
+	if handler := server.handlers().checkClient; handler != nil && !handler(id, r) {
+		return false
+	}
+
+	mux.mu.Lock()
+	mux.clients[id] = protocol
+	mux.mu.Unlock()
+
+	return true
+}
+
+func (mux *protocolMux) newClient(ch ws.Channel) {
+	if server := mux.server(ch.ID()); server != nil {
+		if handler := server.handlers().newClient; handler != nil {
+			handler(ch)
+		}
+	}
+}
+
+func (mux *protocolMux) disconnectedClient(ch ws.Channel) {
+	if server := mux.server(ch.ID()); server != nil {
+		if handler := server.handlers().disconnectedClient; handler != nil {
+			handler(ch)
+		}
+	}
+}
+
+func (mux *protocolMux) message(ch ws.Channel, data []byte) error {
+	if server := mux.server(ch.ID()); server != nil {
+		if handler := server.handlers().message; handler != nil {
+			return handler(ch, data)
+		}
+	}
+
+	return nil
+}
+
+type protocolHandlers struct {
+	checkClient        ws.CheckClientHandler
+	newClient          func(ws.Channel)
+	disconnectedClient func(ws.Channel)
+	message            func(ws.Channel, []byte) error
+}
+
+// protocolServer is the websocket server view of a single central system
+type protocolServer struct {
+	ws.WsServer
+	mux *protocolMux
+	mu  sync.RWMutex
+	h   protocolHandlers
+}
+
+func (s *protocolServer) handlers() protocolHandlers {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.h
+}
+
+// Start starts the shared websocket server once
+func (s *protocolServer) Start(port int, listenPath string) {
+	if s.mux.started.CompareAndSwap(false, true) {
+		s.WsServer.Start(port, listenPath)
+	}
+}
+
+func (s *protocolServer) SetCheckClientHandler(handler func(id string, r *http.Request) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.h.checkClient = handler
+}
+
+func (s *protocolServer) SetNewClientHandler(handler func(ws.Channel)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.h.newClient = handler
+}
+
+func (s *protocolServer) SetDisconnectedClientHandler(handler func(ws.Channel)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.h.disconnectedClient = handler
+}
+
+func (s *protocolServer) SetMessageHandler(handler func(ws.Channel, []byte) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.h.message = handler
+}
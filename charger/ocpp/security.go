@@ -0,0 +1,208 @@
+package ocpp
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Config is the central system configuration
+type Config struct {
+	Port          int    // websocket port
+	AcceptUnknown bool   // accept connections of unconfigured station ids
+	TLSPort       int    // additional tls websocket port
+	Cert          string // tls certificate file, enables tls listener (security profile 2)
+	Key           string // tls key file
+}
+
+var conf = Config{Port: 8887, TLSPort: 8888}
+
+// Configure configures the central system. Must be called before the first charge point is created.
+func Configure(c Config) error {
+	if c.Port == 0 {
+		c.Port = conf.Port
+	}
+
+	if c.TLSPort == 0 {
+		c.TLSPort = conf.TLSPort
+	}
+
+	if (c.Cert == "") != (c.Key == "") {
+		return errors.New("tls requires both cert and key")
+	}
+
+	if c.Cert != "" {
+		if c.TLSPort == c.Port {
+			return errors.New("tls port must differ from port")
+		}
+
+		if _, err := tls.LoadX509KeyPair(c.Cert, c.Key); err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+	}
+
+	conf = c
+
+	return nil
+}
+
+// passwordKey returns the settings key of the charge point's basic auth password
+func passwordKey(id string) string {
+	return "ocpp." + id + ".password"
+}
+
+// SetPassword sets the charge point's basic auth password (security profile 1 and 2). An empty password disables authentication.
+func SetPassword(id, password string) error {
+	if id == "" {
+		return errors.New("missing station id")
+	}
+
+	if password == "" {
+		settings.SetString(passwordKey(id), "")
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	settings.SetString(passwordKey(id), string(hash))
+
+	return nil
+}
+
+// HasPassword returns true if the charge point requires basic auth
+func HasPassword(id string) bool {
+	hash, err := settings.String(passwordKey(id))
+	return err == nil && hash != ""
+}
+
+// secured returns true if security profile 1 or 2 is enabled by any password or tls.
+// Charge points must then authenticate with their password.
+func secured() bool {
+	if conf.Cert != "" {
+		return true
+	}
+
+	for _, s := range settings.All() {
+		if strings.HasPrefix(s.Key, "ocpp.") && strings.HasSuffix(s.Key, ".password") && s.Value != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validPassword checks the basic auth credentials of the request. The user name must match the station id.
+func validPassword(id string, r *http.Request) bool {
+	hash, err := settings.String(passwordKey(id))
+	if err != nil || hash == "" {
+		return true
+	}
+
+	user, password, ok := r.BasicAuth()
+
+	return ok && user == id && bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+const auditSize = 100
+
+// AuditEntry is a charge point connection attempt
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	ID       string    `json:"id"`
+	Remote   string    `json:"remote"`
+	Accepted bool      `json:"accepted"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// audit keeps the most recent connection attempts
+type audit struct {
+	mu      sync.Mutex
+	log     *util.Logger
+	entries []AuditEntry
+}
+
+var connections = &audit{log: util.NewLogger("ocpp-audit")}
+
+func (a *audit) add(id string, r *http.Request, accepted bool, reason string) {
+	entry := AuditEntry{
+		Time:     time.Now(),
+		ID:       id,
+		Remote:   r.RemoteAddr,
+		Accepted: accepted,
+		Reason:   reason,
+	}
+
+	if accepted {
+		a.log.INFO.Printf("accepted %s from %s", id, entry.Remote)
+	} else {
+		a.log.WARN.Printf("rejected %s from %s: %s", id, entry.Remote, reason)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.entries = append(a.entries, entry)
+	if len(a.entries) > auditSize {
+		a.entries = a.entries[len(a.entries)-auditSize:]
+	}
+}
+
+func (a *audit) list() []AuditEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]AuditEntry(nil), a.entries...)
+}
+
+// Audit returns the most recent charge point connection attempts
+func Audit() []AuditEntry {
+	return connections.list()
+}
+
+// checkClient authenticates connecting charge points
+func (cs *CS) checkClient(id string, r *http.Request) bool {
+	secured := secured()
+
+	switch {
+	case !validPassword(id, r):
+		connections.add(id, r, false, "invalid credentials")
+		return false
+
+	case secured && !HasPassword(id):
+		connections.add(id, r, false, "missing password")
+		return false
+
+	case !conf.AcceptUnknown && !cs.configured(id, secured):
+		connections.add(id, r, false, "unknown station id")
+		return false
+	}
+
+	connections.add(id, r, true, "")
+
+	return true
+}
+
+// configured returns true if the charge point is configured or may register as anonymous charge point.
+// Anonymous registration is only available without security profile.
+func (cs *CS) configured(id string, secured bool) bool {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if reg, ok := cs.regs[id]; ok && reg.cp != nil {
+		return true
+	}
+
+	reg, ok := cs.regs[""]
+	return ok && reg.cp != nil && !secured
+}
@@ -0,0 +1,89 @@
+package ocpp
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckClient(t *testing.T) {
+	cs := &CS{regs: map[string]*registration{
+		"known":  {cp: new(CP)},
+		"secure": {cp: new(CP)},
+	}}
+
+	type testcase struct {
+		id, user, password string
+		acceptUnknown      bool
+		accepted           bool
+	}
+
+	check := func(tcs []testcase) {
+		for _, tc := range tcs {
+			conf.AcceptUnknown = tc.acceptUnknown
+
+			r := httptest.NewRequest("GET", "/"+tc.id, nil)
+			if tc.user != "" {
+				r.SetBasicAuth(tc.user, tc.password)
+			}
+
+			assert.Equal(t, tc.accepted, cs.checkClient(tc.id, r), tc)
+		}
+
+		conf.AcceptUnknown = false
+	}
+
+	// without security profile
+	check([]testcase{
+		{"known", "", "", false, true},
+		{"unknown", "", "", false, false},
+		{"unknown", "", "", true, true},
+	})
+
+	require.NoError(t, SetPassword("secure", "secret"))
+	assert.True(t, HasPassword("secure"))
+
+	// security profile 1 requires passwords for all charge points
+	check([]testcase{
+		{"known", "", "", false, false},
+		{"unknown", "", "", true, false},
+		{"secure", "", "", false, false},
+		{"secure", "secure", "wrong", false, false},
+		{"secure", "other", "secret", false, false},
+		{"secure", "secure", "secret", false, true},
+	})
+
+	audit := Audit()
+	require.NotEmpty(t, audit)
+	assert.Equal(t, "secure", audit[len(audit)-1].ID)
+	assert.True(t, audit[len(audit)-1].Accepted)
+
+	require.NoError(t, SetPassword("secure", ""))
+	assert.False(t, HasPassword("secure"))
+}
+
+func TestAnonymousRegistration(t *testing.T) {
+	cs := &CS{regs: map[string]*registration{
+		"": {cp: new(CP)},
+	}}
+
+	assert.True(t, cs.checkClient("any", httptest.NewRequest("GET", "/any", nil)))
+
+	// no wildcard with security profile
+	require.NoError(t, SetPassword("any", "secret"))
+	defer func() { require.NoError(t, SetPassword("any", "")) }()
+
+	r := httptest.NewRequest("GET", "/any", nil)
+	r.SetBasicAuth("any", "secret")
+	assert.False(t, cs.checkClient("any", r))
+}
+
+func TestConfigure(t *testing.T) {
+	assert.Error(t, Configure(Config{Cert: "cert.pem"}))
+	assert.Error(t, Configure(Config{Cert: "missing.pem", Key: "missing.key"}))
+	require.NoError(t, Configure(Config{}))
+	assert.Equal(t, 8887, conf.Port)
+	assert.Equal(t, 8888, conf.TLSPort)
+}
@@ -0,0 +1,290 @@
+package ocpp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// file transfers between evcc and charge points
+//
+// Firmware images are published for download and diagnostics are received by upload. Both are addressed
+// by a random token since charge points cannot authenticate against the evcc api. Diagnostics tokens are
+// single-use and received files are named by evcc. Published firmware images expire with their token,
+// received diagnostics are kept for diagnosticsRetention.
+//
+// Transfers use plain HTTP(S) via the evcc api at the configured network url, FTP is not supported.
+
+const (
+	TransferFirmware    = "firmware"
+	TransferDiagnostics = "diagnostics"
+
+	transferTimeout      = 24 * time.Hour
+	diagnosticsRetention = 30 * 24 * time.Hour
+)
+
+var ErrUnknownTransfer = errors.New("unknown or expired transfer")
+
+// File is a stored firmware image or diagnostics file
+type File struct {
+	Name     string    `json:"name"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+type transfer struct {
+	id, kind string
+	file     string // stored file name
+	created  time.Time
+}
+
+type transfers struct {
+	mu     sync.Mutex
+	dir    string
+	uri    string // base url of the transfer endpoint as reachable by charge points
+	tokens map[string]transfer
+}
+
+var files = &transfers{
+	dir:    filepath.Join(os.TempDir(), "evcc-ocpp"),
+	tokens: make(map[string]transfer),
+}
+
+// ConfigureTransfer sets the storage directory and the base url charge points use to reach the transfer endpoint
+func ConfigureTransfer(dir, uri string) {
+	files.mu.Lock()
+	defer files.mu.Unlock()
+
+	files.dir = dir
+	files.uri = strings.TrimSuffix(uri, "/")
+}
+
+// TransferURL returns the absolute url of the path relative to the transfer endpoint
+func TransferURL(path string) string {
+	files.mu.Lock()
+	defer files.mu.Unlock()
+
+	return files.uri + "/" + path
+}
+
+// validName checks that the name can safely be used as path element
+func validName(name string) error {
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return fmt.Errorf("invalid name: %s", name)
+	}
+	return nil
+}
+
+func (t *transfers) path(id, kind, name string) (string, error) {
+	for _, s := range []string{id, name} {
+		if err := validName(s); err != nil {
+			return "", err
+		}
+	}
+
+	return filepath.Join(t.dir, id, kind, name), nil
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (t *transfers) add(token, id, kind, file string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.expire()
+	t.tokens[token] = transfer{id: id, kind: kind, file: file, created: time.Now()}
+}
+
+// expire removes expired tokens, firmware images and diagnostics, including files left over from previous runs
+func (t *transfers) expire() {
+	for k, v := range t.tokens {
+		if time.Since(v.created) > transferTimeout {
+			delete(t.tokens, k)
+		}
+	}
+
+	t.prune(TransferFirmware, transferTimeout)
+	t.prune(TransferDiagnostics, diagnosticsRetention)
+}
+
+// prune removes files of given kind older than max age
+func (t *transfers) prune(kind string, age time.Duration) {
+	paths, _ := filepath.Glob(filepath.Join(t.dir, "*", kind, "*"))
+	for _, path := range paths {
+		if fi, err := os.Stat(path); err == nil && fi.Mode().IsRegular() && time.Since(fi.ModTime()) > age {
+			_ = os.Remove(path)
+		}
+	}
+}
+
+func (t *transfers) get(token, kind string) (transfer, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.lookup(token, kind)
+}
+
+// take returns the transfer and invalidates its token
+func (t *transfers) take(token, kind string) (transfer, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res, err := t.lookup(token, kind)
+	if err == nil {
+		delete(t.tokens, token)
+	}
+
+	return res, err
+}
+
+func (t *transfers) lookup(token, kind string) (transfer, error) {
+	res, ok := t.tokens[token]
+	if !ok || res.kind != kind || time.Since(res.created) > transferTimeout {
+		return transfer{}, ErrUnknownTransfer
+	}
+
+	return res, nil
+}
+
+// write stores the file, existing files are never overwritten
+func (t *transfers) write(id, kind, name string, r io.Reader) error {
+	path, err := t.path(id, kind, name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		_ = os.Remove(path)
+		return err
+	}
+
+	return f.Close()
+}
+
+// PublishFirmware stores the firmware image and returns the download path relative to the transfer endpoint
+func PublishFirmware(id, name string, r io.Reader) (string, error) {
+	if err := validName(name); err != nil {
+		return "", err
+	}
+
+	// images are stored by token to keep earlier downloads intact
+	token := newToken()
+	if err := files.write(id, TransferFirmware, token, r); err != nil {
+		return "", err
+	}
+
+	files.add(token, id, TransferFirmware, token)
+
+	return token + "/" + name, nil
+}
+
+// OpenFirmware opens a published firmware image for download
+func OpenFirmware(token string) (*os.File, error) {
+	t, err := files.get(token, TransferFirmware)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := files.path(t.id, t.kind, t.file)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
+
+// DiagnosticsUpload returns the upload path relative to the transfer endpoint
+func DiagnosticsUpload(id string) (string, error) {
+	if err := validName(id); err != nil {
+		return "", err
+	}
+
+	token := newToken()
+	files.add(token, id, TransferDiagnostics, "")
+
+	return token + "/", nil
+}
+
+// diagnosticsExt matches file extensions kept from the uploaded file name
+var diagnosticsExt = regexp.MustCompile(`^\.[a-zA-Z0-9]{1,8}$`)
+
+// ReceiveDiagnostics stores a diagnostics file uploaded by the charge point and returns the stored file name.
+// The token is valid for a single upload. Only the extension of the uploaded file name is retained.
+func ReceiveDiagnostics(token, name string, r io.Reader) (string, error) {
+	t, err := files.take(token, TransferDiagnostics)
+	if err != nil {
+		return "", err
+	}
+
+	ext := filepath.Ext(name)
+	if !diagnosticsExt.MatchString(ext) {
+		ext = ".log"
+	}
+
+	file := fmt.Sprintf("diagnostics-%s-%s%s", time.Now().Format("20060102-150405"), token[:8], ext)
+
+	return file, files.write(t.id, t.kind, file, r)
+}
+
+// Diagnostics returns the charge point's received diagnostics files
+func Diagnostics(id string) ([]File, error) {
+	if err := validName(id); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(files.dir, id, TransferDiagnostics))
+	if errors.Is(err, os.ErrNotExist) {
+		return []File{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]File, 0, len(entries))
+	for _, e := range entries {
+		fi, err := e.Info()
+		if err != nil || !fi.Mode().IsRegular() {
+			continue
+		}
+
+		res = append(res, File{
+			Name:     fi.Name(),
+			Size:     fi.Size(),
+			Modified: fi.ModTime(),
+		})
+	}
+
+	return res, nil
+}
+
+// OpenDiagnostics opens a received diagnostics file
+func OpenDiagnostics(id, name string) (*os.File, error) {
+	path, err := files.path(id, TransferDiagnostics, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
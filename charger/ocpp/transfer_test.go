@@ -0,0 +1,132 @@
+package ocpp
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferFirmware(t *testing.T) {
+	files.dir = t.TempDir()
+
+	_, err := PublishFirmware("station", "../firmware.bin", strings.NewReader("fw"))
+	require.Error(t, err)
+
+	path, err := PublishFirmware("station", "firmware.bin", strings.NewReader("fw"))
+	require.NoError(t, err)
+
+	token, name, ok := strings.Cut(path, "/")
+	require.True(t, ok)
+	assert.Equal(t, "firmware.bin", name)
+
+	// publishing again keeps the earlier image
+	_, err = PublishFirmware("station", "firmware.bin", strings.NewReader("fw2"))
+	require.NoError(t, err)
+
+	f, err := OpenFirmware(token)
+	require.NoError(t, err)
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "fw", string(b))
+
+	// tokens are not interchangeable between transfer types
+	_, err = ReceiveDiagnostics(token, "log.txt", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrUnknownTransfer)
+
+	_, err = OpenFirmware("invalid")
+	assert.ErrorIs(t, err, ErrUnknownTransfer)
+}
+
+func TestTransferDiagnostics(t *testing.T) {
+	files.dir = t.TempDir()
+
+	res, err := Diagnostics("station")
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	path, err := DiagnosticsUpload("station")
+	require.NoError(t, err)
+
+	token := strings.TrimSuffix(path, "/")
+	file, err := ReceiveDiagnostics(token, "../../log.zip", strings.NewReader("diagnostics"))
+	require.NoError(t, err)
+	assert.Regexp(t, `^diagnostics-\d{8}-\d{6}-[0-9a-f]{8}\.zip$`, file)
+
+	// tokens are single-use
+	_, err = ReceiveDiagnostics(token, "log.txt", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrUnknownTransfer)
+
+	res, err = Diagnostics("station")
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, file, res[0].Name)
+	assert.Equal(t, int64(len("diagnostics")), res[0].Size)
+
+	_, err = OpenFirmware(token)
+	assert.ErrorIs(t, err, ErrUnknownTransfer)
+}
+
+func TestTransferFirmwareExpiry(t *testing.T) {
+	files.dir = t.TempDir()
+
+	path, err := PublishFirmware("station", "firmware.bin", strings.NewReader("fw"))
+	require.NoError(t, err)
+
+	token, _, _ := strings.Cut(path, "/")
+
+	// expire token and image
+	files.mu.Lock()
+	tr := files.tokens[token]
+	tr.created = tr.created.Add(-transferTimeout - time.Minute)
+	files.tokens[token] = tr
+	files.mu.Unlock()
+
+	image := filepath.Join(files.dir, "station", TransferFirmware, token)
+	old := time.Now().Add(-transferTimeout - time.Minute)
+	require.NoError(t, os.Chtimes(image, old, old))
+
+	_, err = OpenFirmware(token)
+	assert.ErrorIs(t, err, ErrUnknownTransfer)
+
+	_, err = PublishFirmware("station", "firmware.bin", strings.NewReader("fw"))
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, image)
+}
+
+func TestTransferDiagnosticsRetention(t *testing.T) {
+	files.dir = t.TempDir()
+
+	path, err := DiagnosticsUpload("station")
+	require.NoError(t, err)
+
+	token, _, _ := strings.Cut(path, "/")
+
+	file, err := ReceiveDiagnostics(token, "log.txt", strings.NewReader("diagnostics"))
+	require.NoError(t, err)
+
+	diag := filepath.Join(files.dir, "station", TransferDiagnostics, file)
+	old := time.Now().Add(-diagnosticsRetention - time.Minute)
+	require.NoError(t, os.Chtimes(diag, old, old))
+
+	_, err = DiagnosticsUpload("station")
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, diag)
+}
+
+func TestTransferURL(t *testing.T) {
+	uri, dir := files.uri, files.dir
+	defer ConfigureTransfer(dir, uri)
+
+	ConfigureTransfer(t.TempDir(), "http://evcc.local:7070/api/ocpp/files/")
+	assert.Equal(t, "http://evcc.local:7070/api/ocpp/files/token/firmware.bin", TransferURL("token/firmware.bin"))
+}
@@ -0,0 +1,553 @@
+package ocpp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+const (
+	upstreamRetry     = 10 * time.Second // interval for resending messages while the upstream connection is down
+	upstreamAuthCache = 5 * time.Minute  // validity of upstream authorization results
+)
+
+var ErrUpstreamDisconnected = errors.New("upstream disconnected")
+
+// Voltage is the nominal voltage for converting upstream power limits, set from the site configuration
+var Voltage = 230.0
+
+// Upstream forwards the charge point to a third-party central system (proxy mode).
+// Boot, status, authorization, transaction and meter messages are forwarded to the upstream central system.
+// Upstream charging profiles are not forwarded but exposed as limit for evcc to overlay its own charging profile.
+type Upstream struct {
+	log    *util.Logger
+	cp     *CP
+	client ocpp16.ChargePoint
+
+	mu       sync.Mutex
+	txns     map[int]int                          // upstream transaction id by local transaction id
+	profiles map[int][]*types.ChargingProfile     // upstream charging profiles by connector
+	received map[*types.ChargingProfile]time.Time // relative schedule reference time
+	auths    map[string]upstreamAuth              // cached authorization results by id tag
+	subs     []chan struct{}
+
+	queue     chan func() error
+	heartbeat sync.Once
+	done      chan struct{}
+}
+
+type upstreamAuth struct {
+	status  types.AuthorizationStatus
+	expires time.Time
+}
+
+// NewUpstream creates an upstream connection for the charge point. Id defaults to the charge point's id.
+// The connection is closed once the context is cancelled.
+func NewUpstream(ctx context.Context, cp *CP, uri, id, password string) *Upstream {
+	if id == "" {
+		id = cp.ID()
+	}
+
+	client := ws.NewClient()
+	if password != "" {
+		client.SetBasicAuth(id, password)
+	}
+
+	dispatcher := ocppj.NewDefaultClientDispatcher(ocppj.NewFIFOClientQueue(0))
+	dispatcher.SetTimeout(Timeout)
+
+	client.SetRequestedSubProtocol(types.V16Subprotocol)
+	endpoint := ocppj.NewClient(id, client, dispatcher, nil, core.Profile, smartcharging.Profile)
+
+	u := &Upstream{
+		log:      util.NewLogger("ocpp-upstream"),
+		cp:       cp,
+		client:   ocpp16.NewChargePoint(id, endpoint, client),
+		txns:     make(map[int]int),
+		profiles: make(map[int][]*types.ChargingProfile),
+		received: make(map[*types.ChargingProfile]time.Time),
+		auths:    make(map[string]upstreamAuth),
+		queue:    make(chan func() error, 100),
+		done:     make(chan struct{}),
+	}
+
+	u.client.SetCoreHandler(&upstreamHandler{u})
+	u.client.SetSmartChargingHandler(&upstreamHandler{u})
+
+	client.SetReconnectedHandler(func() {
+		u.log.DEBUG.Printf("%s: reconnected", id)
+		u.BootNotification(cp.BootNotificationResult)
+	})
+
+	go func() {
+		u.log.DEBUG.Printf("%s: connecting to %s", id, uri)
+
+		// websocket client reconnects automatically once connected
+		for err := u.client.Start(uri); err != nil; err = u.client.Start(uri) {
+			u.log.ERROR.Printf("%s: %v", id, err)
+
+			if !u.sleep(upstreamRetry) {
+				return
+			}
+		}
+
+		u.log.DEBUG.Printf("%s: connected", id)
+
+		// boot notification precedes queued messages
+		if err := u.bootNotification(cp.BootNotificationResult); err != nil {
+			u.log.ERROR.Printf("%s: %v", id, err)
+		}
+
+		u.run()
+	}()
+
+	go func() {
+		<-ctx.Done()
+		close(u.done)
+		u.client.Stop()
+		u.log.DEBUG.Printf("%s: stopped", id)
+	}()
+
+	return u
+}
+
+// Done is closed once the upstream connection has been stopped
+func (u *Upstream) Done() <-chan struct{} {
+	return u.done
+}
+
+// sleep waits for the given duration. Returns false if the upstream connection has been stopped.
+func (u *Upstream) sleep(d time.Duration) bool {
+	select {
+	case <-u.done:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// run sends queued messages in order, retrying while disconnected
+func (u *Upstream) run() {
+	for {
+		var fun func() error
+
+		select {
+		case <-u.done:
+			return
+		case fun = <-u.queue:
+		}
+
+		for {
+			err := fun()
+			if err == nil {
+				break
+			}
+
+			if u.client.IsConnected() {
+				u.log.ERROR.Printf("%s: %v", u.cp.ID(), err)
+				break
+			}
+
+			if !u.sleep(upstreamRetry) {
+				return
+			}
+		}
+	}
+}
+
+// send queues a message for the upstream central system
+func (u *Upstream) send(fun func() error) {
+	if u == nil {
+		return
+	}
+
+	select {
+	case u.queue <- fun:
+	default:
+		u.log.WARN.Printf("%s: queue full, dropping message", u.cp.ID())
+	}
+}
+
+// call sends a message synchronously on the client, bypassing the retry queue.
+// Queued messages are not waited for, the request is subject to the dispatcher timeout.
+func (u *Upstream) call(fun func() error) error {
+	if !u.client.IsConnected() {
+		return ErrUpstreamDisconnected
+	}
+
+	return fun()
+}
+
+func (u *Upstream) bootNotification(boot *core.BootNotificationRequest) error {
+	res, err := u.client.BootNotification("evcc", "evcc", func(request *core.BootNotificationRequest) {
+		if boot != nil {
+			*request = *boot
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.Status != core.RegistrationStatusAccepted {
+		u.log.WARN.Printf("%s: boot notification %s", u.cp.ID(), res.Status)
+	}
+
+	if res.Interval > 0 {
+		u.heartbeat.Do(func() {
+			go u.heartbeats(time.Duration(res.Interval) * time.Second)
+		})
+	}
+
+	return nil
+}
+
+func (u *Upstream) heartbeats(interval time.Duration) {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-u.done:
+			return
+		case <-tick.C:
+		}
+
+		u.send(func() error {
+			_, err := u.client.Heartbeat()
+			return err
+		})
+	}
+}
+
+// Authorize asks the upstream central system to authorize the id tag.
+// Results are cached since the charge point typically authorizes before starting the transaction.
+func (u *Upstream) Authorize(idTag string) (types.AuthorizationStatus, error) {
+	u.mu.Lock()
+	cached, ok := u.auths[idTag]
+	u.mu.Unlock()
+
+	if ok && time.Now().Before(cached.expires) {
+		return cached.status, nil
+	}
+
+	var res types.AuthorizationStatus
+
+	err := u.call(func() error {
+		conf, err := u.client.Authorize(idTag)
+		if err == nil && conf.IdTagInfo != nil {
+			res = conf.IdTagInfo.Status
+		}
+		return err
+	})
+
+	if err == nil {
+		u.mu.Lock()
+		maps.DeleteFunc(u.auths, func(_ string, a upstreamAuth) bool {
+			return time.Now().After(a.expires)
+		})
+		u.auths[idTag] = upstreamAuth{status: res, expires: time.Now().Add(upstreamAuthCache)}
+		u.mu.Unlock()
+	}
+
+	return res, err
+}
+
+// clearAuthCache removes cached authorization results
+func (u *Upstream) clearAuthCache() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	clear(u.auths)
+}
+
+// BootNotification forwards the charge point's boot notification
+func (u *Upstream) BootNotification(request *core.BootNotificationRequest) {
+	u.send(func() error {
+		return u.bootNotification(request)
+	})
+}
+
+// StatusNotification forwards the connector status
+func (u *Upstream) StatusNotification(request *core.StatusNotificationRequest) {
+	u.send(func() error {
+		_, err := u.client.StatusNotification(request.ConnectorId, request.ErrorCode, request.Status, func(r *core.StatusNotificationRequest) {
+			*r = *request
+		})
+		return err
+	})
+}
+
+// MeterValues forwards meter values, mapping the transaction id
+func (u *Upstream) MeterValues(request *core.MeterValuesRequest) {
+	u.send(func() error {
+		_, err := u.client.MeterValues(request.ConnectorId, request.MeterValue, func(r *core.MeterValuesRequest) {
+			if request.TransactionId != nil {
+				if txn, ok := u.transaction(*request.TransactionId); ok {
+					r.TransactionId = &txn
+				}
+			}
+		})
+		return err
+	})
+}
+
+// StartTransaction forwards the transaction start and records the upstream transaction id
+func (u *Upstream) StartTransaction(request *core.StartTransactionRequest, txnId int) {
+	u.send(func() error {
+		res, err := u.client.StartTransaction(request.ConnectorId, request.IdTag, request.MeterStart, request.Timestamp, func(r *core.StartTransactionRequest) {
+			r.ReservationId = request.ReservationId
+		})
+		if err != nil {
+			return err
+		}
+
+		u.mu.Lock()
+		u.txns[txnId] = res.TransactionId
+		u.mu.Unlock()
+
+		if res.IdTagInfo != nil && res.IdTagInfo.Status != types.AuthorizationStatusAccepted {
+			u.log.WARN.Printf("%s: transaction %d %s", u.cp.ID(), txnId, res.IdTagInfo.Status)
+		}
+
+		return nil
+	})
+}
+
+// StopTransaction forwards the transaction stop
+func (u *Upstream) StopTransaction(request *core.StopTransactionRequest) {
+	u.send(func() error {
+		txn, ok := u.transaction(request.TransactionId)
+		if !ok {
+			return fmt.Errorf("unknown transaction: %d", request.TransactionId)
+		}
+
+		_, err := u.client.StopTransaction(request.MeterStop, request.Timestamp, txn, func(r *core.StopTransactionRequest) {
+			r.IdTag = request.IdTag
+			r.Reason = request.Reason
+			r.TransactionData = request.TransactionData
+		})
+
+		if err == nil {
+			u.mu.Lock()
+			delete(u.txns, request.TransactionId)
+			u.mu.Unlock()
+		}
+
+		return err
+	})
+}
+
+func (u *Upstream) transaction(txnId int) (int, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	res, ok := u.txns[txnId]
+	return res, ok
+}
+
+// Subscribe signals changes of the upstream charging profiles
+func (u *Upstream) Subscribe() <-chan struct{} {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	u.subs = append(u.subs, ch)
+
+	return ch
+}
+
+// signalLimit notifies subscribers. Must only be called while holding lock.
+func (u *Upstream) signalLimit() {
+	for _, ch := range u.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// setChargingProfile stores the upstream charging profile, replacing profiles with same id or purpose and stack level
+func (u *Upstream) setChargingProfile(connectorId int, profile *types.ChargingProfile) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for id, profiles := range u.profiles {
+		u.profiles[id] = slices.DeleteFunc(profiles, func(p *types.ChargingProfile) bool {
+			match := p.ChargingProfileId == profile.ChargingProfileId ||
+				id == connectorId && p.ChargingProfilePurpose == profile.ChargingProfilePurpose && p.StackLevel == profile.StackLevel
+
+			if match {
+				delete(u.received, p)
+			}
+
+			return match
+		})
+	}
+
+	u.profiles[connectorId] = append(u.profiles[connectorId], profile)
+	u.received[profile] = time.Now()
+
+	u.signalLimit()
+}
+
+// clearChargingProfile removes matching upstream charging profiles
+func (u *Upstream) clearChargingProfile(request *smartcharging.ClearChargingProfileRequest) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var found bool
+	for id, profiles := range u.profiles {
+		if request.ConnectorId != nil && *request.ConnectorId != id {
+			continue
+		}
+
+		u.profiles[id] = slices.DeleteFunc(profiles, func(p *types.ChargingProfile) bool {
+			match := (request.Id == nil || *request.Id == p.ChargingProfileId) &&
+				(request.ChargingProfilePurpose == "" || request.ChargingProfilePurpose == p.ChargingProfilePurpose) &&
+				(request.StackLevel == nil || *request.StackLevel == p.StackLevel)
+
+			if match {
+				delete(u.received, p)
+				found = true
+			}
+
+			return match
+		})
+	}
+
+	u.signalLimit()
+
+	return found
+}
+
+// Limit returns the current limit (A) imposed by upstream charging profiles for the connector.
+// Power limits are converted using the site voltage and 3 phases unless the period specifies the number of phases.
+func (u *Upstream) Limit(connectorId int) (float64, bool) {
+	return u.LimitAt(connectorId, time.Now())
+}
+
+// LimitAt returns the current limit (A) imposed by upstream charging profiles for the connector at given time
+func (u *Upstream) LimitAt(connectorId int, ts time.Time) (float64, bool) {
+	if u == nil {
+		return 0, false
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.pruneProfiles(time.Now())
+
+	res, found := math.MaxFloat64, false
+
+	// charge point wide and connector profiles apply
+	for _, id := range []int{0, connectorId} {
+		for _, p := range u.profiles[id] {
+			if limit, ok := profileLimit(p, u.received[p], ts); ok {
+				res, found = min(res, limit), true
+			}
+		}
+
+		if connectorId == 0 {
+			break
+		}
+	}
+
+	return res, found
+}
+
+// pruneProfiles removes expired charging profiles. Must only be called while holding lock.
+func (u *Upstream) pruneProfiles(now time.Time) {
+	for id, profiles := range u.profiles {
+		u.profiles[id] = slices.DeleteFunc(profiles, func(p *types.ChargingProfile) bool {
+			expired := profileExpired(p, u.received[p], now)
+			if expired {
+				delete(u.received, p)
+			}
+			return expired
+		})
+
+		if len(u.profiles[id]) == 0 {
+			delete(u.profiles, id)
+		}
+	}
+}
+
+// profileExpired returns true if the profile no longer applies after given time
+func profileExpired(p *types.ChargingProfile, received, now time.Time) bool {
+	if p.ValidTo != nil && now.After(p.ValidTo.Time) {
+		return true
+	}
+
+	s := p.ChargingSchedule
+	if s == nil || s.Duration == nil || p.ChargingProfileKind == types.ChargingProfileKindRecurring {
+		return false
+	}
+
+	start := received
+	if s.StartSchedule != nil {
+		start = s.StartSchedule.Time
+	}
+
+	return now.Sub(start) > time.Duration(*s.Duration)*time.Second
+}
+
+// profileLimit returns the profile's current limit at given time
+func profileLimit(p *types.ChargingProfile, received, now time.Time) (float64, bool) {
+	if p.ValidFrom != nil && now.Before(p.ValidFrom.Time) || p.ValidTo != nil && now.After(p.ValidTo.Time) {
+		return 0, false
+	}
+
+	s := p.ChargingSchedule
+	if s == nil || len(s.ChargingSchedulePeriod) == 0 {
+		return 0, false
+	}
+
+	start := received
+	if s.StartSchedule != nil {
+		start = s.StartSchedule.Time
+	}
+
+	elapsed := int(now.Sub(start).Seconds())
+	if elapsed < 0 || s.Duration != nil && elapsed > *s.Duration {
+		return 0, false
+	}
+
+	var (
+		period types.ChargingSchedulePeriod
+		found  bool
+	)
+
+	for _, sp := range s.ChargingSchedulePeriod {
+		if sp.StartPeriod <= elapsed {
+			period, found = sp, true
+		}
+	}
+
+	if !found {
+		return 0, false
+	}
+
+	if s.ChargingRateUnit == types.ChargingRateUnitWatts {
+		phases := 3
+		if period.NumberPhases != nil && *period.NumberPhases > 0 {
+			phases = *period.NumberPhases
+		}
+
+		return period.Limit / Voltage / float64(phases), true
+	}
+
+	return period.Limit, true
+}
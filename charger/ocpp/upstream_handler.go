@@ -0,0 +1,118 @@
+package ocpp
+
+import (
+	"slices"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+)
+
+// upstreamHandler handles requests of the upstream central system.
+// Operational requests are relayed to the charge point, charging profiles are kept for evcc to overlay.
+type upstreamHandler struct {
+	u *Upstream
+}
+
+// core
+
+func (h *upstreamHandler) OnChangeAvailability(request *core.ChangeAvailabilityRequest) (*core.ChangeAvailabilityConfirmation, error) {
+	status := core.AvailabilityStatusAccepted
+	if err := h.u.cp.ChangeAvailabilityRequest(request.ConnectorId, request.Type); err != nil {
+		status = core.AvailabilityStatusRejected
+	}
+
+	return core.NewChangeAvailabilityConfirmation(status), nil
+}
+
+func (h *upstreamHandler) OnChangeConfiguration(request *core.ChangeConfigurationRequest) (*core.ChangeConfigurationConfirmation, error) {
+	status := core.ConfigurationStatusAccepted
+	if err := h.u.cp.ChangeConfigurationRequest(request.Key, request.Value); err != nil {
+		status = core.ConfigurationStatusRejected
+	}
+
+	return core.NewChangeConfigurationConfirmation(status), nil
+}
+
+func (h *upstreamHandler) OnClearCache(request *core.ClearCacheRequest) (*core.ClearCacheConfirmation, error) {
+	h.u.clearAuthCache()
+	return core.NewClearCacheConfirmation(core.ClearCacheStatusAccepted), nil
+}
+
+func (h *upstreamHandler) OnDataTransfer(request *core.DataTransferRequest) (*core.DataTransferConfirmation, error) {
+	return core.NewDataTransferConfirmation(core.DataTransferStatusUnknownVendorId), nil
+}
+
+func (h *upstreamHandler) OnGetConfiguration(request *core.GetConfigurationRequest) (*core.GetConfigurationConfirmation, error) {
+	res, err := h.u.cp.GetConfigurationRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request.Key) == 0 {
+		return res, nil
+	}
+
+	conf := core.NewGetConfigurationConfirmation(nil)
+	for _, key := range request.Key {
+		if idx := slices.IndexFunc(res.ConfigurationKey, func(k core.ConfigurationKey) bool { return k.Key == key }); idx >= 0 {
+			conf.ConfigurationKey = append(conf.ConfigurationKey, res.ConfigurationKey[idx])
+		} else {
+			conf.UnknownKey = append(conf.UnknownKey, key)
+		}
+	}
+
+	return conf, nil
+}
+
+func (h *upstreamHandler) OnRemoteStartTransaction(request *core.RemoteStartTransactionRequest) (*core.RemoteStartTransactionConfirmation, error) {
+	connectorId := 1
+	if request.ConnectorId != nil {
+		connectorId = *request.ConnectorId
+	}
+
+	status := types.RemoteStartStopStatusAccepted
+	if err := h.u.cp.RemoteStartTransactionRequest(connectorId, request.IdTag); err != nil {
+		status = types.RemoteStartStopStatusRejected
+	}
+
+	return core.NewRemoteStartTransactionConfirmation(status), nil
+}
+
+func (h *upstreamHandler) OnRemoteStopTransaction(request *core.RemoteStopTransactionRequest) (*core.RemoteStopTransactionConfirmation, error) {
+	// charging is controlled by evcc
+	return core.NewRemoteStopTransactionConfirmation(types.RemoteStartStopStatusRejected), nil
+}
+
+func (h *upstreamHandler) OnReset(request *core.ResetRequest) (*core.ResetConfirmation, error) {
+	return core.NewResetConfirmation(core.ResetStatusRejected), nil
+}
+
+func (h *upstreamHandler) OnUnlockConnector(request *core.UnlockConnectorRequest) (*core.UnlockConnectorConfirmation, error) {
+	return core.NewUnlockConnectorConfirmation(core.UnlockStatusNotSupported), nil
+}
+
+// smart charging
+
+func (h *upstreamHandler) OnSetChargingProfile(request *smartcharging.SetChargingProfileRequest) (*smartcharging.SetChargingProfileConfirmation, error) {
+	if request.ChargingProfile == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	h.u.setChargingProfile(request.ConnectorId, request.ChargingProfile)
+
+	return smartcharging.NewSetChargingProfileConfirmation(smartcharging.ChargingProfileStatusAccepted), nil
+}
+
+func (h *upstreamHandler) OnClearChargingProfile(request *smartcharging.ClearChargingProfileRequest) (*smartcharging.ClearChargingProfileConfirmation, error) {
+	status := smartcharging.ClearChargingProfileStatusUnknown
+	if h.u.clearChargingProfile(request) {
+		status = smartcharging.ClearChargingProfileStatusAccepted
+	}
+
+	return smartcharging.NewClearChargingProfileConfirmation(status), nil
+}
+
+func (h *upstreamHandler) OnGetCompositeSchedule(request *smartcharging.GetCompositeScheduleRequest) (*smartcharging.GetCompositeScheduleConfirmation, error) {
+	return smartcharging.NewGetCompositeScheduleConfirmation(smartcharging.GetCompositeScheduleStatusRejected), nil
+}
@@ -0,0 +1,103 @@
+package ocpp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamProfileLimit(t *testing.T) {
+	now := time.Now()
+	duration := 3600
+	phases := 1
+
+	profile := &types.ChargingProfile{
+		ChargingSchedule: &types.ChargingSchedule{
+			Duration:         &duration,
+			ChargingRateUnit: types.ChargingRateUnitAmperes,
+			ChargingSchedulePeriod: []types.ChargingSchedulePeriod{
+				types.NewChargingSchedulePeriod(0, 16),
+				types.NewChargingSchedulePeriod(1800, 8),
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		received time.Time
+		limit    float64
+		ok       bool
+	}{
+		{now, 16, true},
+		{now.Add(-time.Hour / 2), 8, true},
+		{now.Add(-2 * time.Hour), 0, false},
+		{now.Add(time.Minute), 0, false},
+	} {
+		limit, ok := profileLimit(profile, tc.received, now)
+		assert.Equal(t, tc.ok, ok)
+		assert.Equal(t, tc.limit, limit)
+	}
+
+	// power limits
+	profile.ChargingSchedule.ChargingRateUnit = types.ChargingRateUnitWatts
+	profile.ChargingSchedule.ChargingSchedulePeriod = []types.ChargingSchedulePeriod{
+		types.NewChargingSchedulePeriod(0, 6900),
+		{StartPeriod: 1800, Limit: 2300, NumberPhases: &phases},
+	}
+
+	limit, _ := profileLimit(profile, now, now)
+	assert.Equal(t, 10.0, limit)
+
+	limit, _ = profileLimit(profile, now.Add(-time.Hour/2), now)
+	assert.Equal(t, 10.0, limit)
+
+	// site voltage
+	defer func(v float64) { Voltage = v }(Voltage)
+	Voltage = 115
+
+	limit, _ = profileLimit(profile, now, now)
+	assert.Equal(t, 20.0, limit)
+
+	// expired profile
+	profile.ValidTo = types.NewDateTime(now.Add(-time.Minute))
+	_, ok := profileLimit(profile, now, now)
+	assert.False(t, ok)
+}
+
+func TestUpstreamPruneProfiles(t *testing.T) {
+	now := time.Now()
+	duration := 3600
+
+	newProfile := func(id int) *types.ChargingProfile {
+		return &types.ChargingProfile{
+			ChargingProfileId: id,
+			StackLevel:        id,
+			ChargingSchedule: &types.ChargingSchedule{
+				Duration:               &duration,
+				ChargingRateUnit:       types.ChargingRateUnitAmperes,
+				ChargingSchedulePeriod: []types.ChargingSchedulePeriod{types.NewChargingSchedulePeriod(0, 16)},
+			},
+		}
+	}
+
+	expired, active := newProfile(1), newProfile(2)
+
+	u := &Upstream{
+		profiles: map[int][]*types.ChargingProfile{1: {expired, active}},
+		received: map[*types.ChargingProfile]time.Time{
+			expired: now.Add(-2 * time.Hour),
+			active:  now,
+		},
+	}
+
+	u.pruneProfiles(now)
+	assert.Equal(t, []*types.ChargingProfile{active}, u.profiles[1])
+	assert.NotContains(t, u.received, expired)
+
+	// replaced profile
+	replaced := newProfile(2)
+	u.setChargingProfile(1, replaced)
+	assert.Equal(t, []*types.ChargingProfile{replaced}, u.profiles[1])
+	assert.Len(t, u.received, 1)
+}
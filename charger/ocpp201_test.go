@@ -0,0 +1,254 @@
+package charger
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/ocpp"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+func (suite *ocppTestSuite) startChargingStation(id string, evseId int) (ocpp201.ChargingStation, *ChargingStationHandler) {
+	handler := &ChargingStationHandler{
+		triggerC: make(chan remotecontrol.MessageTrigger, 1),
+		profileC: make(chan *types.ChargingProfile, 1),
+	}
+
+	client := ws.NewClient()
+	dispatcher := ocppj.NewDefaultClientDispatcher(ocppj.NewFIFOClientQueue(0))
+	endpoint := ocppj.NewClient(id, client, dispatcher, nil, availability.Profile, meter.Profile, provisioning.Profile, remotecontrol.Profile, smartcharging.Profile, transactions.Profile)
+
+	cs := ocpp201.NewChargingStation(id, endpoint, client)
+	cs.SetAvailabilityHandler(handler)
+	cs.SetProvisioningHandler(handler)
+	cs.SetRemoteControlHandler(handler)
+	cs.SetSmartChargingHandler(handler)
+
+	// let csms handle the trigger messages
+	go func() {
+		for msg := range handler.triggerC {
+			suite.handleTrigger201(cs, evseId, msg)
+		}
+	}()
+
+	return cs, handler
+}
+
+func (suite *ocppTestSuite) handleTrigger201(cs ocpp201.ChargingStation, evseId int, msg remotecontrol.MessageTrigger) {
+	switch msg {
+	case remotecontrol.MessageTriggerBootNotification:
+		if _, err := cs.BootNotification(provisioning.BootReasonTriggered, "model", "vendor"); err != nil {
+			suite.T().Log("BootNotification:", err)
+		}
+
+	case remotecontrol.MessageTriggerStatusNotification:
+		if _, err := cs.StatusNotification(types.NewDateTime(suite.clock.Now()), availability.ConnectorStatusAvailable, evseId, 1); err != nil {
+			suite.T().Log("StatusNotification:", err)
+		}
+
+	case remotecontrol.MessageTriggerMeterValues:
+		multiplier := 3
+		if _, err := cs.MeterValues(evseId, []types.MeterValue{
+			{
+				Timestamp: types.DateTime{Time: suite.clock.Now()},
+				SampledValue: []types.SampledValue{
+					{Measurand: types.MeasurandPowerActiveImport, Value: 1000},
+					{Measurand: types.MeasurandEnergyActiveImportRegister, Value: 1.2, UnitOfMeasure: &types.UnitOfMeasure{Unit: "Wh", Multiplier: &multiplier}},
+				},
+			},
+		}); err != nil {
+			suite.T().Log("MeterValues:", err)
+		}
+	}
+}
+
+func (suite *ocppTestSuite) TestConnect201() {
+	cs1, handler := suite.startChargingStation("test-201", 1)
+	suite.Require().NoError(cs1.Start(ocppTestUrl))
+	suite.Require().True(cs1.IsConnected())
+
+	c1, err := NewOCPP("test-201", 1, "", "", 0, false, false, ocppTestConnectTimeout)
+	suite.Require().NoError(err)
+
+	// drain profiles sent during setup
+	for len(handler.profileC) > 0 {
+		<-handler.profileC
+	}
+
+	// status
+	{
+		suite.clock.Add(ocpp.Timeout)
+		c1.conn.TestClock(suite.clock)
+
+		_, err = c1.Status()
+		suite.Require().NoError(err)
+	}
+
+	// authorized transaction
+	{
+		expectedIdTag := "tag"
+
+		_, err := cs1.TransactionEvent(transactions.TransactionEventStarted, types.NewDateTime(suite.clock.Now()), transactions.TriggerReasonAuthorized, 0, transactions.Transaction{
+			TransactionID: "txn-1",
+			ChargingState: transactions.ChargingStateCharging,
+		}, func(request *transactions.TransactionEventRequest) {
+			request.Evse = &types.EVSE{ID: 1}
+			request.IDToken = &types.IdToken{IdToken: expectedIdTag, Type: types.IdTokenTypeISO14443}
+		})
+		suite.Require().NoError(err)
+
+		id, err := c1.Identify()
+		suite.Require().NoError(err)
+		suite.Equal(expectedIdTag, id)
+
+		status, err := c1.Status()
+		suite.Require().NoError(err)
+		suite.Equal(api.StatusC, status)
+
+		txn, err := c1.Connector().TransactionID()
+		suite.Require().NoError(err)
+		suite.NotZero(txn)
+	}
+
+	// charging profile
+	{
+		suite.Require().NoError(c1.MaxCurrent(10))
+		profile := <-handler.profileC
+		suite.Require().Len(profile.ChargingSchedule, 1)
+		suite.Equal(10.0, profile.ChargingSchedule[0].ChargingSchedulePeriod[0].Limit)
+	}
+
+	// end transaction
+	{
+		_, err := cs1.TransactionEvent(transactions.TransactionEventEnded, types.NewDateTime(suite.clock.Now()), transactions.TriggerReasonEVDeparted, 1, transactions.Transaction{
+			TransactionID: "txn-1",
+			ChargingState: transactions.ChargingStateIdle,
+		})
+		suite.Require().NoError(err)
+
+		status, err := c1.Status()
+		suite.Require().NoError(err)
+		suite.Equal(api.StatusA, status)
+	}
+}
+
+type ChargingStationHandler struct {
+	triggerC chan remotecontrol.MessageTrigger
+	profileC chan *types.ChargingProfile
+}
+
+// availability
+
+func (handler *ChargingStationHandler) OnChangeAvailability(request *availability.ChangeAvailabilityRequest) (*availability.ChangeAvailabilityResponse, error) {
+	return availability.NewChangeAvailabilityResponse(availability.ChangeAvailabilityStatusAccepted), nil
+}
+
+// provisioning
+
+func (handler *ChargingStationHandler) OnGetBaseReport(request *provisioning.GetBaseReportRequest) (*provisioning.GetBaseReportResponse, error) {
+	return provisioning.NewGetBaseReportResponse(types.GenericDeviceModelStatusNotSupported), nil
+}
+
+func (handler *ChargingStationHandler) OnGetReport(request *provisioning.GetReportRequest) (*provisioning.GetReportResponse, error) {
+	return provisioning.NewGetReportResponse(types.GenericDeviceModelStatusNotSupported), nil
+}
+
+func (handler *ChargingStationHandler) OnGetVariables(request *provisioning.GetVariablesRequest) (*provisioning.GetVariablesResponse, error) {
+	values := map[string]string{
+		"ProfileStackLevel":  "1",
+		"PeriodsPerSchedule": "1",
+		"RateUnit":           "A,W",
+	}
+
+	var res []provisioning.GetVariableResult
+	for _, v := range request.GetVariableData {
+		status := provisioning.GetVariableStatusUnknownVariable
+		value, ok := values[v.Variable.Name]
+		if ok {
+			status = provisioning.GetVariableStatusAccepted
+		}
+
+		res = append(res, provisioning.GetVariableResult{
+			AttributeStatus: status,
+			AttributeValue:  value,
+			Component:       v.Component,
+			Variable:        v.Variable,
+		})
+	}
+
+	return provisioning.NewGetVariablesResponse(res), nil
+}
+
+func (handler *ChargingStationHandler) OnReset(request *provisioning.ResetRequest) (*provisioning.ResetResponse, error) {
+	return provisioning.NewResetResponse(provisioning.ResetStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnSetNetworkProfile(request *provisioning.SetNetworkProfileRequest) (*provisioning.SetNetworkProfileResponse, error) {
+	return provisioning.NewSetNetworkProfileResponse(provisioning.SetNetworkProfileStatusRejected), nil
+}
+
+func (handler *ChargingStationHandler) OnSetVariables(request *provisioning.SetVariablesRequest) (*provisioning.SetVariablesResponse, error) {
+	var res []provisioning.SetVariableResult
+	for _, v := range request.SetVariableData {
+		res = append(res, provisioning.SetVariableResult{
+			AttributeStatus: provisioning.SetVariableStatusAccepted,
+			Component:       v.Component,
+			Variable:        v.Variable,
+		})
+	}
+
+	return provisioning.NewSetVariablesResponse(res), nil
+}
+
+// remote control
+
+func (handler *ChargingStationHandler) OnRequestStartTransaction(request *remotecontrol.RequestStartTransactionRequest) (*remotecontrol.RequestStartTransactionResponse, error) {
+	return remotecontrol.NewRequestStartTransactionResponse(remotecontrol.RequestStartStopStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnRequestStopTransaction(request *remotecontrol.RequestStopTransactionRequest) (*remotecontrol.RequestStopTransactionResponse, error) {
+	return remotecontrol.NewRequestStopTransactionResponse(remotecontrol.RequestStartStopStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnTriggerMessage(request *remotecontrol.TriggerMessageRequest) (*remotecontrol.TriggerMessageResponse, error) {
+	defer func() {
+		select {
+		case handler.triggerC <- request.RequestedMessage:
+		default:
+		}
+	}()
+	return remotecontrol.NewTriggerMessageResponse(remotecontrol.TriggerMessageStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnUnlockConnector(request *remotecontrol.UnlockConnectorRequest) (*remotecontrol.UnlockConnectorResponse, error) {
+	return remotecontrol.NewUnlockConnectorResponse(remotecontrol.UnlockStatusUnlocked), nil
+}
+
+// smart charging
+
+func (handler *ChargingStationHandler) OnClearChargingProfile(request *smartcharging.ClearChargingProfileRequest) (*smartcharging.ClearChargingProfileResponse, error) {
+	return smartcharging.NewClearChargingProfileResponse(smartcharging.ClearChargingProfileStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnGetChargingProfiles(request *smartcharging.GetChargingProfilesRequest) (*smartcharging.GetChargingProfilesResponse, error) {
+	return smartcharging.NewGetChargingProfilesResponse(smartcharging.GetChargingProfileStatusNoProfiles), nil
+}
+
+func (handler *ChargingStationHandler) OnGetCompositeSchedule(request *smartcharging.GetCompositeScheduleRequest) (*smartcharging.GetCompositeScheduleResponse, error) {
+	return smartcharging.NewGetCompositeScheduleResponse(smartcharging.GetCompositeScheduleStatusRejected, request.EvseID), nil
+}
+
+func (handler *ChargingStationHandler) OnSetChargingProfile(request *smartcharging.SetChargingProfileRequest) (*smartcharging.SetChargingProfileResponse, error) {
+	select {
+	case handler.profileC <- request.ChargingProfile:
+	default:
+	}
+	return smartcharging.NewSetChargingProfileResponse(smartcharging.ChargingProfileStatusAccepted), nil
+}
@@ -0,0 +1,144 @@
+package charger
+
+// LICENSE
+
+// Copyright (c) 2024 premultiply, andig
+
+// This module is NOT covered by the MIT license. All rights reserved.
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/ocpp"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+const localListDelay = 5 * time.Second // debounce vehicle configuration changes
+
+// ocppAuthConfig is the id tag whitelist configuration
+type ocppAuthConfig struct {
+	Unknown           string   // handling of unknown id tags: reject or restricted, accept all if empty
+	Guests            []string // id tags accepted in addition to vehicle identifiers
+	LocalList         bool     // push known id tags to the charge point's local authorization list
+	RestrictedCurrent float64  // current limit for unknown id tags in restricted mode
+}
+
+// vehicleIdentifiers returns the identifiers of all configured vehicles
+func vehicleIdentifiers() []string {
+	var res []string
+	for _, v := range config.Instances(config.Vehicles().Devices()) {
+		res = append(res, v.Identifiers()...)
+	}
+	return res
+}
+
+// enableAuthorization validates id tags against vehicle identifiers and guest tags
+func (c *OCPP) enableAuthorization(ctx context.Context, cc ocppAuthConfig) error {
+	mode, err := ocpp.ParseAuthorizationMode(cc.Unknown)
+	if err != nil {
+		return err
+	}
+
+	auth := &ocpp.Authorization{
+		Unknown: mode,
+		Identifiers: func() []string {
+			return append(vehicleIdentifiers(), cc.Guests...)
+		},
+	}
+
+	c.cp.SetAuthorization(auth)
+
+	if mode == ocpp.AuthorizationRestricted {
+		c.restrictedCurrent = cc.RestrictedCurrent
+		go c.restrictionWatcher(ctx)
+	}
+
+	if cc.LocalList {
+		go c.localListUpdater(ctx, auth)
+	}
+
+	return nil
+}
+
+// restrictionWatcher re-applies the current setpoint when restricted transactions start or end
+func (c *OCPP) restrictionWatcher(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.conn.RestrictC():
+		}
+
+		if c.conn.Restricted() {
+			c.log.DEBUG.Printf("restricted transaction: limiting current to %.1fA", c.restrictedCurrent)
+		}
+
+		if err := c.updateCurrent(); err != nil {
+			c.log.ERROR.Printf("restricted: %v", err)
+		}
+	}
+}
+
+// restrictCurrent limits the current for restricted transactions
+func (c *OCPP) restrictCurrent(current float64) float64 {
+	if c.restrictedCurrent > 0 && c.conn.Restricted() {
+		return min(current, c.restrictedCurrent)
+	}
+	return current
+}
+
+// localListUpdater pushes the known id tags to the charge point whenever vehicles are added or removed
+func (c *OCPP) localListUpdater(ctx context.Context, auth *ocpp.Authorization) {
+	updateC := make(chan struct{}, 1)
+	updateC <- struct{}{}
+
+	unsubscribe := config.Vehicles().Subscribe(func(config.Operation, config.Device[api.Vehicle]) {
+		select {
+		case updateC <- struct{}{}:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	var sent []string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-updateC:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(localListDelay):
+		}
+
+		idTags := auth.LocalList()
+		if slices.Equal(idTags, sent) && sent != nil {
+			continue
+		}
+
+		if err := c.cp.UpdateLocalList(idTags); err != nil {
+			c.log.ERROR.Printf("local list: %v", err)
+			continue
+		}
+
+		c.log.DEBUG.Printf("local list: %d id tags", len(idTags))
+		sent = idTags
+	}
+}
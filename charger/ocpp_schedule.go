@@ -0,0 +1,258 @@
+package charger
+
+// LICENSE
+
+// Copyright (c) 2024 premultiply, andig
+
+// This module is NOT covered by the MIT license. All rights reserved.
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/planner"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+)
+
+const (
+	scheduleHold    = 5 * time.Minute // validity of the current setpoint if not refreshed
+	scheduleRefresh = time.Minute     // setpoint refresh interval
+)
+
+// enableSchedule sends the charging plan as multi-period charging schedule.
+// A fallback profile with failsafe current takes over once evcc stops refreshing the schedule.
+func (c *OCPP) enableSchedule(ctx context.Context, failsafeCurrent float64) {
+	c.schedule = true
+	c.failsafeCurrent = failsafeCurrent
+
+	if !c.fallbackAvailable() {
+		c.log.WARN.Println("schedule: no stack level available for fallback profile, using failsafe current at end of schedule")
+	}
+
+	go c.scheduleRefresher(ctx)
+}
+
+// scheduleRefresher periodically re-sends the charging schedule to extend the setpoint validity.
+// Refreshing starts once the charge point has connected and evcc has sent its first setpoint.
+func (c *OCPP) scheduleRefresher(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-c.cp.HasConnected():
+	}
+
+	tick := time.NewTicker(scheduleRefresh)
+	defer tick.Stop()
+
+	var connected bool
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+
+		if !c.cp.Connected() {
+			// charge point may have lost its profiles
+			if connected {
+				c.log.WARN.Println("schedule: disconnected, charger continues with planned schedule and failsafe current")
+			}
+			connected = false
+
+			c.mu.Lock()
+			c.fallback = false
+			c.mu.Unlock()
+
+			continue
+		}
+		connected = true
+
+		if err := c.setFallbackProfile(); err != nil {
+			c.log.ERROR.Printf("schedule: %v", err)
+		}
+
+		c.mu.Lock()
+		sent := c.setpointSent
+		c.mu.Unlock()
+
+		// loadpoint has not yet set a current
+		if !sent {
+			continue
+		}
+
+		if err := c.updateCurrent(); err != nil {
+			c.log.ERROR.Printf("schedule: %v", err)
+		}
+	}
+}
+
+// fallbackAvailable returns true if the fallback profile can be installed below the schedule's stack level
+func (c *OCPP) fallbackAvailable() bool {
+	return !c.stackLevelZero && c.cp.StackLevel > 0
+}
+
+// setFallbackProfile installs the fallback profile if not already installed
+func (c *OCPP) setFallbackProfile() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fallback || !c.fallbackAvailable() {
+		return nil
+	}
+
+	if err := c.conn.SetChargingProfileRequest(c.createFallbackChargingProfile()); err != nil {
+		return err
+	}
+
+	c.fallback = true
+
+	return nil
+}
+
+// createFallbackChargingProfile returns a TxDefaultChargingProfile with failsafe current below the schedule's stack level
+func (c *OCPP) createFallbackChargingProfile() *types.ChargingProfile {
+	return &types.ChargingProfile{
+		ChargingProfileId:      c.cp.ChargingProfileId + 1,
+		StackLevel:             c.cp.StackLevel - 1,
+		ChargingProfilePurpose: types.ChargingProfilePurposeTxDefaultProfile,
+		ChargingProfileKind:    types.ChargingProfileKindAbsolute,
+		ChargingSchedule: &types.ChargingSchedule{
+			StartSchedule:          types.NewDateTime(time.Now().Add(-time.Minute)),
+			ChargingRateUnit:       c.cp.ChargingRateUnit,
+			ChargingSchedulePeriod: []types.ChargingSchedulePeriod{c.chargingSchedulePeriod(0, c.upstreamCurrent(c.failsafeCurrent))},
+		},
+	}
+}
+
+var _ loadpoint.PlanController = (*OCPP)(nil)
+
+// LoadpointPlan implements loadpoint.PlanController
+func (c *OCPP) LoadpointPlan(plan planner.Plan) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.chargingPlan = plan
+}
+
+// plan returns the loadpoint's active charging plan
+func (c *OCPP) plan() planner.Plan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.chargingPlan
+}
+
+// slotCurrent converts the planned slot power to current within the loadpoint's current limits
+func (c *OCPP) slotCurrent(power float64) float64 {
+	phases := c.phases
+	if c.lp != nil {
+		phases = c.lp.GetPhases()
+	}
+	if phases == 0 {
+		phases = 3
+	}
+
+	current := power / voltage / float64(phases)
+	if c.lp != nil && current > 0 {
+		// reduced power slots still charge at min current
+		current = min(max(current, c.lp.GetMinCurrent()), c.lp.GetMaxCurrent())
+	}
+
+	return math.Trunc(10*current) / 10
+}
+
+// createScheduleChargingProfile returns a TxDefaultChargingProfile applying the current setpoint for the hold duration,
+// followed by the planned slots. Outside planned slots the failsafe current applies.
+// All periods are limited by the upstream charging profiles in proxy mode.
+func (c *OCPP) createScheduleChargingProfile(now time.Time, current float64, plan planner.Plan) *types.ChargingProfile {
+	start := now.Add(-time.Minute)
+
+	var periods []types.ChargingSchedulePeriod
+	add := func(ts time.Time, current float64) {
+		limitTs := ts
+		if limitTs.Before(now) {
+			limitTs = now
+		}
+
+		period := c.chargingSchedulePeriod(int(ts.Sub(start).Seconds()), c.upstreamCurrentAt(limitTs, current))
+
+		// replace periods without duration
+		if n := len(periods); n > 0 && periods[n-1].StartPeriod == period.StartPeriod {
+			periods = periods[:n-1]
+		}
+
+		// merge periods with identical limits
+		if n := len(periods); n > 0 && periods[n-1].Limit == period.Limit {
+			return
+		}
+
+		periods = append(periods, period)
+	}
+
+	add(start, current)
+
+	cursor := now.Add(scheduleHold)
+	for _, slot := range plan {
+		if !slot.End.After(cursor) {
+			continue
+		}
+
+		if slot.Start.After(cursor) {
+			add(cursor, c.failsafeCurrent)
+			cursor = slot.Start
+		}
+
+		add(cursor, c.slotCurrent(slot.Power))
+		cursor = slot.End
+	}
+
+	// schedule ends with fallback profile or failsafe current
+	end := cursor
+	if !c.fallbackAvailable() {
+		add(cursor, c.failsafeCurrent)
+	}
+
+	// limit to supported periods, fallback profile takes over afterwards
+	if c.cp.MaxPeriods > 0 && len(periods) > c.cp.MaxPeriods {
+		end = start.Add(time.Duration(periods[c.cp.MaxPeriods].StartPeriod) * time.Second)
+		periods = periods[:c.cp.MaxPeriods]
+	}
+
+	schedule := &types.ChargingSchedule{
+		StartSchedule:          types.NewDateTime(start),
+		ChargingRateUnit:       c.cp.ChargingRateUnit,
+		ChargingSchedulePeriod: periods,
+	}
+
+	if c.fallbackAvailable() {
+		duration := int(end.Sub(start).Seconds())
+		schedule.Duration = &duration
+	}
+
+	res := &types.ChargingProfile{
+		ChargingProfileId:      c.cp.ChargingProfileId,
+		ChargingProfilePurpose: types.ChargingProfilePurposeTxDefaultProfile,
+		ChargingProfileKind:    types.ChargingProfileKindAbsolute,
+		ChargingSchedule:       schedule,
+	}
+
+	if !c.stackLevelZero {
+		res.StackLevel = c.cp.StackLevel
+	}
+
+	return res
+}
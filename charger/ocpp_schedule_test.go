@@ -0,0 +1,88 @@
+package charger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/ocpp"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/planner"
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduleChargingProfile(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	slot := func(start, end time.Duration, power float64) planner.Slot {
+		return planner.Slot{Rate: api.Rate{Start: now.Add(start), End: now.Add(end)}, Power: power}
+	}
+
+	type period struct {
+		start int
+		limit float64
+	}
+
+	tc := []struct {
+		desc       string
+		stackLevel int
+		plan       planner.Plan
+		periods    []period
+		duration   *int
+	}{
+		{"no plan", 1, nil, []period{{0, 10}}, lo.ToPtr(360)},
+		{"no plan, no fallback", 0, nil, []period{{0, 10}, {360, 6}}, nil},
+		{"plan", 1, planner.Plan{
+			slot(time.Hour, 2*time.Hour, 11040),
+			slot(2*time.Hour, 3*time.Hour, 6900),
+		}, []period{{0, 10}, {360, 6}, {3660, 16}, {7260, 10}}, lo.ToPtr(10860)},
+		{"active slot", 1, planner.Plan{
+			slot(-time.Hour, time.Hour, 11040),
+		}, []period{{0, 10}, {360, 16}}, lo.ToPtr(3660)},
+		{"expired slot", 1, planner.Plan{
+			slot(-time.Hour, time.Minute, 11040),
+		}, []period{{0, 10}}, lo.ToPtr(360)},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := &OCPP{
+				log:             util.NewLogger("foo"),
+				cp:              ocpp.NewChargePoint(util.NewLogger("foo"), "test"),
+				phases:          3,
+				schedule:        true,
+				failsafeCurrent: 6,
+			}
+			c.cp.StackLevel = tc.stackLevel
+
+			profile := c.createScheduleChargingProfile(now, 10, tc.plan)
+			require.Equal(t, tc.stackLevel, profile.StackLevel)
+
+			assert.Equal(t, tc.periods, lo.Map(profile.ChargingSchedule.ChargingSchedulePeriod, func(p types.ChargingSchedulePeriod, _ int) period {
+				return period{p.StartPeriod, p.Limit}
+			}))
+			assert.Equal(t, tc.duration, profile.ChargingSchedule.Duration)
+		})
+	}
+}
+
+func TestScheduleSlotCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetPhases().Return(3).AnyTimes()
+	lp.EXPECT().GetMinCurrent().Return(6.0).AnyTimes()
+	lp.EXPECT().GetMaxCurrent().Return(16.0).AnyTimes()
+
+	c := &OCPP{lp: lp}
+
+	assert.Equal(t, 0.0, c.slotCurrent(0))
+	assert.Equal(t, 6.0, c.slotCurrent(2300), "below min current")
+	assert.Equal(t, 10.0, c.slotCurrent(6900))
+	assert.Equal(t, 16.0, c.slotCurrent(13800), "above max current")
+}
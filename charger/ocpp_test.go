@@ -39,6 +39,9 @@ type ocppTestSuite struct {
 func (suite *ocppTestSuite) SetupSuite() {
 	ocpp.Timeout = 5 * time.Second
 
+	// charge points connect before their chargers are configured
+	suite.Require().NoError(ocpp.Configure(ocpp.Config{AcceptUnknown: true}))
+
 	// setup cs so we can overwrite logger afterwards
 	_ = ocpp.Instance()
 	ocppj.SetLogger(&ocppLogger{suite.T()})
@@ -47,10 +50,13 @@ func (suite *ocppTestSuite) SetupSuite() {
 	suite.NotNil(ocpp.Instance())
 }
 
-func (suite *ocppTestSuite) startChargePoint(id string, connectorId int) (ocpp16.ChargePoint, *ocppj.Client) {
+func (suite *ocppTestSuite) startChargePoint(id string, connectorId int) (ocpp16.ChargePoint, *ocppj.Client, *ChargePointHandler) {
 	// set a handler for all callback functions
 	handler := &ChargePointHandler{
 		triggerC: make(chan remotetrigger.MessageTrigger, 1),
+		profileC: make(chan *types.ChargingProfile, 1),
+		listC:    make(chan []localauth.AuthorizationData, 1),
+		fwC:      make(chan string, 1),
 	}
 
 	// ocppj endpoint with handler
@@ -62,6 +68,8 @@ func (suite *ocppTestSuite) startChargePoint(id string, connectorId int) (ocpp16
 	// create charge point with handler
 	cp := ocpp16.NewChargePoint(id, endpoint, client)
 	cp.SetCoreHandler(handler)
+	cp.SetFirmwareManagementHandler(handler)
+	cp.SetLocalAuthListHandler(handler)
 	cp.SetRemoteTriggerHandler(handler)
 	cp.SetSmartChargingHandler(handler)
 
@@ -72,7 +80,7 @@ func (suite *ocppTestSuite) startChargePoint(id string, connectorId int) (ocpp16
 		}
 	}()
 
-	return cp, endpoint
+	return cp, endpoint, handler
 }
 
 func (suite *ocppTestSuite) handleTrigger(cp ocpp16.ChargePoint, connectorId int, msg remotetrigger.MessageTrigger) {
@@ -107,7 +115,7 @@ func (suite *ocppTestSuite) handleTrigger(cp ocpp16.ChargePoint, connectorId int
 
 func (suite *ocppTestSuite) TestConnect() {
 	// 1st charge point- remote
-	cp1, _ := suite.startChargePoint("test-1", 1)
+	cp1, _, _ := suite.startChargePoint("test-1", 1)
 	suite.Require().NoError(cp1.Start(ocppTestUrl))
 	suite.Require().True(cp1.IsConnected())
 
@@ -156,7 +164,7 @@ func (suite *ocppTestSuite) TestConnect() {
 	}
 
 	// 2nd charge point - remote
-	cp2, _ := suite.startChargePoint("test-2", 1)
+	cp2, _, _ := suite.startChargePoint("test-2", 1)
 	suite.Require().NoError(cp2.Start(ocppTestUrl))
 	suite.Require().True(cp2.IsConnected())
 
@@ -174,7 +182,7 @@ func (suite *ocppTestSuite) TestConnect() {
 	}
 
 	// error on unconfigured 2nd charge point
-	cp3, _ := suite.startChargePoint("unconfigured", 1)
+	cp3, _, _ := suite.startChargePoint("unconfigured", 1)
 	_, err = cp3.BootNotification("model", "vendor")
 	suite.Require().Error(err)
 
@@ -198,7 +206,7 @@ WAIT_DISCONNECT:
 
 func (suite *ocppTestSuite) TestAutoStart() {
 	// 1st charge point- remote
-	cp1, _ := suite.startChargePoint("test-3", 1)
+	cp1, _, _ := suite.startChargePoint("test-3", 1)
 	suite.Require().NoError(cp1.Start(ocppTestUrl))
 	suite.Require().True(cp1.IsConnected())
 
@@ -238,7 +246,7 @@ func (suite *ocppTestSuite) TestAutoStart() {
 
 func (suite *ocppTestSuite) TestTimeout() {
 	// 1st charge point- remote
-	cp1, ocppjClient := suite.startChargePoint("test-4", 1)
+	cp1, ocppjClient, _ := suite.startChargePoint("test-4", 1)
 	suite.Require().NoError(cp1.Start(ocppTestUrl))
 	suite.Require().True(cp1.IsConnected())
 
@@ -254,3 +262,221 @@ func (suite *ocppTestSuite) TestTimeout() {
 
 	suite.Require().NoError(err)
 }
+
+func (suite *ocppTestSuite) TestSchedule() {
+	// 1st charge point- remote
+	cp1, _, handler := suite.startChargePoint("test-5", 1)
+	suite.Require().NoError(cp1.Start(ocppTestUrl))
+	suite.Require().True(cp1.IsConnected())
+
+	// 1st charge point- local
+	c1, err := NewOCPP("test-5", 1, "", "", 0, false, false, ocppTestConnectTimeout)
+	suite.Require().NoError(err)
+
+	c1.enableSchedule(suite.T().Context(), 6)
+
+	// drain profiles sent during setup
+	for len(handler.profileC) > 0 {
+		<-handler.profileC
+	}
+
+	// fallback profile below schedule stack level
+	suite.Require().NoError(c1.setFallbackProfile())
+	fallback := <-handler.profileC
+	suite.Equal(0, fallback.StackLevel)
+	suite.Equal(6.0, fallback.ChargingSchedule.ChargingSchedulePeriod[0].Limit)
+	suite.Nil(fallback.ChargingSchedule.Duration)
+
+	// setpoint expires unless refreshed, limited to single period supported by charge point
+	suite.Require().NoError(c1.MaxCurrent(10))
+	profile := <-handler.profileC
+	suite.Equal(1, profile.StackLevel)
+	suite.Len(profile.ChargingSchedule.ChargingSchedulePeriod, 1)
+	suite.Equal(10.0, profile.ChargingSchedule.ChargingSchedulePeriod[0].Limit)
+	suite.Require().NotNil(profile.ChargingSchedule.Duration)
+	suite.Equal(int((time.Minute + scheduleHold).Seconds()), *profile.ChargingSchedule.Duration)
+}
+
+func (suite *ocppTestSuite) TestAuthorization() {
+	// 1st charge point- remote
+	cp1, _, handler := suite.startChargePoint("test-6", 1)
+	suite.Require().NoError(cp1.Start(ocppTestUrl))
+	suite.Require().True(cp1.IsConnected())
+
+	// 1st charge point- local
+	c1, err := NewOCPP("test-6", 1, "", "", 0, false, false, ocppTestConnectTimeout)
+	suite.Require().NoError(err)
+
+	c1.cp.SetAuthorization(&ocpp.Authorization{
+		Unknown: ocpp.AuthorizationReject,
+		Identifiers: func() []string {
+			return []string{"known", "guest-*"}
+		},
+	})
+
+	// authorize
+	res, err := cp1.Authorize("unknown")
+	suite.Require().NoError(err)
+	suite.Equal(types.AuthorizationStatusInvalid, res.IdTagInfo.Status)
+
+	res, err = cp1.Authorize("guest-1")
+	suite.Require().NoError(err)
+	suite.Equal(types.AuthorizationStatusAccepted, res.IdTagInfo.Status)
+
+	// rejected transaction
+	txn, err := cp1.StartTransaction(1, "unknown", 0, types.NewDateTime(suite.clock.Now()))
+	suite.Require().NoError(err)
+	suite.Equal(types.AuthorizationStatusInvalid, txn.IdTagInfo.Status)
+
+	id, err := c1.Identify()
+	suite.Require().NoError(err)
+	suite.Empty(id)
+
+	_, err = cp1.StopTransaction(0, types.NewDateTime(suite.clock.Now()), txn.TransactionId)
+	suite.Require().NoError(err)
+
+	// accepted transaction
+	txn, err = cp1.StartTransaction(1, "known", 0, types.NewDateTime(suite.clock.Now()))
+	suite.Require().NoError(err)
+	suite.Equal(types.AuthorizationStatusAccepted, txn.IdTagInfo.Status)
+
+	id, err = c1.Identify()
+	suite.Require().NoError(err)
+	suite.Equal("known", id)
+
+	// local authorization list
+	suite.Require().NoError(c1.cp.UpdateLocalList([]string{"known"}))
+	list := <-handler.listC
+	suite.Require().Len(list, 1)
+	suite.Equal("known", list[0].IdTag)
+}
+
+func (suite *ocppTestSuite) TestMaintenance() {
+	// 1st charge point- remote
+	cp1, _, handler := suite.startChargePoint("test-7", 1)
+	suite.Require().NoError(cp1.Start(ocppTestUrl))
+	suite.Require().True(cp1.IsConnected())
+
+	// 1st charge point- local
+	c1, err := NewOCPP("test-7", 1, "", "", 0, false, false, ocppTestConnectTimeout)
+	suite.Require().NoError(err)
+
+	// firmware update
+	suite.Require().NoError(c1.cp.UpdateFirmwareRequest("http://localhost/firmware.bin", time.Time{}))
+	suite.Equal("http://localhost/firmware.bin", <-handler.fwC)
+	suite.Equal("Requested", c1.cp.Maintenance().Firmware)
+
+	_, err = cp1.FirmwareStatusNotification(firmware.FirmwareStatusDownloading)
+	suite.Require().NoError(err)
+	suite.Equal(string(firmware.FirmwareStatusDownloading), c1.cp.Maintenance().Firmware)
+
+	// diagnostics
+	file, err := c1.cp.GetDiagnosticsRequest("http://localhost/upload/")
+	suite.Require().NoError(err)
+	suite.Equal("diagnostics.log", file)
+	suite.Equal("http://localhost/upload/", <-handler.fwC)
+
+	_, err = cp1.DiagnosticsStatusNotification(firmware.DiagnosticsStatusUploaded)
+	suite.Require().NoError(err)
+
+	res := c1.cp.Maintenance()
+	suite.Equal(string(firmware.DiagnosticsStatusUploaded), res.Diagnostics)
+	suite.Equal("diagnostics.log", res.DiagnosticsFile)
+}
+
+func (suite *ocppTestSuite) TestUpstream() {
+	// third-party central system
+	upstream := &CentralSystemHandler{
+		txnC:   make(chan *core.StartTransactionRequest, 1),
+		meterC: make(chan *core.MeterValuesRequest, 1),
+		stopC:  make(chan *core.StopTransactionRequest, 1),
+	}
+
+	connC := make(chan string, 1)
+
+	cs := ocpp16.NewCentralSystem(nil, nil)
+	cs.SetCoreHandler(upstream)
+	cs.SetNewChargePointHandler(func(cp ocpp16.ChargePointConnection) {
+		connC <- cp.ID()
+	})
+	go cs.Start(8888, "/{ws}")
+
+	// 1st charge point- remote
+	cp1, _, handler := suite.startChargePoint("test-8", 1)
+	suite.Require().NoError(cp1.Start(ocppTestUrl))
+	suite.Require().True(cp1.IsConnected())
+
+	// 1st charge point- local
+	c1, err := NewOCPP("test-8", 1, "", "", 0, false, false, ocppTestConnectTimeout)
+	suite.Require().NoError(err)
+
+	c1.enableUpstream(suite.T().Context(), ocppUpstreamConfig{URI: "ws://localhost:8888", StationId: "upstream-8"})
+
+	select {
+	case id := <-connC:
+		suite.Equal("upstream-8", id)
+	case <-time.After(ocppTestConnectTimeout + ocpp.Timeout):
+		suite.FailNow("upstream connect timeout")
+	}
+
+	// upstream authorization
+	res, err := cp1.Authorize("blocked")
+	suite.Require().NoError(err)
+	suite.Equal(types.AuthorizationStatusBlocked, res.IdTagInfo.Status)
+
+	// forwarded transaction with upstream transaction id
+	txn, err := cp1.StartTransaction(1, "tag", 0, types.NewDateTime(suite.clock.Now()))
+	suite.Require().NoError(err)
+	suite.Equal("tag", (<-upstream.txnC).IdTag)
+
+	_, err = cp1.MeterValues(1, []types.MeterValue{{
+		Timestamp:    types.NewDateTime(suite.clock.Now()),
+		SampledValue: []types.SampledValue{{Measurand: types.MeasurandEnergyActiveImportRegister, Value: "1200"}},
+	}}, func(request *core.MeterValuesRequest) {
+		request.TransactionId = &txn.TransactionId
+	})
+	suite.Require().NoError(err)
+
+	meter := <-upstream.meterC
+	suite.Require().NotNil(meter.TransactionId)
+	suite.Equal(4711, *meter.TransactionId)
+
+	// upstream profile caps evcc's profile
+	for len(handler.profileC) > 0 {
+		<-handler.profileC
+	}
+
+	resC := make(chan error, 1)
+	suite.Require().NoError(cs.SetChargingProfile("upstream-8", func(_ *smartcharging.SetChargingProfileConfirmation, err error) {
+		resC <- err
+	}, 1, &types.ChargingProfile{
+		ChargingProfileId:      1,
+		ChargingProfilePurpose: types.ChargingProfilePurposeTxDefaultProfile,
+		ChargingProfileKind:    types.ChargingProfileKindAbsolute,
+		ChargingSchedule: &types.ChargingSchedule{
+			StartSchedule:          types.NewDateTime(time.Now().Add(-time.Minute)),
+			ChargingRateUnit:       types.ChargingRateUnitAmperes,
+			ChargingSchedulePeriod: []types.ChargingSchedulePeriod{types.NewChargingSchedulePeriod(0, 10)},
+		},
+	}))
+	suite.Require().NoError(<-resC)
+
+	limit, ok := c1.upstream.Limit(1)
+	suite.True(ok)
+	suite.Equal(10.0, limit)
+
+	// disabled setpoint re-applied on upstream limit change
+	profile := <-handler.profileC
+	suite.Equal(0.0, profile.ChargingSchedule.ChargingSchedulePeriod[0].Limit)
+
+	suite.Require().NoError(c1.MaxCurrent(16))
+	suite.Require().NoError(c1.Enable(true))
+
+	profile = <-handler.profileC
+	suite.Equal(10.0, profile.ChargingSchedule.ChargingSchedulePeriod[0].Limit)
+
+	// forwarded transaction stop
+	_, err = cp1.StopTransaction(1200, types.NewDateTime(suite.clock.Now()), txn.TransactionId)
+	suite.Require().NoError(err)
+	suite.Equal(4711, (<-upstream.stopC).TransactionId)
+}
@@ -1,7 +1,11 @@
 package charger
 
 import (
+	"time"
+
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
@@ -9,6 +13,9 @@ import (
 
 type ChargePointHandler struct {
 	triggerC chan remotetrigger.MessageTrigger
+	profileC chan *types.ChargingProfile
+	listC    chan []localauth.AuthorizationData
+	fwC      chan string // firmware and diagnostics locations
 }
 
 // core
@@ -68,6 +75,10 @@ func (handler *ChargePointHandler) OnTriggerMessage(request *remotetrigger.Trigg
 // smart charging
 
 func (handler *ChargePointHandler) OnSetChargingProfile(request *smartcharging.SetChargingProfileRequest) (*smartcharging.SetChargingProfileConfirmation, error) {
+	select {
+	case handler.profileC <- request.ChargingProfile:
+	default:
+	}
 	return smartcharging.NewSetChargingProfileConfirmation(smartcharging.ChargingProfileStatusAccepted), nil
 }
 
@@ -78,3 +89,87 @@ func (handler *ChargePointHandler) OnClearChargingProfile(request *smartcharging
 func (handler *ChargePointHandler) OnGetCompositeSchedule(request *smartcharging.GetCompositeScheduleRequest) (*smartcharging.GetCompositeScheduleConfirmation, error) {
 	return smartcharging.NewGetCompositeScheduleConfirmation(smartcharging.GetCompositeScheduleStatusAccepted), nil
 }
+
+// local auth list
+
+func (handler *ChargePointHandler) OnGetLocalListVersion(request *localauth.GetLocalListVersionRequest) (*localauth.GetLocalListVersionConfirmation, error) {
+	return localauth.NewGetLocalListVersionConfirmation(1), nil
+}
+
+func (handler *ChargePointHandler) OnSendLocalList(request *localauth.SendLocalListRequest) (*localauth.SendLocalListConfirmation, error) {
+	select {
+	case handler.listC <- request.LocalAuthorizationList:
+	default:
+	}
+	return localauth.NewSendLocalListConfirmation(localauth.UpdateStatusAccepted), nil
+}
+
+// firmware management
+
+func (handler *ChargePointHandler) OnGetDiagnostics(request *firmware.GetDiagnosticsRequest) (*firmware.GetDiagnosticsConfirmation, error) {
+	defer func() { handler.fwC <- request.Location }()
+	return &firmware.GetDiagnosticsConfirmation{FileName: "diagnostics.log"}, nil
+}
+
+func (handler *ChargePointHandler) OnUpdateFirmware(request *firmware.UpdateFirmwareRequest) (*firmware.UpdateFirmwareConfirmation, error) {
+	defer func() { handler.fwC <- request.Location }()
+	return firmware.NewUpdateFirmwareConfirmation(), nil
+}
+
+// CentralSystemHandler is a third-party central system receiving forwarded messages in proxy mode
+type CentralSystemHandler struct {
+	txnC   chan *core.StartTransactionRequest
+	meterC chan *core.MeterValuesRequest
+	stopC  chan *core.StopTransactionRequest
+}
+
+func (handler *CentralSystemHandler) OnAuthorize(chargePointId string, request *core.AuthorizeRequest) (*core.AuthorizeConfirmation, error) {
+	status := types.AuthorizationStatusAccepted
+	if request.IdTag == "blocked" {
+		status = types.AuthorizationStatusBlocked
+	}
+	return core.NewAuthorizationConfirmation(types.NewIdTagInfo(status)), nil
+}
+
+func (handler *CentralSystemHandler) OnBootNotification(chargePointId string, request *core.BootNotificationRequest) (*core.BootNotificationConfirmation, error) {
+	return core.NewBootNotificationConfirmation(types.NewDateTime(time.Now()), 60, core.RegistrationStatusAccepted), nil
+}
+
+func (handler *CentralSystemHandler) OnDataTransfer(chargePointId string, request *core.DataTransferRequest) (*core.DataTransferConfirmation, error) {
+	return core.NewDataTransferConfirmation(core.DataTransferStatusAccepted), nil
+}
+
+func (handler *CentralSystemHandler) OnHeartbeat(chargePointId string, request *core.HeartbeatRequest) (*core.HeartbeatConfirmation, error) {
+	return core.NewHeartbeatConfirmation(types.NewDateTime(time.Now())), nil
+}
+
+func (handler *CentralSystemHandler) OnMeterValues(chargePointId string, request *core.MeterValuesRequest) (*core.MeterValuesConfirmation, error) {
+	// transaction meter values only
+	if request.TransactionId != nil {
+		select {
+		case handler.meterC <- request:
+		default:
+		}
+	}
+	return core.NewMeterValuesConfirmation(), nil
+}
+
+func (handler *CentralSystemHandler) OnStatusNotification(chargePointId string, request *core.StatusNotificationRequest) (*core.StatusNotificationConfirmation, error) {
+	return core.NewStatusNotificationConfirmation(), nil
+}
+
+func (handler *CentralSystemHandler) OnStartTransaction(chargePointId string, request *core.StartTransactionRequest) (*core.StartTransactionConfirmation, error) {
+	select {
+	case handler.txnC <- request:
+	default:
+	}
+	return core.NewStartTransactionConfirmation(types.NewIdTagInfo(types.AuthorizationStatusAccepted), 4711), nil
+}
+
+func (handler *CentralSystemHandler) OnStopTransaction(chargePointId string, request *core.StopTransactionRequest) (*core.StopTransactionConfirmation, error) {
+	select {
+	case handler.stopC <- request:
+	default:
+	}
+	return core.NewStopTransactionConfirmation(), nil
+}
@@ -0,0 +1,90 @@
+package charger
+
+// LICENSE
+
+// Copyright (c) 2024 premultiply, andig
+
+// This module is NOT covered by the MIT license. All rights reserved.
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+import (
+	"context"
+	"time"
+)
+
+const upstreamInterval = time.Minute // re-evaluate upstream charging schedules
+
+// ocppUpstreamConfig is the third-party central system configuration for proxy mode
+type ocppUpstreamConfig struct {
+	URI       string // upstream central system url, e.g. wss://cpo.example.com/ocpp
+	StationId string // station id at the upstream central system, defaults to the charge point's id
+	Password  string // basic auth password
+}
+
+// enableUpstream forwards the charge point to the upstream central system and overlays its charging profiles
+func (c *OCPP) enableUpstream(ctx context.Context, cc ocppUpstreamConfig) {
+	c.upstream = c.cp.EnableUpstream(ctx, cc.URI, cc.StationId, cc.Password)
+	go c.upstreamWatcher(ctx, c.upstream.Subscribe())
+}
+
+// upstreamWatcher re-applies the current setpoint when the upstream limit changes
+func (c *OCPP) upstreamWatcher(ctx context.Context, limitC <-chan struct{}) {
+	tick := time.NewTicker(upstreamInterval)
+	defer tick.Stop()
+
+	var prev float64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.upstream.Done():
+			return
+		case <-limitC:
+		case <-tick.C:
+		}
+
+		limit, ok := c.upstream.Limit(c.conn.ID())
+		if !ok {
+			limit = 0
+		}
+
+		if limit == prev {
+			continue
+		}
+		prev = limit
+
+		if ok {
+			c.log.DEBUG.Printf("upstream: limiting current to %.1fA", limit)
+		}
+
+		if err := c.updateCurrent(); err != nil {
+			c.log.ERROR.Printf("upstream: %v", err)
+		}
+	}
+}
+
+// upstreamCurrent limits the current to the upstream charging profiles
+func (c *OCPP) upstreamCurrent(current float64) float64 {
+	return c.upstreamCurrentAt(time.Now(), current)
+}
+
+// upstreamCurrentAt limits the current to the upstream charging profiles at given time
+func (c *OCPP) upstreamCurrentAt(ts time.Time, current float64) float64 {
+	if c.upstream == nil {
+		return current
+	}
+	if limit, ok := c.upstream.LimitAt(c.conn.ID(), ts); ok {
+		return min(current, limit)
+	}
+	return current
+}
@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _ClassName = "configfilemeterchargervehicletariffcircuitsitemqttdatabasemodbusproxyeebusjavascriptgohemsinfluxmessengersponsorshiploadpoint"
+const _ClassName = "configfilemeterchargervehicletariffcircuitsitemqttdatabasemodbusproxyeebusocppjavascriptgohemsinfluxmessengersponsorshiploadpoint"
 
-var _ClassIndex = [...]uint8{0, 10, 15, 22, 29, 35, 42, 46, 50, 58, 69, 74, 84, 86, 90, 96, 105, 116, 125}
+var _ClassIndex = [...]uint8{0, 10, 15, 22, 29, 35, 42, 46, 50, 58, 69, 74, 78, 88, 90, 94, 100, 109, 120, 129}
 
-const _ClassLowerName = "configfilemeterchargervehicletariffcircuitsitemqttdatabasemodbusproxyeebusjavascriptgohemsinfluxmessengersponsorshiploadpoint"
+const _ClassLowerName = "configfilemeterchargervehicletariffcircuitsitemqttdatabasemodbusproxyeebusocppjavascriptgohemsinfluxmessengersponsorshiploadpoint"
 
 func (i Class) String() string {
 	i -= 1
@@ -36,16 +36,17 @@ func _ClassNoOp() {
 	_ = x[ClassDatabase-(9)]
 	_ = x[ClassModbusProxy-(10)]
 	_ = x[ClassEEBus-(11)]
-	_ = x[ClassJavascript-(12)]
-	_ = x[ClassGo-(13)]
-	_ = x[ClassHEMS-(14)]
-	_ = x[ClassInflux-(15)]
-	_ = x[ClassMessenger-(16)]
-	_ = x[ClassSponsorship-(17)]
-	_ = x[ClassLoadpoint-(18)]
+	_ = x[ClassOcpp-(12)]
+	_ = x[ClassJavascript-(13)]
+	_ = x[ClassGo-(14)]
+	_ = x[ClassHEMS-(15)]
+	_ = x[ClassInflux-(16)]
+	_ = x[ClassMessenger-(17)]
+	_ = x[ClassSponsorship-(18)]
+	_ = x[ClassLoadpoint-(19)]
 }
 
-var _ClassValues = []Class{ClassConfigFile, ClassMeter, ClassCharger, ClassVehicle, ClassTariff, ClassCircuit, ClassSite, ClassMqtt, ClassDatabase, ClassModbusProxy, ClassEEBus, ClassJavascript, ClassGo, ClassHEMS, ClassInflux, ClassMessenger, ClassSponsorship, ClassLoadpoint}
+var _ClassValues = []Class{ClassConfigFile, ClassMeter, ClassCharger, ClassVehicle, ClassTariff, ClassCircuit, ClassSite, ClassMqtt, ClassDatabase, ClassModbusProxy, ClassEEBus, ClassOcpp, ClassJavascript, ClassGo, ClassHEMS, ClassInflux, ClassMessenger, ClassSponsorship, ClassLoadpoint}
 
 var _ClassNameToValueMap = map[string]Class{
 	_ClassName[0:10]:         ClassConfigFile,
@@ -70,20 +71,22 @@ var _ClassNameToValueMap = map[string]Class{
 	_ClassLowerName[58:69]:   ClassModbusProxy,
 	_ClassName[69:74]:        ClassEEBus,
 	_ClassLowerName[69:74]:   ClassEEBus,
-	_ClassName[74:84]:        ClassJavascript,
-	_ClassLowerName[74:84]:   ClassJavascript,
-	_ClassName[84:86]:        ClassGo,
-	_ClassLowerName[84:86]:   ClassGo,
-	_ClassName[86:90]:        ClassHEMS,
-	_ClassLowerName[86:90]:   ClassHEMS,
-	_ClassName[90:96]:        ClassInflux,
-	_ClassLowerName[90:96]:   ClassInflux,
-	_ClassName[96:105]:       ClassMessenger,
-	_ClassLowerName[96:105]:  ClassMessenger,
-	_ClassName[105:116]:      ClassSponsorship,
-	_ClassLowerName[105:116]: ClassSponsorship,
-	_ClassName[116:125]:      ClassLoadpoint,
-	_ClassLowerName[116:125]: ClassLoadpoint,
+	_ClassName[74:78]:        ClassOcpp,
+	_ClassLowerName[74:78]:   ClassOcpp,
+	_ClassName[78:88]:        ClassJavascript,
+	_ClassLowerName[78:88]:   ClassJavascript,
+	_ClassName[88:90]:        ClassGo,
+	_ClassLowerName[88:90]:   ClassGo,
+	_ClassName[90:94]:        ClassHEMS,
+	_ClassLowerName[90:94]:   ClassHEMS,
+	_ClassName[94:100]:       ClassInflux,
+	_ClassLowerName[94:100]:  ClassInflux,
+	_ClassName[100:109]:      ClassMessenger,
+	_ClassLowerName[100:109]: ClassMessenger,
+	_ClassName[109:120]:      ClassSponsorship,
+	_ClassLowerName[109:120]: ClassSponsorship,
+	_ClassName[120:129]:      ClassLoadpoint,
+	_ClassLowerName[120:129]: ClassLoadpoint,
 }
 
 var _ClassNames = []string{
@@ -98,13 +101,14 @@ var _ClassNames = []string{
 	_ClassName[50:58],
 	_ClassName[58:69],
 	_ClassName[69:74],
-	_ClassName[74:84],
-	_ClassName[84:86],
-	_ClassName[86:90],
-	_ClassName[90:96],
-	_ClassName[96:105],
-	_ClassName[105:116],
-	_ClassName[116:125],
+	_ClassName[74:78],
+	_ClassName[78:88],
+	_ClassName[88:90],
+	_ClassName[90:94],
+	_ClassName[94:100],
+	_ClassName[100:109],
+	_ClassName[109:120],
+	_ClassName[120:129],
 }
 
 // ClassString retrieves an enum value from the enum constants string name.
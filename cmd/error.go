@@ -21,6 +21,7 @@ const (
 	ClassDatabase
 	ClassModbusProxy
 	ClassEEBus
+	ClassOcpp
 	ClassJavascript
 	ClassGo
 	ClassHEMS
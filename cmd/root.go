@@ -24,6 +24,7 @@ import (
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/evcc-io/evcc/util/telemetry"
 	_ "github.com/joho/godotenv/autoload"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
@@ -145,6 +146,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 		err = networkSettings(&conf.Network)
 	}
 
+	// configure ocpp file transfers
+	if err == nil {
+		err = wrapErrorWithClass(ClassOcpp, configureOcppTransfer(conf.Database, conf.Network))
+	}
+
 	log.INFO.Printf("listening at :%d", conf.Network.Port)
 
 	// start broadcasting values
@@ -214,6 +220,14 @@ func runRoot(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	// setup prometheus metrics
+	if err == nil && viper.GetBool("metrics") {
+		var metrics *server.Prometheus
+		if metrics, err = server.NewPrometheus(prometheus.DefaultRegisterer); err == nil {
+			go metrics.Run(site, pipe.NewDropper(append(ignoreLogs, ignoreEmpty)...).Pipe(tee.Attach()))
+		}
+	}
+
 	// remove previous fatal startup errors
 	valueChan <- util.Param{Key: keys.Fatal, Val: nil}
 
@@ -223,6 +237,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 		mqtt, err = server.NewMQTT(strings.Trim(conf.Mqtt.Topic, "/"), site)
 		if err == nil {
 			go mqtt.Run(site, pipe.NewDropper(append(ignoreMqtt, ignoreEmpty)...).Pipe(tee.Attach()))
+
+			// home assistant discovery
+			if conf.Mqtt.Discovery != "" {
+				mqtt.Discovery(conf.Mqtt.Discovery, site)
+			}
 		}
 	}
 
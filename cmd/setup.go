@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
@@ -18,6 +19,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/api/globalconfig"
 	"github.com/evcc-io/evcc/charger"
+	"github.com/evcc-io/evcc/charger/ocpp"
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core"
 	"github.com/evcc-io/evcc/core/circuit"
@@ -48,6 +50,7 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/libp2p/zeroconf/v2"
+	"github.com/mitchellh/go-homedir"
 	"github.com/samber/lo"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
@@ -502,6 +505,11 @@ func configureEnvironment(cmd *cobra.Command, conf *globalconfig.All) (err error
 		err = wrapErrorWithClass(ClassEEBus, configureEEBus(&conf.EEBus))
 	}
 
+	// setup OCPP central system
+	if err == nil {
+		err = wrapErrorWithClass(ClassOcpp, configureOcpp(&conf.Ocpp))
+	}
+
 	// setup javascript VMs
 	if err == nil {
 		err = wrapErrorWithClass(ClassJavascript, configureJavascript(conf.Javascript))
@@ -707,6 +715,39 @@ func configureEEBus(conf *eebus.Config) error {
 	return nil
 }
 
+// setup OCPP central system
+func configureOcpp(conf *ocpp.Config) error {
+	// migrate settings
+	if settings.Exists(keys.Ocpp) {
+		if err := settings.Yaml(keys.Ocpp, new(map[string]any), &conf); err != nil {
+			return err
+		}
+	}
+
+	if err := ocpp.Configure(*conf); err != nil {
+		return fmt.Errorf("failed configuring ocpp: %w", err)
+	}
+
+	return nil
+}
+
+// setup OCPP file transfers, files are stored next to the database and served at the network url
+func configureOcppTransfer(database globalconfig.DB, network globalconfig.Network) error {
+	dsn := database.Dsn
+	if dsn == "" {
+		dsn = userDB
+	}
+
+	file, err := homedir.Expand(dsn)
+	if err != nil {
+		return err
+	}
+
+	ocpp.ConfigureTransfer(filepath.Join(filepath.Dir(file), "ocpp"), network.URI()+"/api/ocpp/files")
+
+	return nil
+}
+
 // setup messaging
 func configureMessengers(conf *globalconfig.Messaging, vehicles push.Vehicles, valueChan chan<- util.Param, cache *util.ParamCache) (chan push.Event, error) {
 	// migrate settings
@@ -835,7 +876,7 @@ func configureModbusProxy(conf *[]globalconfig.ModbusProxy) error {
 			return err
 		}
 
-		if err = modbus.StartProxy(cfg.Port, cfg.Settings, mode); err != nil {
+		if err = modbus.StartProxy(cfg.Port, cfg.Settings, mode, cfg.Rules); err != nil {
 			return err
 		}
 	}
@@ -929,6 +970,9 @@ func configureSite(conf map[string]interface{}, loadpoints []*core.Loadpoint, ta
 		return nil, err
 	}
 
+	// convert upstream ocpp power limits using the site voltage
+	ocpp.Voltage = site.Voltage
+
 	if err := site.Boot(log, loadpoints, tariffs); err != nil {
 		return nil, fmt.Errorf("failed configuring site: %w", err)
 	}
@@ -0,0 +1,246 @@
+package battery
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// MaxHorizon is the maximum planning horizon
+const MaxHorizon = 48 * time.Hour
+
+// socSteps is the number of soc states used for optimization
+const socSteps = 100
+
+// Config is the battery scheduler configuration
+type Config struct {
+	ChargePower    float64 `mapstructure:"chargePower"`    // maximum grid charge power (W)
+	DischargePower float64 `mapstructure:"dischargePower"` // maximum discharge power (W), defaults to charge power
+	Efficiency     float64 `mapstructure:"efficiency"`     // round trip efficiency, defaults to 90%
+	HomePower      float64 `mapstructure:"homePower"`      // expected household consumption (W), defaults to measured home power
+	MinSoc         float64 `mapstructure:"minSoc"`         // minimum soc (%)
+	Control        bool    `mapstructure:"control"`        // apply planned battery mode
+}
+
+// Slot is a battery plan slot
+type Slot struct {
+	Start  time.Time       `json:"start"`
+	End    time.Time       `json:"end"`
+	Mode   api.BatteryMode `json:"mode"`
+	Price  float64         `json:"price"`  // grid price
+	FeedIn float64         `json:"feedin"` // feed-in price
+	Solar  float64         `json:"solar"`  // forecasted solar power (W)
+	Soc    float64         `json:"soc"`    // expected soc at end of slot
+	Cost   float64         `json:"cost"`   // expected grid cost
+}
+
+// MarshalJSON implements json.Marshaler
+func (s Slot) MarshalJSON() ([]byte, error) {
+	type slot Slot
+	return json.Marshal(struct {
+		slot
+		Mode string `json:"mode"`
+	}{
+		slot: slot(s),
+		Mode: s.Mode.String(),
+	})
+}
+
+// Plan is a series of battery modes
+type Plan []Slot
+
+// Mode returns the planned battery mode at given time
+func (p Plan) Mode(now time.Time) api.BatteryMode {
+	for _, s := range p {
+		if !s.Start.After(now) && s.End.After(now) {
+			return s.Mode
+		}
+	}
+	return api.BatteryUnknown
+}
+
+// Cost returns the plan's grid cost
+func (p Plan) Cost() float64 {
+	var res float64
+	for _, s := range p {
+		res += s.Cost
+	}
+	return res
+}
+
+// MarshalMQTT implements server.MQTTMarshaler
+func (p Plan) MarshalMQTT() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// Scheduler plans home battery operation for lowest energy cost
+type Scheduler struct {
+	log   *util.Logger
+	clock clock.Clock
+	Config
+}
+
+// New creates a battery scheduler
+func New(log *util.Logger, cc Config) (*Scheduler, error) {
+	if cc.ChargePower <= 0 {
+		return nil, errors.New("missing charge power")
+	}
+	if cc.DischargePower <= 0 {
+		cc.DischargePower = cc.ChargePower
+	}
+	if cc.Efficiency <= 0 || cc.Efficiency > 1 {
+		cc.Efficiency = 0.9
+	}
+
+	return &Scheduler{
+		log:    log,
+		clock:  clock.New(),
+		Config: cc,
+	}, nil
+}
+
+// modes are the battery modes considered for planning, in order of preference
+var modes = []api.BatteryMode{api.BatteryNormal, api.BatteryHold, api.BatteryCharge, api.BatteryDischarge}
+
+// transition returns the battery energy (Wh) after applying mode during the slot and the resulting grid cost
+func (s *Scheduler) transition(slot Slot, homePower, capacity, energy float64, mode api.BatteryMode) (float64, float64) {
+	h := slot.End.Sub(slot.Start).Hours()
+	surplus := (slot.Solar - homePower) * h
+	minEnergy := s.MinSoc / 100 * capacity
+
+	var charge, discharge float64
+
+	switch {
+	case mode == api.BatteryCharge:
+		charge = min(s.ChargePower*h, max(0, capacity-energy)/s.Efficiency)
+	case mode == api.BatteryDischarge:
+		discharge = min(s.DischargePower*h, max(0, energy-minEnergy))
+	case surplus > 0:
+		// normal and hold mode both store solar surplus
+		charge = min(surplus, s.ChargePower*h, max(0, capacity-energy)/s.Efficiency)
+	case mode == api.BatteryNormal:
+		discharge = min(-surplus, s.DischargePower*h, max(0, energy-minEnergy))
+	}
+
+	grid := charge - discharge - surplus
+	price := slot.Price
+	if grid < 0 {
+		price = slot.FeedIn
+	}
+
+	return energy + charge*s.Efficiency - discharge, grid * price / 1e3
+}
+
+// interpolate returns the value function at given battery energy
+func interpolate(values []float64, capacity, energy float64) float64 {
+	pos := max(0, min(socSteps, energy/capacity*socSteps))
+	i := int(pos)
+	if i >= socSteps {
+		return values[socSteps]
+	}
+	frac := pos - float64(i)
+	return values[i]*(1-frac) + values[i+1]*frac
+}
+
+// slots creates the planning slots from available rates, limited to the maximum horizon
+func (s *Scheduler) slots(rates, feedin, solar api.Rates) Plan {
+	now := s.clock.Now()
+	horizon := now.Add(MaxHorizon)
+
+	var res Plan
+	for _, r := range rates {
+		if !r.End.After(now) || !r.Start.Before(horizon) {
+			continue
+		}
+
+		if r.Start.Before(now) {
+			r.Start = now
+		}
+		if r.End.After(horizon) {
+			r.End = horizon
+		}
+
+		res = append(res, Slot{
+			Start:  r.Start,
+			End:    r.End,
+			Price:  r.Price,
+			FeedIn: feedin.Average(r.Start, r.End),
+			Solar:  solar.Average(r.Start, r.End),
+		})
+	}
+
+	return res
+}
+
+// Plan creates the lowest-cost battery plan for the current soc (%) and battery capacity (kWh).
+// Rates are the grid prices, feed-in denotes the feed-in compensation and solar the forecasted solar power (W).
+// Household consumption is assumed to be constant at home power (W) unless configured.
+func (s *Scheduler) Plan(soc, capacity, homePower float64, rates, feedin, solar api.Rates) Plan {
+	plan := s.slots(rates, feedin, solar)
+	if len(plan) == 0 || capacity <= 0 {
+		return nil
+	}
+
+	if s.HomePower > 0 {
+		homePower = s.HomePower
+	}
+
+	capacity *= 1e3 // Wh
+
+	// remaining energy is valued at the lowest grid price to prevent charging at end of horizon
+	minPrice := math.MaxFloat64
+	for _, slot := range plan {
+		minPrice = min(minPrice, slot.Price)
+	}
+
+	minEnergy := s.MinSoc / 100 * capacity
+
+	// values[i][k] is the lowest cost from slot i onwards at soc step k
+	values := make([][]float64, len(plan)+1)
+	for i := range values {
+		values[i] = make([]float64, socSteps+1)
+	}
+
+	for k := range socSteps + 1 {
+		energy := float64(k) / socSteps * capacity
+		values[len(plan)][k] = -max(0, energy-minEnergy) * minPrice / 1e3
+	}
+
+	best := func(i int, energy float64) (api.BatteryMode, float64, float64, float64) {
+		mode, value := api.BatteryUnknown, math.MaxFloat64
+
+		var next, cost float64
+		for _, m := range modes {
+			e, c := s.transition(plan[i], homePower, capacity, energy, m)
+			// prefer earlier modes unless significantly cheaper
+			if v := c + interpolate(values[i+1], capacity, e); v < value-1e-6 {
+				mode, value, next, cost = m, v, e, c
+			}
+		}
+
+		return mode, value, next, cost
+	}
+
+	for i := len(plan) - 1; i >= 0; i-- {
+		for k := range socSteps + 1 {
+			_, values[i][k], _, _ = best(i, float64(k)/socSteps*capacity)
+		}
+	}
+
+	energy := soc / 100 * capacity
+	for i := range plan {
+		var cost float64
+		plan[i].Mode, _, energy, cost = best(i, energy)
+		plan[i].Soc = energy / capacity * 100
+		plan[i].Cost = cost
+	}
+
+	s.log.DEBUG.Printf("battery plan: %d slots, cost %.2f", len(plan), plan.Cost())
+
+	return plan
+}
@@ -0,0 +1,77 @@
+package battery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rates(start time.Time, prices ...float64) api.Rates {
+	res := make(api.Rates, 0, len(prices))
+	for i, p := range prices {
+		res = append(res, api.Rate{
+			Start: start.Add(time.Duration(i) * time.Hour),
+			End:   start.Add(time.Duration(i+1) * time.Hour),
+			Price: p,
+		})
+	}
+	return res
+}
+
+func TestPlan(t *testing.T) {
+	clock := clock.NewMock()
+	now := clock.Now()
+
+	tc := []struct {
+		desc     string
+		soc      float64
+		capacity float64
+		prices   []float64
+		solar    []float64
+		feedin   []float64
+		modes    []api.BatteryMode
+	}{
+		{"flat price", 50, 10, []float64{0.3, 0.3, 0.3}, nil, nil, []api.BatteryMode{api.BatteryNormal, api.BatteryNormal, api.BatteryNormal}},
+		{"charge cheap", 0, 2, []float64{0.1, 0.4, 0.4}, nil, nil, []api.BatteryMode{api.BatteryCharge, api.BatteryNormal, api.BatteryNormal}},
+		{"hold for expensive", 100, 1, []float64{0.3, 0.5}, nil, nil, []api.BatteryMode{api.BatteryHold, api.BatteryNormal}},
+		{"solar instead of grid", 0, 2, []float64{0.1, 0.4, 0.4}, []float64{0, 3000, 0}, nil, []api.BatteryMode{api.BatteryNormal, api.BatteryNormal, api.BatteryNormal}},
+		{"discharge at peak feed-in", 100, 2, []float64{0.3, 0.3}, nil, []float64{0.6, 0}, []api.BatteryMode{api.BatteryDischarge, api.BatteryNormal}},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := New(util.NewLogger("foo"), Config{ChargePower: 2000, Efficiency: 1})
+			require.NoError(t, err)
+			s.clock = clock
+
+			solar := rates(now, tc.solar...)
+			feedin := rates(now, tc.feedin...)
+
+			plan := s.Plan(tc.soc, tc.capacity, 1000, rates(now, tc.prices...), feedin, solar)
+			require.Len(t, plan, len(tc.modes))
+
+			assert.Equal(t, tc.modes, lo.Map(plan, func(s Slot, _ int) api.BatteryMode { return s.Mode }))
+			assert.Equal(t, tc.modes[0], plan.Mode(now))
+		})
+	}
+}
+
+func TestPlanHorizon(t *testing.T) {
+	clock := clock.NewMock()
+
+	s, err := New(util.NewLogger("foo"), Config{ChargePower: 2000})
+	require.NoError(t, err)
+	s.clock = clock
+
+	prices := make([]float64, 72)
+	plan := s.Plan(50, 10, 500, rates(clock.Now(), prices...), nil, nil)
+
+	assert.Len(t, plan, int(MaxHorizon/time.Hour))
+	assert.Equal(t, api.BatteryUnknown, plan.Mode(clock.Now().Add(MaxHorizon)))
+}
@@ -28,11 +28,14 @@ type Circuit struct {
 
 	maxCurrent    float64                 // max allowed current
 	maxPower      float64                 // max allowed power
+	maxImbalance  float64                 // max allowed current difference between phases
 	getMaxCurrent func() (float64, error) // dynamic max allowed current
 	getMaxPower   func() (float64, error) // dynamic max allowed power
 
-	current float64
-	power   float64
+	current      float64    // max phase current
+	currents     [3]float64 // per-phase currents
+	gridCurrents []float64  // absolute grid phase currents for unbalanced load limit without meter
+	power        float64
 
 	currentUpdated time.Time
 	powerUpdated   time.Time
@@ -46,6 +49,7 @@ func NewFromConfig(log *util.Logger, other map[string]interface{}) (api.Circuit,
 		MeterRef      string         `mapstructure:"meter"`  // meter reference
 		MaxCurrent    float64        // the max allowed current
 		MaxPower      float64        // the max allowed power
+		MaxImbalance  float64        // the max allowed current difference between phases
 		GetMaxCurrent *plugin.Config // dynamic max allowed current
 		GetMaxPower   *plugin.Config // dynamic max allowed power
 		Timeout       time.Duration  // timeout between meter updates
@@ -71,6 +75,13 @@ func NewFromConfig(log *util.Logger, other map[string]interface{}) (api.Circuit,
 		return nil, err
 	}
 
+	if cc.MaxImbalance != 0 {
+		if _, ok := meter.(api.PhaseCurrents); meter != nil && !ok {
+			return nil, fmt.Errorf("meter does not support phase currents")
+		}
+		circuit.maxImbalance = cc.MaxImbalance
+	}
+
 	circuit.getMaxPower, err = cc.GetMaxPower.FloatGetter(context.TODO())
 	if err != nil {
 		return nil, err
@@ -157,6 +168,19 @@ func (c *Circuit) Wrap(parent api.Circuit) error {
 	return c.setParent(parent)
 }
 
+// SetGridCurrents sets the grid phase currents used for the unbalanced load limit if the circuit has no meter.
+// Currents are taken as absolute values since export loads the phases as well.
+func (c *Circuit) SetGridCurrents(currents []float64) {
+	var res []float64
+	if len(currents) == 3 {
+		res = []float64{math.Abs(currents[0]), math.Abs(currents[1]), math.Abs(currents[2])}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gridCurrents = res
+}
+
 // HasMeter returns the max power setting
 func (c *Circuit) HasMeter() bool {
 	c.mu.RLock()
@@ -208,58 +232,128 @@ func (c *Circuit) SetMaxCurrent(current float64) {
 	c.maxCurrent = current
 }
 
+// GetMaxImbalance returns the max allowed current difference between phases
+func (c *Circuit) GetMaxImbalance() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.maxImbalance
+}
+
+// SetMaxImbalance sets the max allowed current difference between phases
+func (c *Circuit) SetMaxImbalance(current float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxImbalance = current
+}
+
 // RegisterChild registers child circuit
 func (c *Circuit) RegisterChild(child api.Circuit) {
 	c.children = append(c.children, child)
 }
 
-func (c *Circuit) updateLoadpoints(loadpoints []api.CircuitLoad) {
-	c.power = 0
-	c.current = 0
+// addPhaseCurrents adds the load's per-phase currents. Loads without per-phase currents are assumed to load all phases.
+func addPhaseCurrents(currents *[3]float64, load api.CircuitMeasurements) {
+	if pm, ok := load.(api.CircuitPhaseMeasurements); ok {
+		l1, l2, l3 := pm.GetPhaseCurrents()
+		currents[0] += l1
+		currents[1] += l2
+		currents[2] += l3
+		return
+	}
+
+	current := load.GetMaxPhaseCurrent()
+	for i := range currents {
+		currents[i] += current
+	}
+}
+
+// updateLoads updates power and per-phase currents from the circuit's loadpoints and child circuits
+func (c *Circuit) updateLoads(loadpoints []api.CircuitLoad) {
+	var (
+		power    float64
+		currents [3]float64
+	)
 
 	for _, lp := range loadpoints {
 		if lp.GetCircuit() != c {
 			continue
 		}
 
-		c.power += lp.GetChargePower()
-		c.current += lp.GetMaxPhaseCurrent()
+		power += lp.GetChargePower()
+		addPhaseCurrents(&currents, lp)
 	}
+
+	for _, ch := range c.children {
+		power += ch.GetChargePower()
+		addPhaseCurrents(&currents, ch)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.power = power
+	c.currents = currents
+	c.current = max(currents[0], currents[1], currents[2])
 }
 
+// overloadOnError must be called with lock held
 func (c *Circuit) overloadOnError(t time.Time, val *float64) {
 	if c.timeout > 0 && time.Since(t) > c.timeout {
 		*val = math.MaxFloat64
 	}
 }
 
+// overloadCurrentsOnError must be called with lock held
+func (c *Circuit) overloadCurrentsOnError() {
+	if c.timeout > 0 && time.Since(c.currentUpdated) > c.timeout {
+		c.currents = [3]float64{math.MaxFloat64, math.MaxFloat64, math.MaxFloat64}
+		c.current = math.MaxFloat64
+	}
+}
+
 func (c *Circuit) updateMeters() error {
-	if f, err := c.meter.CurrentPower(); err == nil {
+	f, err := c.meter.CurrentPower()
+
+	c.mu.Lock()
+	if err == nil {
 		c.power = f
 		c.powerUpdated = time.Now()
 	} else {
 		c.overloadOnError(c.powerUpdated, &c.power)
+	}
+	c.mu.Unlock()
+
+	if err != nil {
 		return fmt.Errorf("circuit power: %w", err)
 	}
 
-	if phaseMeter, ok := c.meter.(api.PhaseCurrents); ok {
-		var p1, p2, p3 float64
-		if phaseMeter, ok := c.meter.(api.PhasePowers); ok {
-			var err error // phases needed for signed currents
-			if p1, p2, p3, err = phaseMeter.Powers(); err != nil {
-				return fmt.Errorf("circuit powers: %w", err)
-			}
-		}
+	phaseMeter, ok := c.meter.(api.PhaseCurrents)
+	if !ok {
+		return nil
+	}
 
-		if i1, i2, i3, err := phaseMeter.Currents(); err == nil {
-			c.current = max(util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3))
-			c.currentUpdated = time.Now()
-		} else {
-			c.overloadOnError(c.currentUpdated, &c.current)
-			return fmt.Errorf("circuit currents: %w", err)
+	var p1, p2, p3 float64
+	if phaseMeter, ok := c.meter.(api.PhasePowers); ok {
+		var err error // phases needed for signed currents
+		if p1, p2, p3, err = phaseMeter.Powers(); err != nil {
+			return fmt.Errorf("circuit powers: %w", err)
 		}
 	}
 
+	i1, i2, i3, err := phaseMeter.Currents()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		c.overloadCurrentsOnError()
+		return fmt.Errorf("circuit currents: %w", err)
+	}
+
+	c.currents = [3]float64{util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3)}
+	c.current = max(c.currents[0], c.currents[1], c.currents[2])
+	c.currentUpdated = time.Now()
+
 	return nil
 }
 
@@ -268,16 +362,20 @@ func (c *Circuit) Update(loadpoints []api.CircuitLoad) (err error) {
 	maxCurrent := c.GetMaxCurrent()
 
 	defer func() {
-		if maxPower != 0 && c.power > maxPower {
-			c.log.WARN.Printf("over power detected: %.5gW > %.5gW", c.power, maxPower)
+		if power := c.GetChargePower(); maxPower != 0 && power > maxPower {
+			c.log.WARN.Printf("over power detected: %.5gW > %.5gW", power, maxPower)
 		} else {
-			c.log.DEBUG.Printf("power: %.5gW", c.power)
+			c.log.DEBUG.Printf("power: %.5gW", power)
 		}
 
-		if maxCurrent != 0 && c.current > maxCurrent {
-			c.log.WARN.Printf("over current detected: %.3gA > %.3gA", c.current, maxCurrent)
+		if current := c.GetMaxPhaseCurrent(); maxCurrent != 0 && current > maxCurrent {
+			c.log.WARN.Printf("over current detected: %.3gA > %.3gA", current, maxCurrent)
 		} else {
-			c.log.DEBUG.Printf("current: %.3gA", c.current)
+			c.log.DEBUG.Printf("current: %.3gA", current)
+		}
+
+		if maxImbalance := c.GetMaxImbalance(); maxImbalance != 0 && c.imbalance() > maxImbalance {
+			c.log.WARN.Printf("unbalanced load detected: %.3gA > %.3gA", c.imbalance(), maxImbalance)
 		}
 	}()
 
@@ -294,37 +392,64 @@ func (c *Circuit) Update(loadpoints []api.CircuitLoad) (err error) {
 	}
 
 	// no meter available
-	c.updateLoadpoints(loadpoints)
-	for _, ch := range c.children {
-		c.power += ch.GetChargePower()
-		c.current += ch.GetMaxPhaseCurrent()
-	}
+	c.updateLoads(loadpoints)
 
 	return nil
 }
 
 // GetChargePower returns the actual power
 func (c *Circuit) GetChargePower() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.power
 }
 
 // GetMaxPhaseCurrent returns the actual current
 func (c *Circuit) GetMaxPhaseCurrent() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.current
 }
 
+// GetPhaseCurrents returns the actual per-phase currents
+func (c *Circuit) GetPhaseCurrents() (float64, float64, float64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.currents[0], c.currents[1], c.currents[2]
+}
+
+// imbalanceCurrents returns the per-phase currents determining the imbalance.
+// Grid phase currents include household loads not visible to circuits without meter.
+func (c *Circuit) imbalanceCurrents() [3]float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.meter == nil && c.gridCurrents != nil {
+		return [3]float64(c.gridCurrents)
+	}
+
+	return c.currents
+}
+
+// imbalance returns the current difference between phases
+func (c *Circuit) imbalance() float64 {
+	currents := c.imbalanceCurrents()
+	return max(currents[0], currents[1], currents[2]) - min(currents[0], currents[1], currents[2])
+}
+
 // ValidatePower validates power request
 func (c *Circuit) ValidatePower(old, new float64) float64 {
 	delta := max(0, new-old)
 
 	if maxPower := c.GetMaxPower(); maxPower != 0 {
-		potential := maxPower - c.power
+		power := c.GetChargePower()
+		potential := maxPower - power
 		if delta > potential {
 			capped := max(0, old+potential)
-			c.log.DEBUG.Printf("validate power: %.5gW + (%.5gW -> %.5gW) > %.5gW capped at %.5gW", c.power, old, new, maxPower, capped)
+			c.log.DEBUG.Printf("validate power: %.5gW + (%.5gW -> %.5gW) > %.5gW capped at %.5gW", power, old, new, maxPower, capped)
 			new = capped
 		} else {
-			c.log.TRACE.Printf("validate power: %.5gW + (%.5gW -> %.5gW) <= %.5gW ok", c.power, old, new, maxPower)
+			c.log.TRACE.Printf("validate power: %.5gW + (%.5gW -> %.5gW) <= %.5gW ok", power, old, new, maxPower)
 		}
 	}
 
@@ -335,18 +460,52 @@ func (c *Circuit) ValidatePower(old, new float64) float64 {
 	return c.parent.ValidatePower(old, new)
 }
 
-// ValidateCurrent validates current request
+// ValidateCurrent validates current request for a load on all phases
 func (c *Circuit) ValidateCurrent(old, new float64) float64 {
+	return c.ValidatePhaseCurrent(old, new, [3]bool{true, true, true})
+}
+
+// ValidatePhaseCurrent validates current request for a load on the given grid phases
+func (c *Circuit) ValidatePhaseCurrent(old, new float64, phases [3]bool) float64 {
 	delta := max(0, new-old)
 
 	if maxCurrent := c.GetMaxCurrent(); maxCurrent != 0 {
-		potential := maxCurrent - c.current
+		l1, l2, l3 := c.GetPhaseCurrents()
+		for i, current := range [3]float64{l1, l2, l3} {
+			if !phases[i] {
+				continue
+			}
+
+			potential := maxCurrent - current
+			if delta > potential {
+				capped := max(0, old+potential)
+				c.log.DEBUG.Printf("validate current: L%d %.3gA + (%.3gA -> %.3gA) > %.3gA capped at %.3gA", i+1, current, old, new, maxCurrent, capped)
+				new = capped
+				delta = max(0, new-old)
+			} else {
+				c.log.TRACE.Printf("validate current: L%d %.3gA + (%.3gA -> %.3gA) <= %.3gA ok", i+1, current, old, new, maxCurrent)
+			}
+		}
+	}
+
+	// loads on all phases don't increase the imbalance
+	if maxImbalance := c.GetMaxImbalance(); maxImbalance != 0 && phases != [3]bool{true, true, true} {
+		loaded, unloaded := -math.MaxFloat64, math.MaxFloat64
+		for i, current := range c.imbalanceCurrents() {
+			if phases[i] {
+				loaded = max(loaded, current)
+			} else {
+				unloaded = min(unloaded, current)
+			}
+		}
+
+		potential := maxImbalance - (loaded - unloaded)
 		if delta > potential {
 			capped := max(0, old+potential)
-			c.log.DEBUG.Printf("validate current: %.3gA + (%.3gA -> %.3gA) > %.3gA capped at %.3gA", c.current, old, new, maxCurrent, capped)
+			c.log.DEBUG.Printf("validate imbalance: %.3gA - %.3gA + (%.3gA -> %.3gA) > %.3gA capped at %.3gA", loaded, unloaded, old, new, maxImbalance, capped)
 			new = capped
 		} else {
-			c.log.TRACE.Printf("validate current: %.3gA + (%.3gA -> %.3gA) <= %.3gA ok", c.current, old, new, maxCurrent)
+			c.log.TRACE.Printf("validate imbalance: %.3gA - %.3gA + (%.3gA -> %.3gA) <= %.3gA ok", loaded, unloaded, old, new, maxImbalance)
 		}
 	}
 
@@ -354,5 +513,5 @@ func (c *Circuit) ValidateCurrent(old, new float64) float64 {
 		return new
 	}
 
-	return c.parent.ValidateCurrent(old, new)
+	return c.parent.ValidatePhaseCurrent(old, new, phases)
 }
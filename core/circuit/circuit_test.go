@@ -129,3 +129,87 @@ func TestCircuitCurrents(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+type phaseLoad struct {
+	circuit  api.Circuit
+	currents [3]float64
+}
+
+func (l *phaseLoad) GetChargePower() float64 {
+	return 0
+}
+
+func (l *phaseLoad) GetMaxPhaseCurrent() float64 {
+	return max(l.currents[0], l.currents[1], l.currents[2])
+}
+
+func (l *phaseLoad) GetPhaseCurrents() (float64, float64, float64) {
+	return l.currents[0], l.currents[1], l.currents[2]
+}
+
+func (l *phaseLoad) GetCircuit() api.Circuit {
+	return l.circuit
+}
+
+func TestCircuitPhaseCurrents(t *testing.T) {
+	c, err := New(util.NewLogger("foo"), "foo", 16, 0, nil, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Update([]api.CircuitLoad{
+		&phaseLoad{c, [3]float64{16, 0, 0}},
+		&phaseLoad{c, [3]float64{0, 10, 0}},
+	}))
+
+	l1, l2, l3 := c.GetPhaseCurrents()
+	assert.Equal(t, []float64{16, 10, 0}, []float64{l1, l2, l3})
+	assert.Equal(t, 16.0, c.GetMaxPhaseCurrent())
+
+	assert.Equal(t, 0.0, c.ValidatePhaseCurrent(0, 16, [3]bool{true, false, false}))
+	assert.Equal(t, 6.0, c.ValidatePhaseCurrent(0, 16, [3]bool{false, true, false}))
+	assert.Equal(t, 16.0, c.ValidatePhaseCurrent(0, 16, [3]bool{false, false, true}))
+	assert.Equal(t, 0.0, c.ValidateCurrent(0, 16))
+}
+
+func TestCircuitImbalance(t *testing.T) {
+	c, err := New(util.NewLogger("foo"), "foo", 0, 0, nil, 0)
+	require.NoError(t, err)
+	c.SetMaxImbalance(20)
+
+	require.NoError(t, c.Update([]api.CircuitLoad{
+		&phaseLoad{c, [3]float64{16, 0, 0}},
+	}))
+
+	// existing 1p load limited by imbalance
+	assert.Equal(t, 20.0, c.ValidatePhaseCurrent(16, 32, [3]bool{true, false, false}))
+	// additional 1p load on other phase ok
+	assert.Equal(t, 16.0, c.ValidatePhaseCurrent(0, 16, [3]bool{false, true, false}))
+	// 2p load limited by remaining phase
+	assert.Equal(t, 4.0, c.ValidatePhaseCurrent(0, 16, [3]bool{true, true, false}))
+	// 3p load ok
+	assert.Equal(t, 32.0, c.ValidateCurrent(0, 32))
+}
+
+func TestCircuitGridImbalance(t *testing.T) {
+	c, err := New(util.NewLogger("foo"), "foo", 20, 0, nil, 0)
+	require.NoError(t, err)
+	c.SetMaxImbalance(20)
+
+	// household load on L2 not visible to the circuit, export on L3
+	c.SetGridCurrents([]float64{16, 10, -4})
+
+	require.NoError(t, c.Update([]api.CircuitLoad{
+		&phaseLoad{c, [3]float64{16, 0, 0}},
+	}))
+
+	// max current only limits charging
+	l1, l2, l3 := c.GetPhaseCurrents()
+	assert.Equal(t, []float64{16, 0, 0}, []float64{l1, l2, l3})
+	assert.Equal(t, 4.0, c.ValidatePhaseCurrent(0, 16, [3]bool{true, false, false}))
+
+	// 1p load on L2 limited by imbalance to least loaded L3, export counts as load
+	assert.Equal(t, 14.0, c.ValidatePhaseCurrent(0, 16, [3]bool{false, true, false}))
+
+	// without grid currents
+	c.SetGridCurrents(nil)
+	assert.Equal(t, 16.0, c.ValidatePhaseCurrent(0, 16, [3]bool{false, true, false}))
+}
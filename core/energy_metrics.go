@@ -1,5 +1,46 @@
 package core
 
+import (
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/core/session"
+)
+
+// tariffSlot describes the current grid tariff slot and the split of green energy into solar and battery
+type tariffSlot struct {
+	Start, End  time.Time // zero if grid tariff is not available
+	SolarShare  float64   // share of solar energy (0-1), remaining green share is battery energy
+	GridPrice   *float64  // grid price per kWh
+	FeedInPrice *float64  // feed-in price per kWh
+	GridCo2     *float64  // grid co2 emissions per kWh
+}
+
+// effectivePrice returns the price per kWh blended by green share. Green energy is valued at the feed-in price.
+func (s tariffSlot) effectivePrice(greenShare float64) *float64 {
+	if s.GridPrice == nil {
+		return nil
+	}
+
+	var feedIn float64
+	if s.FeedInPrice != nil {
+		feedIn = *s.FeedInPrice
+	}
+
+	res := *s.GridPrice*(1-greenShare) + feedIn*greenShare
+	return &res
+}
+
+// effectiveCo2 returns the co2 emissions per kWh blended by green share. Green energy is emission free.
+func (s tariffSlot) effectiveCo2(greenShare float64) *float64 {
+	if s.GridCo2 == nil {
+		return nil
+	}
+
+	res := *s.GridCo2 * (1 - greenShare)
+	return &res
+}
+
 // EnergyMetrics calculates stats about the charged energy and gives you details about price or co2s
 type EnergyMetrics struct {
 	totalKWh          float64  // Total amount of energy used (kWh)
@@ -9,6 +50,8 @@ type EnergyMetrics struct {
 	currentGreenShare float64  // Current share of solar energy of site (0-1)
 	currentPrice      *float64 // Current price per kWh
 	currentCo2        *float64 // Current co2 emissions
+	currentSlot       tariffSlot
+	slots             []session.Slot // Energy by tariff slot and source
 }
 
 // SetEnvironment updates site information like solar share, price, co2 for use in later calculations
@@ -18,6 +61,11 @@ func (em *EnergyMetrics) SetEnvironment(greenShare float64, effPrice, effCo2 *fl
 	em.currentCo2 = effCo2
 }
 
+// SetTariffSlot updates the current tariff slot for accounting energy by tariff slot and source
+func (em *EnergyMetrics) SetTariffSlot(slot tariffSlot) {
+	em.currentSlot = slot
+}
+
 // Update sets the a new value for the total amount of charged energy and updated metrics based on environment values.
 // It returns the added total and green energy.
 func (em *EnergyMetrics) Update(chargedKWh float64) (float64, float64) {
@@ -46,15 +94,80 @@ func (em *EnergyMetrics) Update(chargedKWh float64) (float64, float64) {
 		}
 		em.co2 = &newCo2
 	}
+	em.updateSlot(added, addedGreen)
 	return added, addedGreen
 }
 
+// updateSlot adds energy to the current tariff slot. Consecutive slots with same prices are merged.
+func (em *EnergyMetrics) updateSlot(added, addedGreen float64) {
+	cur := em.currentSlot
+
+	var slot *session.Slot
+	if n := len(em.slots); n > 0 {
+		last := &em.slots[n-1]
+		if equalPtr(last.GridPrice, cur.GridPrice) && equalPtr(last.FeedInPrice, cur.FeedInPrice) &&
+			(cur.Start.IsZero() || !cur.Start.After(last.End)) {
+			slot = last
+		}
+	}
+
+	if slot == nil {
+		em.slots = append(em.slots, session.Slot{
+			Start:       cur.Start,
+			GridPrice:   cur.GridPrice,
+			FeedInPrice: cur.FeedInPrice,
+		})
+		slot = &em.slots[len(em.slots)-1]
+	}
+
+	if cur.End.After(slot.End) {
+		slot.End = cur.End
+	}
+
+	solar := added * min(cur.SolarShare, em.currentGreenShare)
+	grid := added - addedGreen
+	battery := addedGreen - solar
+
+	slot.GridEnergy += grid
+	slot.SolarEnergy += solar
+	slot.BatteryEnergy += battery
+
+	// green energy is valued at the feed-in price if a grid price is available, matching the session price
+	if cur.GridPrice != nil {
+		var feedIn float64
+		if cur.FeedInPrice != nil {
+			feedIn = *cur.FeedInPrice
+		}
+
+		slot.GridCost = addPtr(slot.GridCost, grid**cur.GridPrice)
+		slot.SolarCost = addPtr(slot.SolarCost, solar*feedIn)
+		slot.BatteryCost = addPtr(slot.BatteryCost, battery*feedIn)
+	}
+
+	if cur.GridCo2 != nil {
+		slot.GridCo2 = addPtr(slot.GridCo2, grid**cur.GridCo2)
+	}
+}
+
+// addPtr adds the value to the optional sum
+func addPtr(sum *float64, v float64) *float64 {
+	if sum != nil {
+		v += *sum
+	}
+	return &v
+}
+
+func equalPtr(a, b *float64) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
 // Reset sets all calculations to initial values
 func (em *EnergyMetrics) Reset() {
 	em.totalKWh = 0
 	em.solarKWh = 0
 	em.price = nil
 	em.co2 = nil
+	em.slots = nil
 }
 
 // TotalWh returns the total energy in Wh
@@ -96,6 +209,11 @@ func (em *EnergyMetrics) Co2PerKWh() *float64 {
 	return &co2
 }
 
+// Slots returns the charged energy by tariff slot and source
+func (em *EnergyMetrics) Slots() []session.Slot {
+	return slices.Clone(em.slots)
+}
+
 // Publish publishes metrics with a given prefix
 func (em *EnergyMetrics) Publish(prefix string, p publisher) {
 	p.publish(prefix+"Energy", em.TotalWh())
@@ -2,6 +2,10 @@ package core
 
 import (
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func isEqualFloat64(a, b *float64) bool {
@@ -153,3 +157,57 @@ func TestEnergyMetrics(t *testing.T) {
 		t.Errorf("Metrics not properly reset %+v", s)
 	}
 }
+
+func TestEnergyMetricsSlots(t *testing.T) {
+	f := func(f float64) *float64 { return &f }
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var s EnergyMetrics
+
+	// grid only
+	s.SetEnvironment(0, f(0.3), f(400))
+	s.SetTariffSlot(tariffSlot{Start: start, End: start.Add(time.Hour), GridPrice: f(0.3), FeedInPrice: f(0.1), GridCo2: f(400)})
+	s.Update(1)
+
+	// same price in next slot is merged, half solar and half battery
+	s.SetEnvironment(1, f(0.1), f(0))
+	s.SetTariffSlot(tariffSlot{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour), SolarShare: 0.5, GridPrice: f(0.3), FeedInPrice: f(0.1), GridCo2: f(400)})
+	s.Update(3)
+
+	// price change starts new slot
+	s.SetEnvironment(0.5, f(0.25), f(150))
+	s.SetTariffSlot(tariffSlot{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour), SolarShare: 0.5, GridPrice: f(0.3), FeedInPrice: f(0.2), GridCo2: f(300)})
+	s.Update(5)
+
+	slots := s.Slots()
+	require.Len(t, slots, 2)
+
+	assert.Equal(t, start, slots[0].Start)
+	assert.Equal(t, start.Add(2*time.Hour), slots[0].End)
+	assert.Equal(t, 1.0, slots[0].GridEnergy)
+	assert.Equal(t, 1.0, slots[0].SolarEnergy)
+	assert.Equal(t, 1.0, slots[0].BatteryEnergy)
+	assert.InDelta(t, 0.3, *slots[0].GridCost, 1e-6)
+	assert.InDelta(t, 0.1, *slots[0].SolarCost, 1e-6)
+	assert.InDelta(t, 0.1, *slots[0].BatteryCost, 1e-6)
+	assert.InDelta(t, 400, *slots[0].GridCo2, 1e-6)
+
+	assert.Equal(t, start.Add(2*time.Hour), slots[1].Start)
+	assert.Equal(t, 1.0, slots[1].GridEnergy)
+	assert.Equal(t, 1.0, slots[1].SolarEnergy)
+	assert.Equal(t, 0.0, slots[1].BatteryEnergy)
+	assert.InDelta(t, 0.3, *slots[1].GridCost, 1e-6)
+	assert.InDelta(t, 0.2, *slots[1].SolarCost, 1e-6)
+	assert.InDelta(t, 0.0, *slots[1].BatteryCost, 1e-6)
+	assert.InDelta(t, 300, *slots[1].GridCo2, 1e-6)
+
+	// slot totals match session totals
+	var price float64
+	for _, slot := range slots {
+		price += *slot.GridCost + *slot.SolarCost + *slot.BatteryCost
+	}
+	assert.InDelta(t, *s.Price(), price, 1e-6)
+
+	s.Reset()
+	assert.Empty(t, s.Slots())
+}
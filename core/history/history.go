@@ -0,0 +1,153 @@
+package history
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Slot is the aggregation interval of the energy history
+const Slot = 15 * time.Minute
+
+// meter groups
+const (
+	Grid      = "grid"
+	PV        = "pv"
+	Battery   = "battery"
+	Aux       = "aux"
+	Loadpoint = "loadpoint"
+)
+
+// Config is the energy history configuration
+type Config struct {
+	Retention time.Duration `mapstructure:"retention"` // keep entries for this duration, zero keeps all
+}
+
+// Entry is the energy of a meter group during a single slot.
+// Import is energy in direction of positive power, export in direction of negative power.
+type Entry struct {
+	ID     uint      `json:"-" gorm:"primarykey"`
+	Meter  string    `json:"meter" gorm:"uniqueIndex:idx_history_slot"`
+	Name   string    `json:"name,omitempty" gorm:"uniqueIndex:idx_history_slot"`
+	Start  time.Time `json:"start" gorm:"uniqueIndex:idx_history_slot;index"`
+	Import float64   `json:"import" gorm:"column:import_kwh"`
+	Export float64   `json:"export" gorm:"column:export_kwh"`
+	Tariff `gorm:"embedded"`
+}
+
+// Tariff are the grid tariffs during a slot. Aggregated entries don't carry tariffs.
+type Tariff struct {
+	GridPrice   *float64 `json:"gridPrice,omitempty" gorm:"column:grid_price_per_kwh"`
+	FeedInPrice *float64 `json:"feedInPrice,omitempty" gorm:"column:feedin_price_per_kwh"`
+	Co2         *float64 `json:"co2PerKWh,omitempty" gorm:"column:co2_per_kwh"`
+}
+
+// TableName implements the gorm tabler interface
+func (Entry) TableName() string {
+	return "history"
+}
+
+// Aggregate is the query aggregation
+type Aggregate string
+
+const (
+	AggregateSlot  Aggregate = "15m"
+	AggregateHour  Aggregate = "hour"
+	AggregateDay   Aggregate = "day"
+	AggregateMonth Aggregate = "month"
+)
+
+// ParseAggregate returns the aggregation for given string, defaulting to slot
+func ParseAggregate(s string) (Aggregate, error) {
+	switch a := Aggregate(s); a {
+	case "":
+		return AggregateSlot, nil
+	case AggregateSlot, AggregateHour, AggregateDay, AggregateMonth:
+		return a, nil
+	default:
+		return "", fmt.Errorf("invalid aggregate: %s", s)
+	}
+}
+
+// truncate returns the start of the aggregation interval in local time
+func (a Aggregate) truncate(ts time.Time) time.Time {
+	ts = ts.Local()
+
+	switch a {
+	case AggregateHour:
+		return time.Date(ts.Year(), ts.Month(), ts.Day(), ts.Hour(), 0, 0, 0, time.Local)
+	case AggregateDay:
+		return time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.Local)
+	case AggregateMonth:
+		return time.Date(ts.Year(), ts.Month(), 1, 0, 0, 0, 0, time.Local)
+	default:
+		return ts
+	}
+}
+
+// Query selects history entries. Empty fields match all entries.
+type Query struct {
+	Meter     string
+	Name      string
+	From, To  time.Time // to is exclusive
+	Aggregate Aggregate
+}
+
+// Entries returns the aggregated history entries ordered by meter, name and start
+func Entries(db *gorm.DB, q Query) ([]Entry, error) {
+	tx := db.Model(new(Entry))
+
+	if q.Meter != "" {
+		tx = tx.Where("meter = ?", q.Meter)
+	}
+	if q.Name != "" {
+		tx = tx.Where("name = ?", q.Name)
+	}
+	if !q.From.IsZero() {
+		tx = tx.Where("start >= ?", q.From)
+	}
+	if !q.To.IsZero() {
+		tx = tx.Where("start < ?", q.To)
+	}
+
+	var entries []Entry
+	if err := tx.Order("start").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		meter, name string
+		start       int64
+	}
+
+	res := make([]Entry, 0)
+	idx := make(map[key]int)
+
+	for _, e := range entries {
+		start := q.Aggregate.truncate(e.Start)
+		k := key{e.Meter, e.Name, start.Unix()}
+
+		i, ok := idx[k]
+		if !ok {
+			i = len(res)
+			idx[k] = i
+			res = append(res, Entry{Meter: e.Meter, Name: e.Name, Start: start})
+
+			if q.Aggregate == AggregateSlot || q.Aggregate == "" {
+				res[i].Tariff = e.Tariff
+			}
+		}
+
+		res[i].Import += e.Import
+		res[i].Export += e.Export
+	}
+
+	slices.SortStableFunc(res, func(a, b Entry) int {
+		return cmp.Or(cmp.Compare(a.Meter, b.Meter), cmp.Compare(a.Name, b.Name), a.Start.Compare(b.Start))
+	})
+
+	return res, nil
+}
@@ -0,0 +1,100 @@
+package history
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kpi are the site's key performance indicators for a period
+type Kpi struct {
+	GridImport      float64 // grid import (kWh)
+	GridExport      float64 // grid export (kWh)
+	SelfSufficiency float64 // share of consumption not imported from grid (%)
+	SelfConsumption float64 // share of pv production consumed on site (%)
+	FeedInRevenue   float64 // grid export valued at feed-in price
+	NetCost         float64 // grid import valued at grid price minus feed-in revenue
+	Co2Avoided      float64 // self-consumed pv energy valued at grid co2 emissions (kg)
+}
+
+// slotEnergy is the site's energy balance during a slot
+type slotEnergy struct {
+	GridImport       float64 `gorm:"column:grid_import"`
+	GridExport       float64 `gorm:"column:grid_export"`
+	PV               float64 `gorm:"column:pv"`
+	BatteryCharge    float64 `gorm:"column:battery_charge"`
+	BatteryDischarge float64 `gorm:"column:battery_discharge"`
+	Tariff           `gorm:"embedded"`
+}
+
+// SiteKpi calculates the site's key performance indicators from given time.
+// Grid, pv and battery energies are summed per slot by the database.
+func SiteKpi(db *gorm.DB, from time.Time) (Kpi, error) {
+	tx := db.Model(new(Entry)).
+		Select(`SUM(CASE WHEN meter = ? THEN import_kwh ELSE 0 END) AS grid_import,
+			SUM(CASE WHEN meter = ? THEN export_kwh ELSE 0 END) AS grid_export,
+			SUM(CASE WHEN meter = ? THEN import_kwh ELSE 0 END) AS pv,
+			SUM(CASE WHEN meter = ? THEN export_kwh ELSE 0 END) AS battery_charge,
+			SUM(CASE WHEN meter = ? THEN import_kwh ELSE 0 END) AS battery_discharge,
+			MAX(CASE WHEN meter = ? THEN grid_price_per_kwh END) AS grid_price_per_kwh,
+			MAX(CASE WHEN meter = ? THEN feedin_price_per_kwh END) AS feedin_price_per_kwh,
+			MAX(CASE WHEN meter = ? THEN co2_per_kwh END) AS co2_per_kwh`,
+			Grid, Grid, PV, Battery, Battery, Grid, Grid, Grid).
+		Where("meter IN ?", []string{Grid, PV, Battery})
+
+	if !from.IsZero() {
+		tx = tx.Where("start >= ?", from)
+	}
+
+	var slots []slotEnergy
+	if err := tx.Group("start").Scan(&slots).Error; err != nil {
+		return Kpi{}, err
+	}
+
+	return newKpi(slots), nil
+}
+
+// newKpi calculates the site's key performance indicators from slot energies
+func newKpi(slots []slotEnergy) Kpi {
+	var (
+		res                              Kpi
+		pv, selfConsumption, consumption float64
+		cost                             float64
+	)
+
+	for _, s := range slots {
+		selfConsumed := max(0, s.PV-s.GridExport)
+
+		res.GridImport += s.GridImport
+		res.GridExport += s.GridExport
+
+		pv += s.PV
+		consumption += max(0, s.GridImport+s.PV+s.BatteryDischarge-s.GridExport-s.BatteryCharge)
+
+		if s.Tariff.GridPrice != nil {
+			cost += s.GridImport * *s.Tariff.GridPrice
+		}
+
+		if s.Tariff.FeedInPrice != nil {
+			res.FeedInRevenue += s.GridExport * *s.Tariff.FeedInPrice
+		}
+
+		if s.Tariff.Co2 != nil {
+			res.Co2Avoided += selfConsumed * *s.Tariff.Co2 / 1e3
+		}
+
+		selfConsumption += selfConsumed
+	}
+
+	if consumption > 0 {
+		res.SelfSufficiency = 100 * max(0, 1-res.GridImport/consumption)
+	}
+
+	if pv > 0 {
+		res.SelfConsumption = 100 * selfConsumption / pv
+	}
+
+	res.NetCost = cost - res.FeedInRevenue
+
+	return res
+}
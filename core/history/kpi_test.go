@@ -0,0 +1,52 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKpi(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(Slot)
+
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(new(Entry)))
+
+	require.NoError(t, db.Create([]Entry{
+		// grid import only
+		{Meter: Grid, Start: t1, Import: 1, Tariff: Tariff{GridPrice: lo.ToPtr(0.3)}},
+		// pv charges battery and feeds in
+		{Meter: Grid, Start: t2, Export: 1, Tariff: Tariff{GridPrice: lo.ToPtr(0.3), FeedInPrice: lo.ToPtr(0.1), Co2: lo.ToPtr(400.0)}},
+		{Meter: PV, Start: t2, Import: 3},
+		{Meter: Battery, Start: t2, Export: 1},
+		{Meter: Loadpoint, Name: "garage", Start: t2, Import: 1},
+	}).Error)
+
+	res, err := SiteKpi(db, time.Time{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1.0, res.GridImport)
+	assert.Equal(t, 1.0, res.GridExport)
+	assert.InDelta(t, 50, res.SelfSufficiency, 1e-6)
+	assert.InDelta(t, 200.0/3, res.SelfConsumption, 1e-6)
+	assert.InDelta(t, 0.1, res.FeedInRevenue, 1e-6)
+	assert.InDelta(t, 0.2, res.NetCost, 1e-6)
+	assert.InDelta(t, 0.8, res.Co2Avoided, 1e-6)
+
+	// period without entries
+	res, err = SiteKpi(db, t2.Add(Slot))
+	require.NoError(t, err)
+	assert.Equal(t, Kpi{}, res)
+
+	// period from second slot
+	res, err = SiteKpi(db, t2)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, res.GridImport)
+	assert.InDelta(t, 0.1, res.FeedInRevenue, 1e-6)
+}
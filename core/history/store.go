@@ -0,0 +1,232 @@
+package history
+
+import (
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// accumulator integrates a meter group's energy during the current slot
+type accumulator struct {
+	start   time.Time // slot start
+	updated time.Time // last sample
+	power   float64   // last power (W)
+	total   float64   // last meter total (kWh), zero if not available
+	metered bool      // import taken from meter total
+
+	imp, exp float64 // integrated energy (kWh)
+	meter    float64 // metered import (kWh)
+	tariff   Tariff  // tariff during the slot
+}
+
+// add integrates energy between last sample and ts. Meter deltas take precedence
+// over integrated import and are distributed proportionally to elapsed time.
+func (a *accumulator) add(ts time.Time, power, total float64, flush func(Entry)) {
+	metered := a.total > 0 && total >= a.total
+
+	var delta float64
+	if metered {
+		delta = total - a.total
+	}
+
+	elapsed := ts.Sub(a.updated)
+
+	for from := a.updated; from.Before(ts); {
+		to := a.start.Add(Slot)
+		if ts.Before(to) {
+			to = ts
+		}
+
+		d := to.Sub(from)
+		if energy := a.power / 1e3 * d.Hours(); energy > 0 {
+			a.imp += energy
+		} else {
+			a.exp -= energy
+		}
+
+		if metered {
+			a.metered = true
+			if delta > 0 {
+				a.meter += delta * float64(d) / float64(elapsed)
+			}
+		}
+
+		// slot completed
+		if to.Equal(a.start.Add(Slot)) {
+			flush(a.entry())
+			a.reset(to)
+		}
+
+		from = to
+	}
+
+	a.updated = ts
+	a.power = power
+	a.total = total
+}
+
+// entry returns the accumulated slot entry
+func (a *accumulator) entry() Entry {
+	imp := a.imp
+	if a.metered {
+		imp = a.meter
+	}
+
+	return Entry{
+		Start:  a.start,
+		Import: imp,
+		Export: a.exp,
+		Tariff: a.tariff,
+	}
+}
+
+func (a *accumulator) reset(start time.Time) {
+	a.start = start
+	a.imp, a.exp, a.meter = 0, 0, 0
+	a.metered = false
+}
+
+type meterKey struct {
+	meter, name string
+}
+
+// Store accumulates meter samples into slots and persists them to the database
+type Store struct {
+	mu        sync.Mutex
+	log       *util.Logger
+	db        *gorm.DB
+	clock     clock.Clock
+	retention time.Duration
+	tariff    Tariff
+	meters    map[meterKey]*accumulator
+}
+
+// NewStore creates an energy history store
+func NewStore(db *gorm.DB, conf Config) (*Store, error) {
+	err := db.AutoMigrate(new(Entry))
+
+	s := &Store{
+		log:       util.NewLogger("history"),
+		db:        db,
+		clock:     clock.New(),
+		retention: conf.Retention,
+		meters:    make(map[meterKey]*accumulator),
+	}
+
+	return s, err
+}
+
+// Add adds a meter group sample. Power is in W, total is the meter's import total in kWh or zero if not available.
+func (s *Store) Add(meter, name string, power, total float64) {
+	s.mu.Lock()
+
+	now := s.clock.Now()
+
+	var entries []Entry
+
+	if a, ok := s.meters[meterKey{meter, name}]; ok {
+		a.add(now, power, total, func(e Entry) {
+			e.Meter, e.Name = meter, name
+			entries = append(entries, e)
+		})
+
+		a.tariff = s.tariff
+	} else {
+		s.meters[meterKey{meter, name}] = &accumulator{
+			start:   now.Truncate(Slot),
+			updated: now,
+			power:   power,
+			total:   total,
+			tariff:  s.tariff,
+		}
+	}
+
+	s.mu.Unlock()
+
+	// database writes don't block other meters
+	for _, e := range entries {
+		s.persist(e)
+	}
+
+	if len(entries) > 0 {
+		s.purge(now)
+	}
+}
+
+// SetTariff sets the current grid tariffs applied to subsequent samples
+func (s *Store) SetTariff(gridPrice, feedInPrice, co2 *float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tariff = Tariff{
+		GridPrice:   gridPrice,
+		FeedInPrice: feedInPrice,
+		Co2:         co2,
+	}
+}
+
+// Persist persists the partial slots, e.g. on shutdown
+func (s *Store) Persist() {
+	s.mu.Lock()
+
+	now := s.clock.Now()
+
+	var entries []Entry
+
+	for k, a := range s.meters {
+		a.add(now, a.power, a.total, func(e Entry) {
+			e.Meter, e.Name = k.meter, k.name
+			entries = append(entries, e)
+		})
+
+		e := a.entry()
+		e.Meter, e.Name = k.meter, k.name
+		entries = append(entries, e)
+
+		a.reset(a.start)
+	}
+
+	s.mu.Unlock()
+
+	for _, e := range entries {
+		s.persist(e)
+	}
+}
+
+// persist adds the entry's energy to the database. Slots may be persisted
+// multiple times if interrupted by restarts.
+func (s *Store) persist(e Entry) {
+	if e.Import == 0 && e.Export == 0 {
+		return
+	}
+
+	err := s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "meter"}, {Name: "name"}, {Name: "start"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"import_kwh": gorm.Expr("import_kwh + ?", e.Import),
+			"export_kwh": gorm.Expr("export_kwh + ?", e.Export),
+			// tariffs are identical for all parts of the slot
+			"grid_price_per_kwh":   gorm.Expr("excluded.grid_price_per_kwh"),
+			"feedin_price_per_kwh": gorm.Expr("excluded.feedin_price_per_kwh"),
+			"co2_per_kwh":          gorm.Expr("excluded.co2_per_kwh"),
+		}),
+	}).Create(&e).Error
+	if err != nil {
+		s.log.ERROR.Printf("persist: %v", err)
+	}
+}
+
+// purge removes entries exceeding retention
+func (s *Store) purge(now time.Time) {
+	if s.retention <= 0 {
+		return
+	}
+
+	if err := s.db.Where("start < ?", now.Add(-s.retention)).Delete(new(Entry)).Error; err != nil {
+		s.log.ERROR.Printf("purge: %v", err)
+	}
+}
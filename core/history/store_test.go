@@ -0,0 +1,124 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T, conf Config) (*Store, *clock.Mock) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	s, err := NewStore(db, conf)
+	require.NoError(t, err)
+
+	clock := clock.NewMock()
+	clock.Set(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	s.clock = clock
+
+	return s, clock
+}
+
+func TestStoreIntegratePower(t *testing.T) {
+	s, clock := newTestStore(t, Config{})
+	start := clock.Now()
+
+	s.Add(Grid, "", 4000, 0)
+
+	// 4kW for 10min, -2kW for 10min across slot boundary
+	clock.Add(10 * time.Minute)
+	s.Add(Grid, "", -2000, 0)
+	clock.Add(10 * time.Minute)
+	s.Add(Grid, "", 0, 0)
+
+	res, err := Entries(s.db, Query{})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	assert.Equal(t, start, res[0].Start.UTC())
+	assert.InDelta(t, 4.0/6, res[0].Import, 1e-6)
+	assert.InDelta(t, 2.0/12, res[0].Export, 1e-6)
+
+	// partial slot on shutdown
+	s.Persist()
+
+	res, err = Entries(s.db, Query{Meter: Grid})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.InDelta(t, 2.0/12, res[1].Export, 1e-6)
+
+	// aggregated
+	res, err = Entries(s.db, Query{Aggregate: AggregateDay})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.InDelta(t, 4.0/12, res[0].Export, 1e-6)
+}
+
+func TestStoreMeterTotal(t *testing.T) {
+	s, clock := newTestStore(t, Config{})
+
+	s.Add(Loadpoint, "garage", 11000, 100)
+
+	// meter total takes precedence over integrated power
+	clock.Add(20 * time.Minute)
+	s.Add(Loadpoint, "garage", 11000, 102)
+
+	res, err := Entries(s.db, Query{Name: "garage"})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.InDelta(t, 1.5, res[0].Import, 1e-6)
+	assert.Equal(t, Loadpoint, res[0].Meter)
+}
+
+func TestStoreRetention(t *testing.T) {
+	s, clock := newTestStore(t, Config{Retention: time.Hour})
+
+	s.Add(PV, "", 1000, 0)
+	clock.Add(Slot)
+	s.Add(PV, "", 1000, 0)
+
+	res, err := Entries(s.db, Query{})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	clock.Add(2 * time.Hour)
+	s.Add(PV, "", 1000, 0)
+
+	res, err = Entries(s.db, Query{From: clock.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.NotEmpty(t, res)
+
+	res, err = Entries(s.db, Query{To: clock.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestStoreTariff(t *testing.T) {
+	s, clock := newTestStore(t, Config{})
+
+	price := 0.3
+	s.SetTariff(&price, nil, nil)
+	s.Add(Grid, "", 1000, 0)
+
+	// tariff change applies to next slot
+	clock.Add(Slot)
+	s.SetTariff(nil, nil, nil)
+	s.Add(Grid, "", 1000, 0)
+
+	res, err := Entries(s.db, Query{})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.NotNil(t, res[0].GridPrice)
+	assert.Equal(t, price, *res[0].GridPrice)
+
+	// aggregates don't carry tariffs
+	res, err = Entries(s.db, Query{Aggregate: AggregateHour})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Nil(t, res[0].GridPrice)
+}
@@ -9,6 +9,7 @@ const (
 	Mqtt               = "mqtt"
 	Influx             = "influx"
 	EEBus              = "eebus"
+	Ocpp               = "ocpp"
 	Hems               = "hems"
 	Messaging          = "messaging"
 	ModbusProxy        = "modbusproxy"
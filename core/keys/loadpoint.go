@@ -20,6 +20,7 @@ const (
 	BatteryBoost     = "batteryBoost"
 
 	PhasesConfigured = "phasesConfigured" // desired phase mode (0/1/3, 0 = automatic), user selection
+	PhaseRotation    = "phaseRotation"    // grid phase connected to the charger's L1
 	PhasesActive     = "phasesActive"     // active phases as used by vehicle (1/2/3)
 
 	ChargerIcon         = "chargerIcon"         // charger icon for ui
@@ -69,6 +70,10 @@ const (
 	PlanProjectedStart = "planProjectedStart" // charge plan start time (earliest slot)
 	PlanProjectedEnd   = "planProjectedEnd"   // charge plan ends (end of last slot)
 	PlanOverrun        = "planOverrun"        // charge plan goal not reachable in time
+	PlanGridShare      = "planGridShare"      // charge plan expected share of grid energy
+	PlanCost           = "planCost"           // charge plan expected cost of grid energy
+	PlanConflict       = "planConflict"       // charge plan goal not reachable due to shared circuit
+	PlanRisk           = "planRisk"           // charge plan goal at risk due to measured charging progress
 
 	// repeating plans
 	RepeatingPlans = "repeatingPlans" // key to access all repeating plans in db
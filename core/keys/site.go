@@ -9,6 +9,8 @@ const (
 	GreenShareHome        = "greenShareHome"
 	GreenShareLoadpoints  = "greenShareLoadpoints"
 	GridConfigured        = "gridConfigured"
+	MainFuseActive        = "mainFuseActive"
+	MainFuseEvent         = "mainFuseEvent"
 	Grid                  = "grid"
 	HomePower             = "homePower"
 	PrioritySoc           = "prioritySoc"
@@ -38,17 +40,22 @@ const (
 	AuxMeters     = "auxMeters"
 
 	// battery settings
-	BatteryCapacity         = "batteryCapacity"
-	BatteryDischargeControl = "batteryDischargeControl"
-	BatteryGridChargeLimit  = "batteryGridChargeLimit"
-	BatteryGridChargeActive = "batteryGridChargeActive"
-	BufferSoc               = "bufferSoc"
-	BufferStartSoc          = "bufferStartSoc"
+	BatteryCapacity            = "batteryCapacity"
+	BatteryDischargeControl    = "batteryDischargeControl"
+	BatteryGridChargeLimit     = "batteryGridChargeLimit"
+	BatteryGridChargeActive    = "batteryGridChargeActive"
+	BatteryGridDischargeLimit  = "batteryGridDischargeLimit"
+	BatteryGridDischargeActive = "batteryGridDischargeActive"
+	BatteryDischargePower      = "batteryDischargePower"
+	BatteryMaxChargePower      = "batteryMaxChargePower"
+	BufferSoc                  = "bufferSoc"
+	BufferStartSoc             = "bufferStartSoc"
 
 	// battery status
 	Battery       = "battery"
 	BatteryEnergy = "batteryEnergy"
 	BatteryMode   = "batteryMode"
+	BatteryPlan   = "batteryPlan"
 	BatteryPower  = "batteryPower"
 	BatterySoc    = "batterySoc"
 )
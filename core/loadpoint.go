@@ -94,9 +94,11 @@ type Loadpoint struct {
 	Enable, Disable loadpoint.ThresholdConfig
 
 	// from yaml
-	DefaultMode api.ChargeMode `mapstructure:"mode"`     // Default charge mode, used for disconnect
-	Title       string         `mapstructure:"title"`    // UI title
-	Priority    int            `mapstructure:"priority"` // Priority
+	DefaultMode   api.ChargeMode         `mapstructure:"mode"`          // Default charge mode, used for disconnect
+	Title         string                 `mapstructure:"title"`         // UI title
+	Priority      int                    `mapstructure:"priority"`      // Priority
+	PlanStrategy  loadpoint.PlanStrategy `mapstructure:"planStrategy"`  // Charge planning strategy
+	PhaseRotation int                    `mapstructure:"phaseRotation"` // Grid phase connected to the charger's L1, 0 if unknown
 
 	// from yaml, deprecated
 	GuardDuration_ time.Duration `mapstructure:"guardduration"` // ignored, present for compatibility
@@ -114,6 +116,13 @@ type Loadpoint struct {
 	smartCostLimit   *float64 // always charge if cost is below this value
 	batteryBoost     int      // battery boost state
 
+	fuseLimit     *float64  // Main fuse current limit (runtime only)
+	fuseReduction float64   // Main fuse current reduction of last limit change
+	fuseUpdated   time.Time // Main fuse limit change timestamp
+
+	phaseRotation      int     // Grid phase connected to the charger's L1, 0 if unknown
+	measuredGridPhases [3]bool // Grid phases physically measured
+
 	mode                api.ChargeMode
 	enabled             bool      // Charger enabled state
 	phases              int       // Charger enabled phases, guarded by mutex
@@ -139,11 +148,16 @@ type Loadpoint struct {
 	socEstimator   *soc.Estimator
 
 	// charge planning
-	planner     *planner.Planner
-	planTime    time.Time // time goal
-	planEnergy  float64   // Plan charge energy in kWh (dumb vehicles)
-	planSlotEnd time.Time // current plan slot end time
-	planActive  bool      // charge plan exists and has a currently active slot
+	planner       *planner.Planner
+	planTime      time.Time        // time goal
+	planEnergy    float64          // Plan charge energy in kWh (dumb vehicles)
+	planSlotEnd   time.Time        // current plan slot end time
+	planSlotPower float64          // current plan slot power, zero for maximum power
+	planCapacity  planner.Capacity // available plan power as determined by site, nil for unlimited
+	capacityPlan  capacityPlan     // plan computed by site for the available plan power
+	planConflict  bool             // plan goal can't be met due to other loadpoints' plans
+	planGuard     planGuard        // plan progress measurement
+	planActive    bool             // charge plan exists and has a currently active slot
 
 	// cached state
 	status         api.ChargeStatus       // Charger status
@@ -188,6 +202,11 @@ func NewLoadpointFromConfig(log *util.Logger, settings settings.Settings, other
 		lp.Soc.Poll.Mode = loadpoint.PollCharging
 	}
 
+	if lp.PhaseRotation < 0 || lp.PhaseRotation > 3 {
+		return nil, fmt.Errorf("invalid phase rotation: %d", lp.PhaseRotation)
+	}
+	lp.phaseRotation = lp.PhaseRotation
+
 	if lp.CircuitRef != "" {
 		dev, err := config.Circuits().ByName(lp.CircuitRef)
 		if err != nil {
@@ -327,6 +346,9 @@ func (lp *Loadpoint) restoreSettings() {
 	if v, err := lp.settings.Float(keys.SmartCostLimit); err == nil {
 		lp.SetSmartCostLimit(&v)
 	}
+	if v, err := lp.settings.Int(keys.PhaseRotation); err == nil && v >= 0 && v <= 3 {
+		lp.setPhaseRotation(int(v))
+	}
 
 	var thresholds loadpoint.ThresholdsConfig
 	if err := lp.settings.Json(keys.Thresholds, &thresholds); err == nil {
@@ -496,7 +518,9 @@ func (lp *Loadpoint) evVehicleConnectHandler() {
 func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	lp.log.INFO.Println("car disconnected")
 
-	// session is persisted during evChargeStopHandler which runs before
+	// session is persisted during evChargeStopHandler which runs before,
+	// the signed transaction end is only available once the transaction has been stopped
+	lp.updateSession(lp.updateSignedMeterValues)
 	lp.clearSession()
 
 	// phases are unknown when vehicle disconnects
@@ -538,6 +562,7 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	// mark plan slot as inactive
 	// this will force a deletion of an outdated plan once plan time is expired in GetPlan()
 	lp.setPlanActive(false)
+	lp.resetPlanGuard()
 }
 
 // evVehicleSocProgressHandler sends external start event
@@ -831,13 +856,18 @@ func (lp *Loadpoint) roundedCurrent(chargeCurrent float64) float64 {
 
 // setLimit applies charger current limits and enables/disables accordingly
 func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
-	chargeCurrent = lp.roundedCurrent(chargeCurrent)
+	chargeCurrent = lp.roundedCurrent(lp.applyFuseLimit(chargeCurrent))
 
 	// apply circuit limits
 	if lp.circuit != nil {
-		currentLimit := lp.circuit.ValidateCurrent(lp.chargeCurrent, chargeCurrent)
-
 		activePhases := lp.ActivePhases()
+
+		// unknown grid phases are validated worst case
+		currentLimit := chargeCurrent
+		for _, phases := range lp.gridPhaseCandidates(activePhases) {
+			currentLimit = min(currentLimit, lp.circuit.ValidatePhaseCurrent(lp.chargeCurrent, chargeCurrent, phases))
+		}
+
 		powerLimit := lp.circuit.ValidatePower(lp.chargePower, currentToPower(chargeCurrent, activePhases))
 		currentLimitViaPower := powerToCurrent(powerLimit, activePhases)
 
@@ -1201,6 +1231,17 @@ func (lp *Loadpoint) fastCharging() error {
 	return err
 }
 
+// planCharging charges at the active plan slot's power or falls back to fast charging
+func (lp *Loadpoint) planCharging() error {
+	power := lp.planSlotPower
+	if power <= 0 || lp.minSocNotReached() {
+		return lp.fastCharging()
+	}
+
+	current := powerToCurrent(power, lp.ActivePhases())
+	return lp.setLimit(min(max(current, lp.effectiveMinCurrent()), lp.effectiveMaxCurrent()))
+}
+
 // pvScalePhases switches phases if necessary and returns number of phases switched to
 func (lp *Loadpoint) pvScalePhases(sitePower, minCurrent, maxCurrent float64) int {
 	phases := lp.GetPhases()
@@ -1222,6 +1263,11 @@ func (lp *Loadpoint) pvScalePhases(sitePower, minCurrent, maxCurrent float64) in
 	availablePower := lp.chargePower - sitePower
 	scalable := (sitePower > 0 || !lp.enabled) && activePhases > 1 && lp.phasesConfigured < 3
 
+	// 1p charging must not exceed the circuits' unbalanced load limit
+	if scalable && lp.phaseImbalanceExceeded(minCurrent) {
+		scalable = false
+	}
+
 	lp.log.DEBUG.Printf("!! pvScalePhases DOWN activePhases: %d, available power: %.0fW, scalable: %t", activePhases, availablePower, scalable)
 
 	// scale down phases
@@ -1512,16 +1558,22 @@ func (lp *Loadpoint) phasesFromChargeCurrents() {
 	}
 
 	if lp.charging() && lp.phaseSwitchCompleted() {
-		var phases int
-		for _, i := range lp.chargeCurrents {
-			if i > minActiveCurrent {
+		var (
+			phases     int
+			gridPhases [3]bool
+		)
+
+		for i, current := range lp.chargeCurrents {
+			if current > minActiveCurrent {
 				phases++
+				gridPhases[lp.gridPhase(i)] = true
 			}
 		}
 
 		if phases >= 1 {
 			lp.Lock()
 			lp.measuredPhases = phases
+			lp.measuredGridPhases = gridPhases
 			lp.Unlock()
 
 			lp.log.DEBUG.Printf("detected active phases: %dp", phases)
@@ -1829,7 +1881,7 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 
 	// minimum or target charging
 	case lp.minSocNotReached() || plannerActive:
-		err = lp.fastCharging()
+		err = lp.planCharging()
 		lp.resetPhaseTimer()
 		lp.elapsePVTimer() // let PV mode disable immediately afterwards
 
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/planner"
 )
 
 //go:generate go tool mockgen -package loadpoint -destination mock.go -mock_names API=MockAPI github.com/evcc-io/evcc/core/loadpoint API
@@ -13,6 +14,11 @@ type Controller interface {
 	LoadpointControl(API)
 }
 
+// PlanController receives the loadpoint's active charging plan on every update
+type PlanController interface {
+	LoadpointPlan(planner.Plan)
+}
+
 // API is the external loadpoint API
 type API interface {
 	//
@@ -72,6 +78,10 @@ type API interface {
 	GetPhasesConfigured() int
 	// SetPhasesConfigured sets the configured phases
 	SetPhasesConfigured(int) error
+	// GetPhaseRotation returns the grid phase connected to the charger's L1
+	GetPhaseRotation() int
+	// SetPhaseRotation sets the grid phase connected to the charger's L1
+	SetPhaseRotation(int) error
 	// ActivePhases returns the active phases for the current vehicle
 	ActivePhases() int
 
@@ -116,7 +126,7 @@ type API interface {
 	// SocBasedPlanning determines if the planner is soc based
 	SocBasedPlanning() bool
 	// GetPlan creates a charging plan
-	GetPlan(targetTime time.Time, requiredDuration time.Duration) (api.Rates, error)
+	GetPlan(targetTime time.Time, requiredDuration time.Duration) (planner.Plan, error)
 
 	// GetSocConfig returns the soc poll settings
 	GetSocConfig() SocConfig
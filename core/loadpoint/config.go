@@ -21,6 +21,7 @@ type DynamicConfig struct {
 	DefaultMode      string    `json:"defaultMode"`
 	Priority         int       `json:"priority"`
 	PhasesConfigured int       `json:"phasesConfigured"`
+	PhaseRotation    *int      `json:"phaseRotation"`
 	MinCurrent       float64   `json:"minCurrent"`
 	MaxCurrent       float64   `json:"maxCurrent"`
 	SmartCostLimit   *float64  `json:"smartCostLimit"`
@@ -72,6 +73,10 @@ func (payload DynamicConfig) Apply(lp API) error {
 		err = lp.SetPhasesConfigured(payload.PhasesConfigured)
 	}
 
+	if err == nil && payload.PhaseRotation != nil {
+		err = lp.SetPhaseRotation(*payload.PhaseRotation)
+	}
+
 	if err == nil && payload.MinCurrent != 0 {
 		err = lp.SetMinCurrent(payload.MinCurrent)
 	}
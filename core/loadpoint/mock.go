@@ -14,6 +14,7 @@ import (
 	time "time"
 
 	api "github.com/evcc-io/evcc/api"
+	planner "github.com/evcc-io/evcc/core/planner"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -391,6 +392,20 @@ func (mr *MockAPIMockRecorder) GetMode() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMode", reflect.TypeOf((*MockAPI)(nil).GetMode))
 }
 
+// GetPhaseRotation mocks base method.
+func (m *MockAPI) GetPhaseRotation() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhaseRotation")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetPhaseRotation indicates an expected call of GetPhaseRotation.
+func (mr *MockAPIMockRecorder) GetPhaseRotation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhaseRotation", reflect.TypeOf((*MockAPI)(nil).GetPhaseRotation))
+}
+
 // GetPhases mocks base method.
 func (m *MockAPI) GetPhases() int {
 	m.ctrl.T.Helper()
@@ -420,10 +435,10 @@ func (mr *MockAPIMockRecorder) GetPhasesConfigured() *gomock.Call {
 }
 
 // GetPlan mocks base method.
-func (m *MockAPI) GetPlan(targetTime time.Time, requiredDuration time.Duration) (api.Rates, error) {
+func (m *MockAPI) GetPlan(targetTime time.Time, requiredDuration time.Duration) (planner.Plan, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPlan", targetTime, requiredDuration)
-	ret0, _ := ret[0].(api.Rates)
+	ret0, _ := ret[0].(planner.Plan)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -794,6 +809,20 @@ func (mr *MockAPIMockRecorder) SetMode(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMode", reflect.TypeOf((*MockAPI)(nil).SetMode), arg0)
 }
 
+// SetPhaseRotation mocks base method.
+func (m *MockAPI) SetPhaseRotation(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPhaseRotation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPhaseRotation indicates an expected call of SetPhaseRotation.
+func (mr *MockAPIMockRecorder) SetPhaseRotation(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPhaseRotation", reflect.TypeOf((*MockAPI)(nil).SetPhaseRotation), arg0)
+}
+
 // SetPhasesConfigured mocks base method.
 func (m *MockAPI) SetPhasesConfigured(arg0 int) error {
 	m.ctrl.T.Helper()
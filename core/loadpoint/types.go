@@ -28,6 +28,15 @@ type PollConfig struct {
 	Interval time.Duration `json:"interval"` // interval when not charging
 }
 
+//go:generate enumer -type PlanStrategy -trimprefix PlanStrategy -transform=lower -text
+type PlanStrategy int
+
+// Plan strategies
+const (
+	PlanStrategyCost  PlanStrategy = iota // cheapest slots at full power
+	PlanStrategySolar                     // solar forecast first, then cheapest slots with variable power
+)
+
 //go:generate enumer -type PollMode -trimprefix Poll -transform=lower -text
 type PollMode int
 
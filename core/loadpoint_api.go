@@ -557,6 +557,11 @@ func (lp *Loadpoint) GetChargePowerFlexibility() float64 {
 func (lp *Loadpoint) GetMaxPhaseCurrent() float64 {
 	lp.RLock()
 	defer lp.RUnlock()
+	return lp.getMaxPhaseCurrent()
+}
+
+// getMaxPhaseCurrent returns the current charge power (no mutex)
+func (lp *Loadpoint) getMaxPhaseCurrent() float64 {
 	if lp.chargeCurrents == nil {
 		return lp.chargeCurrent
 	}
@@ -0,0 +1,80 @@
+package core
+
+import "time"
+
+// reduceFuseLimit reduces the charge current by the required current for main fuse protection.
+// If the resulting current is below min current, charging is disabled.
+// Returns the effective current reduction.
+func (lp *Loadpoint) reduceFuseLimit(required float64) (float64, error) {
+	lp.RLock()
+	current := lp.getMaxPhaseCurrent()
+	lp.RUnlock()
+
+	if !lp.enabled || current <= 0 {
+		return 0, nil
+	}
+
+	target := max(0, min(current, lp.chargeCurrent)-required)
+	if target < lp.effectiveMinCurrent() {
+		target = 0
+	}
+
+	lp.fuseLimit = &target
+	lp.fuseReduction = current - target
+	lp.fuseUpdated = lp.clock.Now()
+
+	if err := lp.setLimit(target); err != nil {
+		return 0, err
+	}
+
+	return lp.fuseReduction, nil
+}
+
+// releaseFuseLimit raises the main fuse current limit by the available headroom.
+// The limit is removed once it no longer restricts the loadpoint.
+// Returns true if the limit was changed.
+func (lp *Loadpoint) releaseFuseLimit(headroom float64) bool {
+	if lp.fuseLimit == nil || headroom <= 0 {
+		return false
+	}
+
+	limit := *lp.fuseLimit + headroom
+
+	// re-enabling requires headroom for min current
+	if limit < lp.effectiveMinCurrent() {
+		return false
+	}
+
+	lp.fuseReduction = 0
+	lp.fuseUpdated = lp.clock.Now()
+
+	if limit >= lp.effectiveMaxCurrent() {
+		lp.log.DEBUG.Println("main fuse: limit released")
+		lp.fuseLimit = nil
+		return true
+	}
+
+	lp.fuseLimit = &limit
+	return true
+}
+
+// fuseSettled returns true if grid currents are expected to reflect the last main fuse limit change
+func (lp *Loadpoint) fuseSettled(settle time.Duration) bool {
+	return lp.clock.Since(lp.fuseUpdated) >= settle
+}
+
+// pendingFuseReduction returns the main fuse current reduction not yet reflected by grid currents
+func (lp *Loadpoint) pendingFuseReduction(settle time.Duration) float64 {
+	if lp.fuseLimit == nil || lp.fuseSettled(settle) {
+		return 0
+	}
+	return lp.fuseReduction
+}
+
+// applyFuseLimit restricts the charge current to the main fuse limit
+func (lp *Loadpoint) applyFuseLimit(chargeCurrent float64) float64 {
+	if lp.fuseLimit == nil {
+		return chargeCurrent
+	}
+	return min(chargeCurrent, *lp.fuseLimit)
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
 )
@@ -48,6 +50,7 @@ func (lp *Loadpoint) ResetMeasuredPhases() {
 // resetMeasuredPhases resets measured phases to unknown on vehicle disconnect, phase switch or phase api call
 func (lp *Loadpoint) resetMeasuredPhases() {
 	lp.measuredPhases = 0
+	lp.measuredGridPhases = [3]bool{}
 	lp.publish(keys.PhasesActive, lp.activePhases())
 }
 
@@ -99,6 +102,122 @@ func (lp *Loadpoint) activePhases() int {
 	return active
 }
 
+// GetPhaseRotation returns the grid phase connected to the charger's L1
+func (lp *Loadpoint) GetPhaseRotation() int {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.phaseRotation
+}
+
+// SetPhaseRotation sets the grid phase connected to the charger's L1
+func (lp *Loadpoint) SetPhaseRotation(phase int) error {
+	if phase < 0 || phase > 3 {
+		return fmt.Errorf("invalid phase rotation: %d", phase)
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set phase rotation:", phase)
+
+	if lp.phaseRotation != phase {
+		lp.setPhaseRotation(phase)
+		lp.resetMeasuredPhases()
+	}
+
+	return nil
+}
+
+// setPhaseRotation sets the grid phase connected to the charger's L1 (no mutex)
+func (lp *Loadpoint) setPhaseRotation(phase int) {
+	lp.phaseRotation = phase
+	lp.publish(keys.PhaseRotation, phase)
+	lp.settings.SetInt(keys.PhaseRotation, int64(phase))
+}
+
+// gridPhase returns the grid phase index (0-2) for the given charger phase index (0-2).
+// Unknown phase rotation is treated as identity.
+func (lp *Loadpoint) gridPhase(i int) int {
+	if lp.phaseRotation == 0 {
+		return i
+	}
+	return (lp.phaseRotation - 1 + i) % 3
+}
+
+// GridPhases returns the grid phases loaded when charging on the given number of phases
+func (lp *Loadpoint) GridPhases(phases int) [3]bool {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.gridPhases(phases)
+}
+
+// gridPhases returns the grid phases loaded when charging on the given number of phases.
+// Measured grid phases take precedence. All phases are assumed loaded if the phase rotation is unknown.
+func (lp *Loadpoint) gridPhases(phases int) [3]bool {
+	if lp.phaseRotation != 0 && lp.measuredPhases == phases && lp.measuredGridPhases != [3]bool{} {
+		return lp.measuredGridPhases
+	}
+
+	if lp.phaseRotation == 0 || phases >= 3 {
+		return [3]bool{true, true, true}
+	}
+
+	var res [3]bool
+	for i := range phases {
+		res[lp.gridPhase(i)] = true
+	}
+
+	return res
+}
+
+// gridPhaseCandidates returns the possible grid phases loaded when charging on the given number of phases.
+// If the phase rotation is unknown, any grid phase may be loaded by charging on less than 3 phases.
+func (lp *Loadpoint) gridPhaseCandidates(phases int) [][3]bool {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if lp.phaseRotation != 0 || phases >= 3 {
+		return [][3]bool{lp.gridPhases(phases)}
+	}
+
+	res := make([][3]bool, 0, 3)
+	for rotation := range 3 {
+		var candidate [3]bool
+		for i := range phases {
+			candidate[(rotation+i)%3] = true
+		}
+		res = append(res, candidate)
+	}
+
+	return res
+}
+
+// GetPhaseCurrents returns the charge currents ordered by grid phase
+func (lp *Loadpoint) GetPhaseCurrents() (float64, float64, float64) {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if lp.phaseRotation == 0 {
+		current := lp.getMaxPhaseCurrent()
+		return current, current, current
+	}
+
+	var res [3]float64
+	if lp.chargeCurrents != nil {
+		for i, current := range lp.chargeCurrents {
+			res[lp.gridPhase(i)] = current
+		}
+	} else {
+		for i, loaded := range lp.gridPhases(lp.activePhases()) {
+			if loaded {
+				res[i] = lp.chargeCurrent
+			}
+		}
+	}
+
+	return res[0], res[1], res[2]
+}
+
 // MinActivePhases returns the minimum number of active phases for the loadpoint.
 func (lp *Loadpoint) MinActivePhases() int {
 	lp.RLock()
@@ -162,3 +281,38 @@ func (lp *Loadpoint) hasPhaseSwitching() bool {
 	_, ok := lp.charger.(api.PhaseSwitcher)
 	return ok
 }
+
+// phaseImbalanceExceeded returns true if 1p charging at the given current would exceed any circuit's unbalanced load limit.
+// If the phase mapping is unknown, the worst case grid phase is assumed.
+func (lp *Loadpoint) phaseImbalanceExceeded(current float64) bool {
+	var own [3]float64
+	own[0], own[1], own[2] = lp.GetPhaseCurrents()
+
+	candidates := lp.gridPhaseCandidates(1)
+
+	for c := lp.circuit; c != nil; c = c.GetParent() {
+		maxImbalance := c.GetMaxImbalance()
+		if maxImbalance == 0 {
+			continue
+		}
+
+		l1, l2, l3 := c.GetPhaseCurrents()
+
+		for _, phases := range candidates {
+			currents := [3]float64{l1, l2, l3}
+			for i := range currents {
+				currents[i] -= own[i]
+				if phases[i] {
+					currents[i] += current
+				}
+			}
+
+			if imbalance := max(currents[0], currents[1], currents[2]) - min(currents[0], currents[1], currents[2]); imbalance > maxImbalance {
+				lp.log.DEBUG.Printf("1p charging at %.3gA exceeds unbalanced load limit of circuit %s: %.3gA > %.3gA", current, c.GetTitle(), imbalance, maxImbalance)
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -477,3 +477,106 @@ func TestScalePhasesIfAvailable(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestGridPhaseCurrents(t *testing.T) {
+	tc := []struct {
+		rotation   int
+		currents   []float64
+		phases     int
+		gridPhases [3]bool
+		expected   [3]float64
+	}{
+		{0, []float64{16, 0, 0}, 1, [3]bool{true, true, true}, [3]float64{16, 16, 16}},
+		{1, []float64{16, 0, 0}, 1, [3]bool{true, false, false}, [3]float64{16, 0, 0}},
+		{2, []float64{16, 0, 0}, 1, [3]bool{false, true, false}, [3]float64{0, 16, 0}},
+		{3, []float64{16, 0, 0}, 1, [3]bool{false, false, true}, [3]float64{0, 0, 16}},
+		{3, []float64{6, 7, 8}, 3, [3]bool{true, true, true}, [3]float64{7, 8, 6}},
+		{2, nil, 1, [3]bool{false, true, false}, [3]float64{0, 10, 0}},
+		{3, []float64{16, 16, 0}, 2, [3]bool{true, false, true}, [3]float64{16, 0, 16}},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		lp := &Loadpoint{
+			log:            util.NewLogger("foo"),
+			phaseRotation:  tc.rotation,
+			phases:         tc.phases,
+			chargeCurrent:  10,
+			chargeCurrents: tc.currents,
+		}
+
+		require.Equal(t, tc.gridPhases, lp.gridPhases(tc.phases))
+
+		l1, l2, l3 := lp.GetPhaseCurrents()
+		require.Equal(t, tc.expected, [3]float64{l1, l2, l3})
+	}
+}
+
+func TestMeasuredGridPhases(t *testing.T) {
+	lp := &Loadpoint{
+		log:            util.NewLogger("foo"),
+		status:         api.StatusC,
+		phaseRotation:  2,
+		phases:         1,
+		chargeCurrents: []float64{0, 0, 16},
+	}
+
+	// charger connected to L3 although single phase charging is expected on L1
+	lp.phasesFromChargeCurrents()
+
+	require.Equal(t, 1, lp.measuredPhases)
+	require.Equal(t, [3]bool{true, false, false}, lp.gridPhases(1))
+
+	lp.resetMeasuredPhases()
+	require.Equal(t, [3]bool{false, true, false}, lp.gridPhases(1))
+}
+
+func TestPhaseImbalanceExceeded(t *testing.T) {
+	tc := []struct {
+		rotation int
+		exceeded bool
+	}{
+		{0, true},  // worst case
+		{1, true},  // L1: 22+6A vs 2A
+		{2, false}, // L2: 22A vs 2+6A
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		ctrl := gomock.NewController(t)
+
+		circuit := api.NewMockCircuit(ctrl)
+		circuit.EXPECT().GetMaxImbalance().Return(20.0).AnyTimes()
+		circuit.EXPECT().GetPhaseCurrents().Return(30.0, 10.0, 10.0).AnyTimes()
+		circuit.EXPECT().GetParent().Return(nil).AnyTimes()
+		circuit.EXPECT().GetTitle().Return("foo").AnyTimes()
+
+		lp := &Loadpoint{
+			log:            util.NewLogger("foo"),
+			circuit:        circuit,
+			phaseRotation:  tc.rotation,
+			phases:         3,
+			chargeCurrent:  8,
+			chargeCurrents: []float64{8, 8, 8},
+		}
+
+		require.Equal(t, tc.exceeded, lp.phaseImbalanceExceeded(6))
+	}
+}
+
+func TestGridPhaseCandidates(t *testing.T) {
+	lp := &Loadpoint{
+		log:    util.NewLogger("foo"),
+		phases: 1,
+	}
+
+	// unknown rotation
+	require.Equal(t, [][3]bool{{true, false, false}, {false, true, false}, {false, false, true}}, lp.gridPhaseCandidates(1))
+	require.Equal(t, [][3]bool{{true, true, false}, {false, true, true}, {true, false, true}}, lp.gridPhaseCandidates(2))
+	require.Equal(t, [][3]bool{{true, true, true}}, lp.gridPhaseCandidates(3))
+
+	lp.phaseRotation = 2
+	require.Equal(t, [][3]bool{{false, true, false}}, lp.gridPhaseCandidates(1))
+}
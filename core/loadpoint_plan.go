@@ -6,6 +6,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/vehicle"
 )
@@ -21,6 +22,7 @@ const (
 func (lp *Loadpoint) setPlanActive(active bool) {
 	if !active {
 		lp.planSlotEnd = time.Time{}
+		lp.planSlotPower = 0
 	}
 	if lp.planActive != active {
 		lp.planActive = active
@@ -78,13 +80,89 @@ func (lp *Loadpoint) GetPlanGoal() (float64, bool) {
 	return limit, false
 }
 
-// GetPlan creates a charging plan for given time and duration
-func (lp *Loadpoint) GetPlan(targetTime time.Time, requiredDuration time.Duration) (api.Rates, error) {
+// capacityPlan is the plan computed for given plan time and duration
+type capacityPlan struct {
+	time     time.Time
+	duration time.Duration
+	plan     planner.Plan
+}
+
+// setPlanCapacity sets the available plan power and the plan computed by the site
+func (lp *Loadpoint) setPlanCapacity(capacity planner.Capacity, plan capacityPlan) {
+	lp.Lock()
+	defer lp.Unlock()
+	lp.planCapacity = capacity
+	lp.capacityPlan = plan
+}
+
+// setPlanConflict publishes the plan conflict status and notifies on new conflicts
+func (lp *Loadpoint) setPlanConflict(conflict bool) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.planConflict == conflict {
+		return
+	}
+
+	if conflict {
+		lp.pushEvent(evPlanConflict)
+	}
+
+	lp.planConflict = conflict
+	lp.publish(keys.PlanConflict, conflict)
+}
+
+// hasPlanCapacity returns true if the available plan power is determined by the site
+func (lp *Loadpoint) hasPlanCapacity() bool {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.planCapacity != nil
+}
+
+// planDemand returns plan time and required duration if the loadpoint needs charge planning
+func (lp *Loadpoint) planDemand() (time.Time, time.Duration) {
+	if !lp.connected() {
+		return time.Time{}, 0
+	}
+
+	planTime := lp.EffectivePlanTime()
+	if planTime.IsZero() {
+		return time.Time{}, 0
+	}
+
+	goal, _ := lp.GetPlanGoal()
+	return planTime, lp.GetPlanRequiredDuration(goal, lp.EffectiveMaxPower())
+}
+
+// GetPlan creates a charging plan for given time and duration.
+// The plan computed by the site is reused if time and duration match.
+func (lp *Loadpoint) GetPlan(targetTime time.Time, requiredDuration time.Duration) (planner.Plan, error) {
+	lp.RLock()
+	capacity, cached := lp.planCapacity, lp.capacityPlan
+	lp.RUnlock()
+
+	if capacity != nil && cached.time.Equal(targetTime) && cached.duration == requiredDuration {
+		return cached.plan, nil
+	}
+
+	return lp.getPlan(targetTime, requiredDuration, capacity)
+}
+
+// getPlan creates a charging plan for given time, duration and available plan power.
+// Capacity is only set by the site if reservations of other loadpoints constrain the plan.
+func (lp *Loadpoint) getPlan(targetTime time.Time, requiredDuration time.Duration, capacity planner.Capacity) (planner.Plan, error) {
 	if lp.planner == nil || targetTime.IsZero() {
 		return nil, nil
 	}
 
-	return lp.planner.Plan(requiredDuration, targetTime)
+	maxPower := lp.EffectiveMaxPower()
+
+	if lp.PlanStrategy == loadpoint.PlanStrategySolar || capacity != nil {
+		return lp.planner.PowerPlan(requiredDuration.Hours()*maxPower, maxPower, targetTime, capacity)
+	}
+
+	plan, err := lp.planner.Plan(requiredDuration, targetTime)
+	return planner.FromRates(plan, maxPower), err
 }
 
 // plannerActive checks if the charging plan has a currently active slot
@@ -95,11 +173,25 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 
 	var planStart, planEnd time.Time
 	var planOverrun time.Duration
+	var planGridShare, planCost *float64
+	var powerPlan planner.Plan
+
+	defer func() {
+		if ctrl, ok := lp.charger.(loadpoint.PlanController); ok {
+			// plan is not in effect while charging is disabled
+			if lp.GetMode() == api.ModeOff || lp.remoteControlled(loadpoint.RemoteHardDisable) {
+				powerPlan = nil
+			}
+			ctrl.LoadpointPlan(powerPlan)
+		}
+	}()
 
 	defer func() {
 		lp.publish(keys.PlanProjectedStart, planStart)
 		lp.publish(keys.PlanProjectedEnd, planEnd)
 		lp.publish(keys.PlanOverrun, planOverrun)
+		lp.publish(keys.PlanGridShare, planGridShare)
+		lp.publish(keys.PlanCost, planCost)
 	}()
 
 	// re-check since plannerActive() is called before connected() check in Update()
@@ -109,6 +201,7 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 
 	planTime := lp.EffectivePlanTime()
 	if planTime.IsZero() {
+		lp.resetPlanGuard()
 		return false
 	}
 
@@ -123,6 +216,8 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 	maxPower := lp.EffectiveMaxPower()
 	requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower)
 	if requiredDuration <= 0 {
+		lp.resetPlanGuard()
+
 		// continue a 100% plan as long as the vehicle is charging
 		if lp.planActive && isSocBased && goal == 100 && lp.charging() {
 			return true
@@ -132,12 +227,26 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 		return false
 	}
 
-	plan, err := lp.GetPlan(planTime, requiredDuration)
+	// re-plan using measured charging progress
+	var planRisk bool
+	if isSocBased {
+		requiredDuration, planRisk = lp.guardPlanDuration(goal, requiredDuration, planTime)
+	}
+	lp.setPlanRisk(planRisk)
+
+	powerPlan, err := lp.GetPlan(planTime, requiredDuration)
 	if err != nil {
 		lp.log.ERROR.Println("planner:", err)
 		return false
 	}
 
+	plan := powerPlan.Rates()
+
+	if len(powerPlan) > 0 {
+		gridShare, cost := powerPlan.GridShare(), powerPlan.Cost()
+		planGridShare, planCost = &gridShare, &cost
+	}
+
 	var overrun string
 	if excessDuration := requiredDuration - lp.clock.Until(planTime); excessDuration > 0 {
 		overrun = fmt.Sprintf("overruns by %v, ", excessDuration.Round(time.Second))
@@ -146,13 +255,19 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 
 	planStart = planner.Start(plan)
 	planEnd = planner.End(plan)
-	lp.log.DEBUG.Printf("plan: charge %v between %v until %v (%spower: %.0fW, avg cost: %.3f)",
+	lp.log.DEBUG.Printf("plan: charge %v between %v until %v (%spower: %.0fW, avg cost: %.3f, grid share: %.0f%%)",
 		planner.Duration(plan).Round(time.Second), planStart.Round(time.Second).Local(), planTime.Round(time.Second).Local(), overrun,
-		maxPower, planner.AverageCost(plan))
+		maxPower, planner.AverageCost(plan), 100*powerPlan.GridShare())
 
 	// log plan
-	for _, slot := range plan {
-		lp.log.TRACE.Printf("  slot from: %v to %v cost %.3f", slot.Start.Round(time.Second).Local(), slot.End.Round(time.Second).Local(), slot.Price)
+	for _, slot := range powerPlan {
+		lp.log.TRACE.Printf("  slot from: %v to %v cost %.3f power %.0fW (solar %.0fW)", slot.Start.Round(time.Second).Local(), slot.End.Round(time.Second).Local(), slot.Price, slot.Power, slot.SolarPower)
+	}
+
+	// force charging if plan is at risk
+	if planRisk {
+		lp.forcePlanCharging()
+		return true
 	}
 
 	activeSlot := planner.SlotAt(lp.clock.Now(), plan)
@@ -168,6 +283,11 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 		// remember last active plan's end time
 		lp.setPlanActive(true)
 		lp.planSlotEnd = activeSlot.End
+
+		// variable power only applies to power-aware planning
+		if lp.PlanStrategy == loadpoint.PlanStrategySolar || lp.hasPlanCapacity() {
+			lp.planSlotPower = powerPlan.SlotAt(activeSlot.Start).Power
+		}
 	} else if lp.planActive {
 		// planner was active (any slot, not necessarily previous slot) and charge goal has not yet been met
 		switch {
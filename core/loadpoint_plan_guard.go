@@ -0,0 +1,110 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+const (
+	evPlanRisk = "planrisk" // plan goal at risk
+
+	planGuardInterval = 15 * time.Minute // minimum interval for measuring soc progress
+	planGuardMargin   = 15 * time.Minute // safety margin before forcing charge
+	planGuardMinPower = 500              // minimum measured power considered for estimating duration (W)
+)
+
+// planGuard tracks the measured charging progress of a soc-based plan
+type planGuard struct {
+	start   time.Time // begin of current measurement window
+	soc     float64   // soc at begin of current measurement window
+	socRate float64   // measured soc increase in %/h
+	risk    bool      // plan goal at risk
+}
+
+// resetPlanGuard resets plan progress measurement
+func (lp *Loadpoint) resetPlanGuard() {
+	lp.setPlanRisk(false)
+	lp.planGuard = planGuard{}
+}
+
+// fullPowerCharging returns true if the loadpoint is charging without reduced current
+func (lp *Loadpoint) fullPowerCharging() bool {
+	return lp.charging() && lp.planSlotPower == 0 && lp.chargeCurrent >= lp.effectiveMaxCurrent()
+}
+
+// forcePlanCharging charges at full power while the plan is at risk, overriding reduced slot power
+func (lp *Loadpoint) forcePlanCharging() {
+	lp.log.DEBUG.Println("plan: goal at risk, forcing charge")
+	lp.planSlotPower = 0
+}
+
+// updatePlanSocRate measures the soc increase while charging at full power
+func (lp *Loadpoint) updatePlanSocRate() {
+	g := &lp.planGuard
+
+	if !lp.fullPowerCharging() {
+		// restart measurement next time, keep measured rate
+		g.start = time.Time{}
+		return
+	}
+
+	if g.start.IsZero() {
+		g.start = lp.clock.Now()
+		g.soc = lp.vehicleSoc
+		return
+	}
+
+	if elapsed := lp.clock.Since(g.start); elapsed >= planGuardInterval {
+		g.socRate = max(0, lp.vehicleSoc-g.soc) / elapsed.Hours()
+		lp.log.DEBUG.Printf("plan: measured soc rate %.1f%%/h", g.socRate)
+
+		g.start = lp.clock.Now()
+		g.soc = lp.vehicleSoc
+	}
+}
+
+// guardPlanDuration returns the required plan duration taking measured charge power and soc progress into account.
+// The plan is at risk if the goal can't be reached in time without forcing charge immediately.
+func (lp *Loadpoint) guardPlanDuration(goal float64, requiredDuration time.Duration, planTime time.Time) (time.Duration, bool) {
+	lp.updatePlanSocRate()
+
+	estimated := requiredDuration
+
+	// measured charge power
+	if lp.socEstimator != nil && lp.fullPowerCharging() && lp.chargePower > planGuardMinPower {
+		if d := lp.socEstimator.RemainingChargeDuration(int(goal), lp.chargePower); d > estimated {
+			lp.log.DEBUG.Printf("plan: charge power %.0fW requires %v", lp.chargePower, d.Round(time.Second))
+			estimated = d
+		}
+	}
+
+	// measured soc progress
+	if rate := lp.planGuard.socRate; rate > 0 {
+		if d := time.Duration((goal - lp.vehicleSoc) / rate * float64(time.Hour)); d > estimated {
+			lp.log.DEBUG.Printf("plan: soc rate %.1f%%/h requires %v", rate, d.Round(time.Second))
+			estimated = d
+		}
+	}
+
+	risk := estimated > requiredDuration && estimated+planGuardMargin >= lp.clock.Until(planTime)
+	if risk {
+		lp.log.WARN.Printf("plan: goal at risk, requires %v until %v", estimated.Round(time.Second), planTime.Round(time.Second).Local())
+	}
+
+	return estimated, risk
+}
+
+// setPlanRisk publishes the plan risk status and notifies when the plan becomes at risk
+func (lp *Loadpoint) setPlanRisk(risk bool) {
+	if lp.planGuard.risk == risk {
+		return
+	}
+
+	if risk {
+		lp.pushEvent(evPlanRisk)
+	}
+
+	lp.planGuard.risk = risk
+	lp.publish(keys.PlanRisk, risk)
+}
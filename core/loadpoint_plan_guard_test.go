@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGuardPlanDuration(t *testing.T) {
+	tc := []struct {
+		desc     string
+		planTime time.Duration
+		duration time.Duration
+		risk     bool
+	}{
+		{"behind plan", 5 * time.Hour, 210 * time.Minute, false},
+		{"behind plan, at risk", 220 * time.Minute, 210 * time.Minute, true},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.desc, func(t *testing.T) {
+			clock := clock.NewMock()
+			ctrl := gomock.NewController(t)
+
+			lp := &Loadpoint{
+				log:           util.NewLogger("foo"),
+				clock:         clock,
+				charger:       api.NewMockCharger(ctrl),
+				maxCurrent:    maxA,
+				chargeCurrent: maxA,
+				status:        api.StatusC,
+				vehicleSoc:    50,
+			}
+
+			planTime := clock.Now().Add(tc.planTime)
+
+			// start measurement
+			d, risk := lp.guardPlanDuration(80, 3*time.Hour, planTime)
+			assert.Equal(t, 3*time.Hour, d)
+			assert.False(t, risk)
+
+			// 2% in 15 minutes
+			clock.Add(planGuardInterval)
+			lp.vehicleSoc = 52
+
+			d, risk = lp.guardPlanDuration(80, 3*time.Hour, planTime)
+			assert.Equal(t, tc.duration, d)
+			assert.Equal(t, tc.risk, risk)
+		})
+	}
+}
+
+func TestForcePlanChargingDuringReducedSlot(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock,
+		charger:       api.NewMockCharger(ctrl),
+		maxCurrent:    maxA,
+		chargeCurrent: maxA,
+		status:        api.StatusC,
+		vehicleSoc:    50,
+		planGuard:     planGuard{socRate: 8}, // measured before reduced slot
+	}
+
+	planTime := clock.Now().Add(220 * time.Minute)
+
+	// reduced power slot
+	lp.planSlotPower = 3000
+	assert.False(t, lp.fullPowerCharging())
+
+	_, risk := lp.guardPlanDuration(80, 3*time.Hour, planTime)
+	assert.True(t, risk)
+
+	lp.forcePlanCharging()
+	assert.Zero(t, lp.planSlotPower)
+	assert.True(t, lp.fullPowerCharging())
+}
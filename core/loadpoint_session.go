@@ -23,6 +23,23 @@ func (lp *Loadpoint) chargeMeterTotal() float64 {
 	return f
 }
 
+// sampleChargeTotalImport reads the charge meter total import for the energy history.
+// Zero is returned if not available, the history then integrates charge power instead.
+func (lp *Loadpoint) sampleChargeTotalImport() float64 {
+	m, ok := lp.chargeMeter.(api.MeterEnergy)
+	if !ok {
+		return 0
+	}
+
+	f, err := m.TotalEnergy()
+	if err != nil {
+		lp.log.DEBUG.Printf("history: charge total import: %v", err)
+		return 0
+	}
+
+	return f
+}
+
 // createSession creates a charging session. The created timestamp is empty until set by evChargeStartHandler.
 // The session is not persisted yet. That will only happen when stopSession is called.
 func (lp *Loadpoint) createSession() {
@@ -73,10 +90,26 @@ func (lp *Loadpoint) stopSession() {
 	s.Co2PerKWh = lp.energyMetrics.Co2PerKWh()
 	s.ChargedEnergy = lp.energyMetrics.TotalWh() / 1e3
 	s.ChargeDuration = lo.ToPtr(lp.chargeDuration.Abs())
+	s.Slots = lp.energyMetrics.Slots()
+	lp.updateSignedMeterValues(s)
 
 	lp.db.Persist(s)
 }
 
+// updateSignedMeterValues updates the session's signed meter values from the charger
+func (lp *Loadpoint) updateSignedMeterValues(s *session.Session) {
+	if c, ok := lp.charger.(api.SignedMeterValues); ok {
+		if start, stop, err := c.SignedMeterValues(); err == nil {
+			s.SignedMeterStart, s.SignedMeterStop = start, stop
+		}
+	}
+}
+
+// setTariffSlot updates the tariff slot for accounting session energy by slot and source
+func (lp *Loadpoint) setTariffSlot(slot tariffSlot) {
+	lp.energyMetrics.SetTariffSlot(slot)
+}
+
 type sessionOption func(*session.Session)
 
 // updateSession updates any parameter of a charging session and persists the session.
@@ -85,6 +85,10 @@ func TestSession(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, s, 1)
 	t.Logf("session: %+v", s)
+
+	// tariff slots are replaced, not duplicated
+	require.Len(t, s[0].Slots, 1)
+	assert.InDelta(t, lp.getChargedEnergy()/1e3, s[0].Slots[0].GridEnergy, 1e-6)
 }
 
 func TestCloseSessionsOnStartup_emptyDb(t *testing.T) {
@@ -181,3 +185,47 @@ func createMockSessions(db *session.DB, clock *clock.Mock) []*session.Session {
 	}
 	return sessions
 }
+
+type signedCharger struct {
+	api.Charger
+	start, stop string
+}
+
+func (c *signedCharger) SignedMeterValues() (string, string, error) {
+	return c.start, c.stop, nil
+}
+
+func TestSessionSignedMeterValues(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	db, err := session.NewStore("foo", serverdb.Instance)
+	require.NoError(t, err)
+
+	charger := &signedCharger{start: "OCMF|begin"}
+
+	lp := &Loadpoint{
+		log:     util.NewLogger("foo"),
+		clock:   clock.NewMock(),
+		db:      db,
+		charger: charger,
+	}
+
+	lp.createSession()
+	lp.session.Created = lp.clock.Now()
+
+	// charging stopped while transaction is still open
+	lp.stopSession()
+	assert.Equal(t, "OCMF|begin", lp.session.SignedMeterStart)
+	assert.Empty(t, lp.session.SignedMeterStop)
+
+	// transaction end available on disconnect
+	charger.stop = "OCMF|end"
+	lp.updateSession(lp.updateSignedMeterValues)
+
+	s, err := db.Sessions()
+	require.NoError(t, err)
+	require.Len(t, s, 1)
+	assert.Equal(t, "OCMF|end", s[0].SignedMeterStop)
+}
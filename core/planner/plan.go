@@ -0,0 +1,126 @@
+package planner
+
+import (
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Slot is a plan slot with planned charge power
+type Slot struct {
+	api.Rate
+	Power      float64 `json:"power"`      // planned charge power (W)
+	SolarPower float64 `json:"solarPower"` // planned charge power covered by solar surplus (W)
+	maxPower   float64 // available charge power during planning
+}
+
+// GridPower is the planned charge power taken from grid
+func (s Slot) GridPower() float64 {
+	return max(0, s.Power-s.SolarPower)
+}
+
+func (s Slot) hours() float64 {
+	return s.End.Sub(s.Start).Hours()
+}
+
+// Plan is a charging plan with variable power per slot
+type Plan []Slot
+
+// FromRates creates a plan from given rates charging at fixed grid power
+func FromRates(rates api.Rates, power float64) Plan {
+	res := make(Plan, 0, len(rates))
+	for _, r := range rates {
+		res = append(res, Slot{Rate: r, Power: power})
+	}
+	return res
+}
+
+// Rates returns the plan's slots as rates
+func (p Plan) Rates() api.Rates {
+	if p == nil {
+		return nil
+	}
+
+	res := make(api.Rates, 0, len(p))
+	for _, s := range p {
+		res = append(res, s.Rate)
+	}
+	return res
+}
+
+// Sort plan by start time
+func (p Plan) Sort() {
+	slices.SortStableFunc(p, func(i, j Slot) int {
+		return i.Start.Compare(j.Start)
+	})
+}
+
+// Energy returns the planned charge energy in Wh
+func (p Plan) Energy() float64 {
+	var energy float64
+	for _, s := range p {
+		energy += s.Power * s.hours()
+	}
+	return energy
+}
+
+// GridEnergy returns the planned charge energy taken from grid in Wh
+func (p Plan) GridEnergy() float64 {
+	var energy float64
+	for _, s := range p {
+		energy += s.GridPower() * s.hours()
+	}
+	return energy
+}
+
+// GridShare returns the planned share of grid energy (0..1)
+func (p Plan) GridShare() float64 {
+	energy := p.Energy()
+	if energy == 0 {
+		return 0
+	}
+	return p.GridEnergy() / energy
+}
+
+// Cost returns the expected cost of grid energy
+func (p Plan) Cost() float64 {
+	var cost float64
+	for _, s := range p {
+		cost += s.GridPower() * s.hours() / 1e3 * s.Price
+	}
+	return cost
+}
+
+// AveragePower returns the time-weighted average planned power between start and end
+func (p Plan) AveragePower(start, end time.Time) float64 {
+	if !end.After(start) {
+		return 0
+	}
+
+	var energy float64
+	for _, s := range p {
+		from, to := s.Start, s.End
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if to.After(from) {
+			energy += s.Power * to.Sub(from).Hours()
+		}
+	}
+
+	return energy / end.Sub(start).Hours()
+}
+
+// SlotAt returns the slot for the given time or an empty slot
+func (p Plan) SlotAt(time time.Time) Slot {
+	for _, slot := range p {
+		if !slot.Start.After(time) && slot.End.After(time) {
+			return slot
+		}
+	}
+	return Slot{}
+}
@@ -11,9 +11,11 @@ import (
 
 // Planner plans a series of charging slots for a given (variable) tariff
 type Planner struct {
-	log    *util.Logger
-	clock  clock.Clock // mockable time
-	tariff api.Tariff
+	log      *util.Logger
+	clock    clock.Clock // mockable time
+	tariff   api.Tariff
+	solar    api.Tariff     // solar forecast
+	baseLoad func() float64 // expected home consumption
 }
 
 // New creates a price planner
@@ -31,6 +33,20 @@ func New(log *util.Logger, tariff api.Tariff, opt ...func(t *Planner)) *Planner
 	return p
 }
 
+// WithSolarForecast adds a solar forecast for power-aware planning
+func WithSolarForecast(solar api.Tariff) func(t *Planner) {
+	return func(t *Planner) {
+		t.solar = solar
+	}
+}
+
+// WithBaseLoad subtracts the expected home consumption from the solar forecast
+func WithBaseLoad(baseLoad func() float64) func(t *Planner) {
+	return func(t *Planner) {
+		t.baseLoad = baseLoad
+	}
+}
+
 // plan creates a lowest-cost plan or required duration.
 // It MUST already established that
 // - rates are sorted in ascending order by cost and descending order by start time (prefer late slots)
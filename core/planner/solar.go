@@ -0,0 +1,201 @@
+package planner
+
+import (
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Capacity returns the maximum available charge power between start and end
+type Capacity func(start, end time.Time) float64
+
+// sortSlotsByCost is a sortFunc for slices.Sort
+func sortSlotsByCost(i, j Slot) int {
+	return sortByCost(i.Rate, j.Rate)
+}
+
+// sortSlotsBySolar sorts by descending solar power, preferring late slots
+func sortSlotsBySolar(i, j Slot) int {
+	switch {
+	case i.SolarPower > j.SolarPower:
+		return -1
+	case i.SolarPower < j.SolarPower:
+		return +1
+	default:
+		return j.Start.Compare(i.Start)
+	}
+}
+
+// withSolar assigns the solar surplus to all plan slots and limits power to available capacity
+func withSolar(plan Plan, surplus, capacity Capacity) Plan {
+	for i, s := range plan {
+		if capacity != nil {
+			plan[i].Power = min(s.Power, max(0, capacity(s.Start, s.End)))
+		}
+		plan[i].SolarPower = min(plan[i].Power, surplus(s.Start, s.End))
+	}
+
+	return slices.DeleteFunc(plan, func(s Slot) bool {
+		return s.Power <= 0
+	})
+}
+
+// powerPlan distributes required energy across slots. Slots' solar power denotes the available solar surplus.
+// If slots are insufficient, the plan provides less than the required energy.
+// Solar surplus is used first, remaining energy is taken from grid in the cheapest slots.
+func (t *Planner) powerPlan(slots Plan, requiredEnergy float64) Plan {
+	// solar surplus, largest first
+	slices.SortStableFunc(slots, sortSlotsBySolar)
+
+	for i, s := range slots {
+		if requiredEnergy <= 0 || s.SolarPower <= 0 {
+			slots[i].SolarPower = 0
+			continue
+		}
+
+		if energy := s.SolarPower * s.hours(); energy > requiredEnergy {
+			slots[i].SolarPower = requiredEnergy / s.hours()
+		}
+
+		slots[i].Power = slots[i].SolarPower
+		requiredEnergy -= slots[i].Power * s.hours()
+	}
+
+	// grid, cheapest first
+	slices.SortStableFunc(slots, sortSlotsByCost)
+
+	for i, s := range slots {
+		if requiredEnergy <= 0 {
+			break
+		}
+
+		headroom := s.maxPower - s.Power
+		if headroom <= 0 {
+			continue
+		}
+
+		energy := min(headroom*s.hours(), requiredEnergy)
+		slots[i].Power += energy / s.hours()
+		requiredEnergy -= energy
+	}
+
+	plan := slices.DeleteFunc(slots, func(s Slot) bool {
+		return s.Power <= 0
+	})
+
+	// available power is only relevant during planning
+	for i := range plan {
+		plan[i].maxPower = 0
+	}
+
+	plan.Sort()
+
+	return plan
+}
+
+// warnShortfall logs if the plan does not provide the required energy
+func (t *Planner) warnShortfall(plan Plan, requiredEnergy float64) {
+	if missing := requiredEnergy - plan.Energy(); missing > 0.01*requiredEnergy {
+		t.log.WARN.Printf("plan: goal not reachable until target time, missing %.1fkWh", missing/1e3)
+	}
+}
+
+// PowerPlan creates a lowest-cost plan for the required energy (Wh) until target time.
+// Other than Plan, PowerPlan considers the solar forecast reduced by the expected home consumption
+// and plans variable power per slot. Optional capacity limits the charge power per slot, e.g. due to shared circuits.
+func (t *Planner) PowerPlan(requiredEnergy, maxPower float64, targetTime time.Time, capacity Capacity) (Plan, error) {
+	if t == nil || requiredEnergy <= 0 || maxPower <= 0 {
+		return nil, nil
+	}
+
+	var forecast api.Rates
+	if t.solar != nil {
+		var err error
+		if forecast, err = t.solar.Rates(); err != nil {
+			t.log.WARN.Println("solar forecast:", err)
+		}
+	}
+
+	var baseLoad float64
+	if t.baseLoad != nil {
+		baseLoad = t.baseLoad()
+	}
+
+	// solar surplus after home consumption
+	surplus := func(start, end time.Time) float64 {
+		return max(0, forecast.Average(start, end)-baseLoad)
+	}
+
+	requiredDuration := time.Duration(requiredEnergy / maxPower * float64(time.Hour))
+
+	var rates api.Rates
+	if t.tariff != nil {
+		var err error
+		if rates, err = t.tariff.Rates(); err != nil {
+			t.log.WARN.Println("planner tariff:", err)
+		}
+	}
+
+	// without prices, use solar forecast slots at zero cost
+	if len(rates) == 0 {
+		for _, r := range forecast {
+			rates = append(rates, api.Rate{Start: r.Start, End: r.End})
+		}
+	}
+
+	// consume remaining time or plan without any forecast
+	if t.clock.Until(targetTime) <= requiredDuration || len(rates) == 0 {
+		res, err := t.Plan(requiredDuration, targetTime)
+		plan := withSolar(FromRates(res, maxPower), surplus, capacity)
+		t.warnShortfall(plan, requiredEnergy)
+		return plan, err
+	}
+
+	// reduce planning horizon to available rates
+	if last := rates[len(rates)-1].End; targetTime.After(last) {
+		// there is enough time for charging after end of current rates
+		durationAfterRates := targetTime.Sub(last)
+		if durationAfterRates >= requiredDuration {
+			return nil, nil
+		}
+
+		t.log.DEBUG.Printf("target time beyond available slots- reducing plan horizon by %v", durationAfterRates.Round(time.Second))
+
+		targetTime = last
+		requiredEnergy -= durationAfterRates.Hours() * maxPower
+	}
+
+	now := t.clock.Now()
+
+	slots := make(Plan, 0, len(rates))
+	for _, r := range rates {
+		if !r.End.After(now) || !r.Start.Before(targetTime) {
+			continue
+		}
+
+		// adjust slot start and end
+		if r.Start.Before(now) {
+			r.Start = now
+		}
+		if r.End.After(targetTime) {
+			r.End = targetTime
+		}
+
+		slotMax := maxPower
+		if capacity != nil {
+			slotMax = min(slotMax, max(0, capacity(r.Start, r.End)))
+		}
+
+		slots = append(slots, Slot{
+			Rate:       r,
+			SolarPower: min(slotMax, surplus(r.Start, r.End)),
+			maxPower:   slotMax,
+		})
+	}
+
+	plan := t.powerPlan(slots, requiredEnergy)
+	t.warnShortfall(plan, requiredEnergy)
+
+	return plan, nil
+}
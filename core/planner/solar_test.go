@@ -0,0 +1,141 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPowerPlan(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{20, 60, 10, 80}, clock.Now(), time.Hour), nil)
+
+	solar := api.NewMockTariff(ctrl)
+	solar.EXPECT().Rates().AnyTimes().Return(rates([]float64{0, 5000, 2000, 0}, clock.Now(), time.Hour), nil)
+
+	p := &Planner{
+		log:    util.NewLogger("foo"),
+		clock:  clock,
+		tariff: trf,
+		solar:  solar,
+	}
+
+	tc := []struct {
+		desc      string
+		energy    float64
+		power     []float64
+		solar     []float64
+		gridShare float64
+		cost      float64
+	}{
+		{"solar only", 4000, []float64{4000}, []float64{4000}, 0, 0},
+		{"all solar", 7000, []float64{5000, 2000}, []float64{5000, 2000}, 0, 0},
+		{"solar and cheapest grid", 14000, []float64{5000, 9000}, []float64{5000, 2000}, 0.5, 70},
+		{"solar and two grid slots", 23000, []float64{7000, 5000, 11000}, []float64{0, 5000, 2000}, float64(16000) / 23000, 90 + 140},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.desc, func(t *testing.T) {
+			plan, err := p.PowerPlan(tc.energy, 11000, clock.Now().Add(4*time.Hour), nil)
+			require.NoError(t, err)
+
+			var power, solar []float64
+			for _, s := range plan {
+				power = append(power, s.Power)
+				solar = append(solar, s.SolarPower)
+			}
+
+			assert.Equal(t, tc.power, power, "power")
+			assert.Equal(t, tc.solar, solar, "solar")
+			assert.InDelta(t, tc.energy, plan.Energy(), 1e-6, "energy")
+			assert.InDelta(t, tc.gridShare, plan.GridShare(), 1e-6, "grid share")
+			assert.InDelta(t, tc.cost, plan.Cost(), 1e-6, "cost")
+		})
+	}
+}
+
+func TestPowerPlanWithoutTariff(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	solar := api.NewMockTariff(ctrl)
+	solar.EXPECT().Rates().AnyTimes().Return(rates([]float64{1000, 5000, 2000}, clock.Now(), time.Hour), nil)
+
+	p := &Planner{
+		log:   util.NewLogger("foo"),
+		clock: clock,
+		solar: solar,
+	}
+
+	plan, err := p.PowerPlan(6000, 11000, clock.Now().Add(3*time.Hour), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, Plan{
+		{Rate: api.Rate{Start: clock.Now().Add(time.Hour), End: clock.Now().Add(2 * time.Hour)}, Power: 5000, SolarPower: 5000},
+		{Rate: api.Rate{Start: clock.Now().Add(2 * time.Hour), End: clock.Now().Add(3 * time.Hour)}, Power: 1000, SolarPower: 1000},
+	}, plan)
+	assert.Equal(t, 0.0, plan.GridShare())
+}
+
+func TestPowerPlanCapacity(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{20, 60, 10, 80}, clock.Now(), time.Hour), nil)
+
+	p := &Planner{
+		log:    util.NewLogger("foo"),
+		clock:  clock,
+		tariff: trf,
+	}
+
+	// capacity limited to 4kW during cheapest slot
+	capacity := func(start, end time.Time) float64 {
+		if start.Equal(clock.Now().Add(2 * time.Hour)) {
+			return 4000
+		}
+		return 11000
+	}
+
+	plan, err := p.PowerPlan(10000, 11000, clock.Now().Add(4*time.Hour), capacity)
+	require.NoError(t, err)
+
+	require.Len(t, plan, 2)
+	assert.Equal(t, 6000.0, plan[0].Power)
+	assert.Equal(t, 4000.0, plan[1].Power)
+	assert.InDelta(t, 6*20+4*10, plan.Cost(), 1e-6)
+}
+
+func TestPowerPlanBaseLoad(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	solar := api.NewMockTariff(ctrl)
+	solar.EXPECT().Rates().AnyTimes().Return(rates([]float64{1000, 5000, 2000}, clock.Now(), time.Hour), nil)
+
+	p := &Planner{
+		log:      util.NewLogger("foo"),
+		clock:    clock,
+		solar:    solar,
+		baseLoad: func() float64 { return 1000 },
+	}
+
+	// home consumption reduces the solar surplus
+	plan, err := p.PowerPlan(5000, 11000, clock.Now().Add(3*time.Hour), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, Plan{
+		{Rate: api.Rate{Start: clock.Now().Add(time.Hour), End: clock.Now().Add(2 * time.Hour)}, Power: 4000, SolarPower: 4000},
+		{Rate: api.Rate{Start: clock.Now().Add(2 * time.Hour), End: clock.Now().Add(3 * time.Hour)}, Power: 1000, SolarPower: 1000},
+	}, plan)
+}
@@ -3,6 +3,7 @@ package session
 import (
 	"github.com/evcc-io/evcc/util"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // DB is a SQL database storage service
@@ -14,7 +15,7 @@ type DB struct {
 
 // NewStore creates a session store
 func NewStore(name string, db *gorm.DB) (*DB, error) {
-	err := db.AutoMigrate(new(Session))
+	err := db.AutoMigrate(new(Session), new(Slot))
 
 	sessiondb := &DB{
 		log:  util.NewLogger("db"),
@@ -40,16 +41,46 @@ func (s *DB) New(meter float64) *Session {
 
 // Persist creates or updates a transaction in the database
 func (s *DB) Persist(session interface{}) {
-	if err := s.db.Save(session).Error; err != nil {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(session).Error; err != nil {
+			return err
+		}
+
+		// replace tariff slots unless not loaded
+		if t, ok := session.(*Session); ok && t.Slots != nil {
+			return t.replaceSlots(tx)
+		}
+
+		return nil
+	})
+	if err != nil {
 		s.log.ERROR.Printf("persist: %v", err)
 	}
 }
 
+// replaceSlots replaces the session's tariff slots
+func (t *Session) replaceSlots(tx *gorm.DB) error {
+	if err := tx.Where("session_id = ?", t.ID).Delete(new(Slot)).Error; err != nil {
+		return err
+	}
+
+	if len(t.Slots) == 0 {
+		return nil
+	}
+
+	for i := range t.Slots {
+		t.Slots[i].ID = 0
+		t.Slots[i].SessionID = t.ID
+	}
+
+	return tx.Create(&t.Slots).Error
+}
+
 // Return sessions
 // TODO make this part of server/db
 func (s *DB) Sessions() (Sessions, error) {
 	var res Sessions
-	tx := s.db.Find(&res)
+	tx := s.db.Preload("Slots").Find(&res)
 	return res, tx.Error
 }
 
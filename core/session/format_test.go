@@ -1,9 +1,15 @@
 package session
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
 	"testing"
 
+	"github.com/evcc-io/evcc/util/locale"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 )
@@ -15,3 +21,27 @@ func TestFormatValue(t *testing.T) {
 	assert.Equal(t, "1.234", formatValue(mp, f, 3))
 	assert.Equal(t, "1.234", formatValue(mp, &f, 3))
 }
+
+func TestSlotSessionsCsv(t *testing.T) {
+	price := 0.3
+	res := SlotSessions{
+		{Loadpoint: "lp", Slots: []Slot{{GridEnergy: 1, GridPrice: &price}, {SolarEnergy: 2}}},
+		{Loadpoint: "lp"},
+	}
+
+	// untranslated captions
+	locale.Bundle = i18n.NewBundle(language.English)
+	locale.Localizer = i18n.NewLocalizer(locale.Bundle, "en")
+
+	var b bytes.Buffer
+	ctx := context.WithValue(context.Background(), locale.Locale, "en")
+	require.NoError(t, res.WriteCsv(ctx, &b))
+
+	rows, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(b.Bytes(), []byte{0xEF, 0xBB, 0xBF}))).ReadAll()
+	require.NoError(t, err)
+
+	// header and one row per slot
+	require.Len(t, rows, 4)
+	assert.Contains(t, rows[0], "Grid (kWh)")
+	assert.Contains(t, rows[1], "0.3")
+}
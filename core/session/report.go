@@ -0,0 +1,208 @@
+package session
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/samber/lo"
+)
+
+// Grouping is the grouping of sessions in a report
+type Grouping string
+
+const (
+	GroupByMonth     Grouping = "month"
+	GroupByVehicle   Grouping = "vehicle"
+	GroupByLoadpoint Grouping = "loadpoint"
+)
+
+// ParseGrouping returns the grouping for given string, defaulting to month
+func ParseGrouping(s string) (Grouping, error) {
+	switch g := Grouping(s); g {
+	case "":
+		return GroupByMonth, nil
+	case GroupByMonth, GroupByVehicle, GroupByLoadpoint:
+		return g, nil
+	default:
+		return "", fmt.Errorf("invalid grouping: %s", s)
+	}
+}
+
+// key returns the session's group key
+func (g Grouping) key(s Session) string {
+	switch g {
+	case GroupByVehicle:
+		return s.Vehicle
+	case GroupByLoadpoint:
+		return s.Loadpoint
+	default:
+		return s.Created.Local().Format("2006-01")
+	}
+}
+
+// Filter selects sessions for reporting. Empty fields match all sessions.
+type Filter struct {
+	Vehicle    string    `json:"vehicle,omitempty"`
+	Loadpoint  string    `json:"loadpoint,omitempty"`
+	Identifier string    `json:"identifier,omitempty"`
+	From       time.Time `json:"from,omitzero"`
+	To         time.Time `json:"to,omitzero"` // exclusive
+}
+
+// Match returns true if the session matches the filter
+func (f Filter) Match(s Session) bool {
+	return (f.Vehicle == "" || s.Vehicle == f.Vehicle) &&
+		(f.Loadpoint == "" || s.Loadpoint == f.Loadpoint) &&
+		(f.Identifier == "" || s.Identifier == f.Identifier) &&
+		(f.From.IsZero() || !s.Created.Before(f.From)) &&
+		(f.To.IsZero() || s.Created.Before(f.To))
+}
+
+// Totals are the aggregated values of a list of sessions
+type Totals struct {
+	Count           int           `json:"count"`
+	ChargedEnergy   float64       `json:"chargedEnergy"`
+	ChargeDuration  time.Duration `json:"chargeDuration"`
+	SolarPercentage *float64      `json:"solarPercentage"`
+	Price           *float64      `json:"price"`
+	PricePerKWh     *float64      `json:"pricePerKWh"`
+	Co2PerKWh       *float64      `json:"co2PerKWh"`
+}
+
+// totals aggregates the sessions. Percentages and specific values are weighted by charged energy.
+func totals(sessions Sessions) Totals {
+	var (
+		res                        Totals
+		solar, solarEnergy         float64
+		price, priceEnergy         float64
+		co2, co2Energy             float64
+		hasSolar, hasPrice, hasCo2 bool
+	)
+
+	for _, s := range sessions {
+		res.Count++
+		res.ChargedEnergy += s.ChargedEnergy
+
+		if s.ChargeDuration != nil {
+			res.ChargeDuration += *s.ChargeDuration
+		}
+
+		if s.SolarPercentage != nil {
+			solar += s.ChargedEnergy * *s.SolarPercentage / 100
+			solarEnergy += s.ChargedEnergy
+			hasSolar = true
+		}
+
+		if s.Price != nil {
+			price += *s.Price
+			priceEnergy += s.ChargedEnergy
+			hasPrice = true
+		}
+
+		if s.Co2PerKWh != nil {
+			co2 += s.ChargedEnergy * *s.Co2PerKWh
+			co2Energy += s.ChargedEnergy
+			hasCo2 = true
+		}
+	}
+
+	if hasSolar && solarEnergy > 0 {
+		res.SolarPercentage = lo.ToPtr(100 * solar / solarEnergy)
+	}
+
+	if hasPrice {
+		res.Price = &price
+		if priceEnergy > 0 {
+			res.PricePerKWh = lo.ToPtr(price / priceEnergy)
+		}
+	}
+
+	if hasCo2 && co2Energy > 0 {
+		res.Co2PerKWh = lo.ToPtr(co2 / co2Energy)
+	}
+
+	return res
+}
+
+// reportKey is the report localization key prefix
+const reportKey = "sessions.report."
+
+// totalsHeader returns the localized summary column captions
+func (r *Report) totalsHeader(localizer *i18n.Localizer) []any {
+	return []any{
+		localize(localizer, reportKey+string(r.GroupBy), string(r.GroupBy)),
+		localize(localizer, reportKey+"count", "Count"),
+		localize(localizer, sessionKey+"chargedenergy", "Charged Energy (kWh)"),
+		localize(localizer, sessionKey+"chargeduration", "Charge Duration"),
+		localize(localizer, sessionKey+"solarpercentage", "Solar (%)"),
+		localize(localizer, sessionKey+"price", "Price"),
+		localize(localizer, sessionKey+"priceperkwh", "Price/kWh"),
+		localize(localizer, sessionKey+"co2perkwh", "CO2/kWh (gCO2eq)"),
+	}
+}
+
+// row returns the summary row values for the totals
+func (t Totals) row(key string) []any {
+	return []any{key, t.Count, t.ChargedEnergy, t.ChargeDuration, t.SolarPercentage, t.Price, t.PricePerKWh, t.Co2PerKWh}
+}
+
+// Group is a group of sessions with totals
+type Group struct {
+	Key string `json:"key"`
+	Totals
+	Sessions Sessions `json:"sessions,omitempty"`
+}
+
+// Report is a filtered and grouped list of sessions with totals
+type Report struct {
+	Filter  Filter   `json:"filter"`
+	GroupBy Grouping `json:"groupBy"`
+	Groups  []Group  `json:"groups"`
+	Total   Totals   `json:"total"`
+}
+
+// NewReport filters and groups the sessions. Groups and sessions are sorted ascending.
+func NewReport(sessions Sessions, filter Filter, groupBy Grouping) *Report {
+	res := &Report{
+		Filter:  filter,
+		GroupBy: groupBy,
+		Groups:  make([]Group, 0),
+	}
+
+	var matched Sessions
+	groups := make(map[string]Sessions)
+
+	for _, s := range sessions {
+		if !filter.Match(s) {
+			continue
+		}
+
+		matched = append(matched, s)
+
+		key := groupBy.key(s)
+		groups[key] = append(groups[key], s)
+	}
+
+	for key, sessions := range groups {
+		slices.SortFunc(sessions, func(a, b Session) int {
+			return a.Created.Compare(b.Created)
+		})
+
+		res.Groups = append(res.Groups, Group{
+			Key:      key,
+			Totals:   totals(sessions),
+			Sessions: sessions,
+		})
+	}
+
+	slices.SortFunc(res.Groups, func(a, b Group) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+
+	res.Total = totals(matched)
+
+	return res
+}
@@ -0,0 +1,109 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util/locale"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/language"
+)
+
+func reportSessions() Sessions {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 12, 0, 0, 0, time.Local)
+	}
+
+	return Sessions{
+		{Created: date(2, 1), Vehicle: "blue", Loadpoint: "garage", Identifier: "tag", ChargedEnergy: 10, SolarPercentage: lo.ToPtr(100.0), Price: lo.ToPtr(1.0)},
+		{Created: date(1, 15), Vehicle: "red", Loadpoint: "carport", ChargedEnergy: 30, SolarPercentage: lo.ToPtr(0.0), Price: lo.ToPtr(9.0)},
+		{Created: date(1, 1), Vehicle: "blue", Loadpoint: "garage", ChargedEnergy: 10, Price: lo.ToPtr(3.0)},
+	}
+}
+
+func TestReportGrouping(t *testing.T) {
+	g, err := ParseGrouping("")
+	require.NoError(t, err)
+	assert.Equal(t, GroupByMonth, g)
+
+	_, err = ParseGrouping("foo")
+	assert.Error(t, err)
+
+	res := NewReport(reportSessions(), Filter{}, GroupByMonth)
+	require.Len(t, res.Groups, 2)
+	assert.Equal(t, "2024-01", res.Groups[0].Key)
+	assert.Equal(t, "2024-02", res.Groups[1].Key)
+
+	// sessions sorted ascending
+	jan := res.Groups[0]
+	assert.True(t, jan.Sessions[0].Created.Before(jan.Sessions[1].Created))
+
+	assert.Equal(t, 2, jan.Count)
+	assert.Equal(t, 40.0, jan.ChargedEnergy)
+	assert.Equal(t, 12.0, *jan.Price)
+	assert.Equal(t, 0.3, *jan.PricePerKWh)
+	assert.Equal(t, 0.0, *jan.SolarPercentage)
+	assert.Nil(t, jan.Co2PerKWh)
+
+	assert.Equal(t, 3, res.Total.Count)
+	assert.Equal(t, 50.0, res.Total.ChargedEnergy)
+	assert.Equal(t, 25.0, *res.Total.SolarPercentage) // weighted by energy
+
+	res = NewReport(reportSessions(), Filter{}, GroupByVehicle)
+	require.Len(t, res.Groups, 2)
+	assert.Equal(t, "blue", res.Groups[0].Key)
+	assert.Equal(t, 2, res.Groups[0].Count)
+}
+
+func TestReportFilter(t *testing.T) {
+	res := NewReport(reportSessions(), Filter{Vehicle: "blue"}, GroupByLoadpoint)
+	assert.Equal(t, 2, res.Total.Count)
+
+	res = NewReport(reportSessions(), Filter{Identifier: "tag"}, GroupByMonth)
+	assert.Equal(t, 1, res.Total.Count)
+
+	res = NewReport(reportSessions(), Filter{
+		From: time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local),
+		To:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local),
+	}, GroupByMonth)
+	require.Len(t, res.Groups, 1)
+	assert.Equal(t, "red", res.Groups[0].Sessions[0].Vehicle)
+
+	res = NewReport(reportSessions(), Filter{Loadpoint: "none"}, GroupByMonth)
+	assert.Empty(t, res.Groups)
+	assert.Equal(t, 0, res.Total.Count)
+}
+
+func TestReportExport(t *testing.T) {
+	// untranslated captions
+	locale.Bundle = i18n.NewBundle(language.English)
+	locale.Localizer = i18n.NewLocalizer(locale.Bundle, "en")
+
+	ctx := context.WithValue(context.Background(), locale.Locale, "en")
+	res := NewReport(reportSessions(), Filter{}, GroupByMonth)
+
+	var b bytes.Buffer
+	require.NoError(t, res.WriteXlsx(ctx, &b))
+
+	f, err := excelize.OpenReader(&b)
+	require.NoError(t, err)
+	defer f.Close()
+
+	assert.Equal(t, []string{"Summary", "Sessions"}, f.GetSheetList())
+
+	rows, err := f.GetRows("Summary")
+	require.NoError(t, err)
+	require.Len(t, rows, 4) // header, 2 months, total
+	assert.Equal(t, []string{"2024-01", "2"}, rows[1][:2])
+	assert.Equal(t, "Total", rows[3][0])
+
+	rows, err = f.GetRows("Sessions")
+	require.NoError(t, err)
+	assert.Len(t, rows, 4) // header, 3 sessions
+}
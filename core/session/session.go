@@ -20,53 +20,88 @@ import (
 
 // Session is a single charging session
 type Session struct {
-	ID              uint           `json:"id" csv:"-" gorm:"primarykey"`
-	Created         time.Time      `json:"created"`
-	Finished        time.Time      `json:"finished"`
-	Loadpoint       string         `json:"loadpoint"`
-	Identifier      string         `json:"identifier"`
-	Vehicle         string         `json:"vehicle"`
-	Odometer        *float64       `json:"odometer" format:"int"`
-	MeterStart      *float64       `json:"meterStart" csv:"Meter Start (kWh)" gorm:"column:meter_start_kwh"`
-	MeterStop       *float64       `json:"meterStop" csv:"Meter Stop (kWh)" gorm:"column:meter_end_kwh"`
-	ChargedEnergy   float64        `json:"chargedEnergy" csv:"Charged Energy (kWh)" gorm:"column:charged_kwh"`
-	ChargeDuration  *time.Duration `json:"chargeDuration" csv:"Charge Duration" gorm:"column:charge_duration"`
-	SolarPercentage *float64       `json:"solarPercentage" csv:"Solar (%)" gorm:"column:solar_percentage"`
-	Price           *float64       `json:"price" csv:"Price" gorm:"column:price"`
-	PricePerKWh     *float64       `json:"pricePerKWh" csv:"Price/kWh" gorm:"column:price_per_kwh"`
-	Co2PerKWh       *float64       `json:"co2PerKWh" csv:"CO2/kWh (gCO2eq)" gorm:"column:co2_per_kwh"`
+	ID               uint           `json:"id" csv:"-" gorm:"primarykey"`
+	Created          time.Time      `json:"created"`
+	Finished         time.Time      `json:"finished"`
+	Loadpoint        string         `json:"loadpoint"`
+	Identifier       string         `json:"identifier"`
+	Vehicle          string         `json:"vehicle"`
+	Odometer         *float64       `json:"odometer" format:"int"`
+	MeterStart       *float64       `json:"meterStart" csv:"Meter Start (kWh)" gorm:"column:meter_start_kwh"`
+	MeterStop        *float64       `json:"meterStop" csv:"Meter Stop (kWh)" gorm:"column:meter_end_kwh"`
+	ChargedEnergy    float64        `json:"chargedEnergy" csv:"Charged Energy (kWh)" gorm:"column:charged_kwh"`
+	ChargeDuration   *time.Duration `json:"chargeDuration" csv:"Charge Duration" gorm:"column:charge_duration"`
+	SolarPercentage  *float64       `json:"solarPercentage" csv:"Solar (%)" gorm:"column:solar_percentage"`
+	Price            *float64       `json:"price" csv:"Price" gorm:"column:price"`
+	PricePerKWh      *float64       `json:"pricePerKWh" csv:"Price/kWh" gorm:"column:price_per_kwh"`
+	Co2PerKWh        *float64       `json:"co2PerKWh" csv:"CO2/kWh (gCO2eq)" gorm:"column:co2_per_kwh"`
+	SignedMeterStart string         `json:"signedMeterStart" csv:"Signed Meter Start" gorm:"column:signed_meter_start"`
+	SignedMeterStop  string         `json:"signedMeterStop" csv:"Signed Meter Stop" gorm:"column:signed_meter_stop"`
+	Slots            []Slot         `json:"slots" csv:"-" gorm:"foreignKey:SessionID"`
 }
 
+// csv column localization key prefixes
+const (
+	sessionKey = "sessions.csv."
+	slotKey    = "sessions.csv.slot."
+)
+
 // Sessions is a list of sessions
 type Sessions []Session
 
 var _ api.CsvWriter = (*Sessions)(nil)
 
-func (t *Sessions) writeHeader(ctx context.Context, ww *csv.Writer) error {
-	localizer := locale.Localizer
-	if val := ctx.Value(locale.Locale).(string); val != "" {
-		localizer = i18n.NewLocalizer(locale.Bundle, val, locale.Language)
-	}
+// csvField is an exported csv field with its localization key
+type csvField struct {
+	*structs.Field
+	key string
+}
 
-	var row []string
-	for _, f := range structs.Fields(Session{}) {
-		csv := f.Tag("csv")
-		if csv == "-" {
-			continue
+// csvFields returns the exported csv fields of the given struct
+func csvFields(prefix string, v any) []csvField {
+	var res []csvField
+	for _, f := range structs.Fields(v) {
+		if f.Tag("csv") != "-" {
+			res = append(res, csvField{f, prefix + strings.ToLower(f.Name())})
 		}
+	}
+	return res
+}
 
-		caption, err := localizer.Localize(&locale.Config{
-			MessageID: "sessions.csv." + strings.ToLower(f.Name()),
-		})
-		if err != nil {
-			if csv != "" {
-				caption = csv
-			} else {
-				caption = f.Name()
-			}
-		}
+// newLocalizer returns the localizer for the context's locale
+func newLocalizer(ctx context.Context) *i18n.Localizer {
+	if val, ok := ctx.Value(locale.Locale).(string); ok && val != "" {
+		return i18n.NewLocalizer(locale.Bundle, val, locale.Language)
+	}
+	return locale.Localizer
+}
+
+// localize returns the localized message or the fallback if not available
+func localize(localizer *i18n.Localizer, key, fallback string) string {
+	res, err := localizer.Localize(&locale.Config{
+		MessageID: key,
+	})
+	if err != nil {
+		return fallback
+	}
+	return res
+}
+
+// caption returns the localized column caption of the field
+func (f csvField) caption(localizer *i18n.Localizer) string {
+	fallback := f.Tag("csv")
+	if fallback == "" {
+		fallback = f.Name()
+	}
+	return localize(localizer, f.key, fallback)
+}
 
-		row = append(row, caption)
+func writeHeader(ctx context.Context, ww *csv.Writer, fields []csvField) error {
+	localizer := newLocalizer(ctx)
+
+	var row []string
+	for _, f := range fields {
+		row = append(row, f.caption(localizer))
 	}
 
 	return ww.Write(row)
@@ -92,13 +127,9 @@ func formatValue(mp *message.Printer, value any, digits int) string {
 	}
 }
 
-func (t *Sessions) writeRow(ww *csv.Writer, mp *message.Printer, r Session) error {
+func writeRow(ww *csv.Writer, mp *message.Printer, fields []csvField) error {
 	var row []string
-	for _, f := range structs.Fields(r) {
-		if f.Tag("csv") == "-" {
-			continue
-		}
-
+	for _, f := range fields {
 		digits := 3
 		if format := f.Tag("format"); format == "int" {
 			digits = 0
@@ -112,21 +143,25 @@ func (t *Sessions) writeRow(ww *csv.Writer, mp *message.Printer, r Session) erro
 	return ww.Write(row)
 }
 
-// WriteCsv implements the api.CsvWriter interface
-func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
-	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
-		return err
-	}
-
-	// get context language
+// contextLanguage returns the language tag of the context's locale
+func contextLanguage(ctx context.Context) (language.Tag, error) {
 	lang := locale.Language
 	if language, ok := ctx.Value(locale.Locale).(string); ok && language != "" {
 		lang = language
 	}
 
-	tag, err := language.Parse(lang)
+	return language.Parse(lang)
+}
+
+// newCsvWriter returns a csv writer and message printer for the context's locale
+func newCsvWriter(ctx context.Context, w io.Writer) (*csv.Writer, *message.Printer, error) {
+	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+		return nil, nil, err
+	}
+
+	tag, err := contextLanguage(ctx)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	ww := csv.NewWriter(w)
@@ -136,13 +171,22 @@ func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
 		ww.Comma = ';'
 	}
 
-	if err := t.writeHeader(ctx, ww); err != nil {
+	return ww, message.NewPrinter(tag), nil
+}
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
+	ww, mp, err := newCsvWriter(ctx, w)
+	if err != nil {
+		return err
+	}
+
+	if err := writeHeader(ctx, ww, csvFields(sessionKey, Session{})); err != nil {
 		return err
 	}
 
-	mp := message.NewPrinter(tag)
 	for _, r := range *t {
-		if err := t.writeRow(ww, mp, r); err != nil {
+		if err := writeRow(ww, mp, csvFields(sessionKey, r)); err != nil {
 			return err
 		}
 	}
@@ -151,3 +195,38 @@ func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
 
 	return ww.Error()
 }
+
+// SlotSessions is a list of sessions exported with one row per tariff slot
+type SlotSessions Sessions
+
+var _ api.CsvWriter = (*SlotSessions)(nil)
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *SlotSessions) WriteCsv(ctx context.Context, w io.Writer) error {
+	ww, mp, err := newCsvWriter(ctx, w)
+	if err != nil {
+		return err
+	}
+
+	if err := writeHeader(ctx, ww, append(csvFields(sessionKey, Session{}), csvFields(slotKey, Slot{})...)); err != nil {
+		return err
+	}
+
+	for _, r := range *t {
+		slots := r.Slots
+		if len(slots) == 0 {
+			// sessions without slot accounting
+			slots = []Slot{{}}
+		}
+
+		for _, slot := range slots {
+			if err := writeRow(ww, mp, append(csvFields(sessionKey, r), csvFields(slotKey, slot)...)); err != nil {
+				return err
+			}
+		}
+	}
+
+	ww.Flush()
+
+	return ww.Error()
+}
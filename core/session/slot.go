@@ -0,0 +1,22 @@
+package session
+
+import "time"
+
+// Slot is the energy charged during a single tariff slot of a session, split by energy source.
+// Cost and CO2 are stored per source. Solar and battery energy are valued at the feed-in price
+// and are emission free.
+type Slot struct {
+	ID            uint      `json:"-" csv:"-" gorm:"primarykey"`
+	SessionID     uint      `json:"-" csv:"-" gorm:"index"`
+	Start         time.Time `json:"start" csv:"Slot Start"`
+	End           time.Time `json:"end" csv:"Slot End"`
+	GridEnergy    float64   `json:"gridEnergy" csv:"Grid (kWh)" gorm:"column:grid_kwh"`
+	SolarEnergy   float64   `json:"solarEnergy" csv:"Solar (kWh)" gorm:"column:solar_kwh"`
+	BatteryEnergy float64   `json:"batteryEnergy" csv:"Battery (kWh)" gorm:"column:battery_kwh"`
+	GridPrice     *float64  `json:"gridPrice" csv:"Grid Price/kWh" gorm:"column:grid_price_per_kwh"`
+	FeedInPrice   *float64  `json:"feedInPrice" csv:"Feed-in Price/kWh" gorm:"column:feedin_price_per_kwh"`
+	GridCost      *float64  `json:"gridCost" csv:"Grid Cost" gorm:"column:grid_cost"`
+	SolarCost     *float64  `json:"solarCost" csv:"Solar Cost" gorm:"column:solar_cost"`
+	BatteryCost   *float64  `json:"batteryCost" csv:"Battery Cost" gorm:"column:battery_cost"`
+	GridCo2       *float64  `json:"gridCo2" csv:"Grid CO2 (gCO2eq)" gorm:"column:grid_co2"`
+}
@@ -0,0 +1,139 @@
+package session
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxSheet is a spreadsheet worksheet. The first row is the header row.
+type xlsxSheet struct {
+	name string
+	rows [][]any
+}
+
+// xlsxValue returns the cell value, dereferencing pointers. Times are converted to local wall clock
+// since spreadsheets have no time zone.
+func xlsxValue(value any) any {
+	if rv := reflect.ValueOf(value); !rv.IsValid() || rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	} else if rv.Kind() == reflect.Pointer {
+		value = rv.Elem().Interface()
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		t := v.Local()
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	case time.Duration:
+		return v.String()
+	}
+
+	return value
+}
+
+// writeXlsx writes the sheets as Office Open XML spreadsheet
+func writeXlsx(w io.Writer, sheets ...xlsxSheet) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	header, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+
+	date, err := f.NewStyle(&excelize.Style{CustomNumFmt: lo.ToPtr("yyyy-mm-dd hh:mm")})
+	if err != nil {
+		return err
+	}
+
+	for i, sheet := range sheets {
+		if i == 0 {
+			err = f.SetSheetName(f.GetSheetName(0), sheet.name)
+		} else {
+			_, err = f.NewSheet(sheet.name)
+		}
+		if err != nil {
+			return err
+		}
+
+		for r, row := range sheet.rows {
+			for c, value := range row {
+				cell, err := excelize.CoordinatesToCellName(c+1, r+1)
+				if err != nil {
+					return err
+				}
+
+				value = xlsxValue(value)
+				if value == nil {
+					continue
+				}
+
+				if err := f.SetCellValue(sheet.name, cell, value); err != nil {
+					return err
+				}
+
+				style := 0
+				if r == 0 {
+					style = header
+				} else if _, ok := value.(time.Time); ok {
+					style = date
+				}
+
+				if style != 0 {
+					if err := f.SetCellStyle(sheet.name, cell, cell, style); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return f.Write(w)
+}
+
+// WriteXlsx writes the report as spreadsheet with summary and sessions sheets
+func (r *Report) WriteXlsx(ctx context.Context, w io.Writer) error {
+	localizer := newLocalizer(ctx)
+
+	summary := xlsxSheet{
+		name: localize(localizer, reportKey+"summary", "Summary"),
+		rows: [][]any{r.totalsHeader(localizer)},
+	}
+
+	for _, g := range r.Groups {
+		summary.rows = append(summary.rows, g.Totals.row(g.Key))
+	}
+	summary.rows = append(summary.rows, r.Total.row(localize(localizer, reportKey+"total", "Total")))
+
+	fields := csvFields(sessionKey, Session{})
+
+	var header []any
+	for _, f := range fields {
+		header = append(header, f.caption(localizer))
+	}
+
+	sessions := xlsxSheet{
+		name: localize(localizer, reportKey+"sessions", "Sessions"),
+		rows: [][]any{header},
+	}
+
+	for _, g := range r.Groups {
+		for _, s := range g.Sessions {
+			var row []any
+			for _, f := range csvFields(sessionKey, s) {
+				row = append(row, f.Value())
+			}
+			sessions.rows = append(sessions.rows, row)
+		}
+	}
+
+	return writeXlsx(w, summary, sessions)
+}
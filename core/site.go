@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -12,8 +13,10 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/cmd/shutdown"
+	"github.com/evcc-io/evcc/core/battery"
 	"github.com/evcc-io/evcc/core/circuit"
 	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/core/history"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/planner"
@@ -34,12 +37,16 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-const standbyPower = 10 // consider less than 10W as charger in standby
+const (
+	standbyPower      = 10   // consider less than 10W as charger in standby
+	homeBaseLoadDecay = 0.01 // weight of current home power in base load average, ~1h at 30s interval
+)
 
 // updater abstracts the Loadpoint implementation for testing
 type updater interface {
 	loadpoint.API
 	Update(sitePower, batteryBoostPower float64, rates api.Rates, batteryBuffered, batteryStart bool, greenShare float64, effectivePrice, effectiveCo2 *float64)
+	setTariffSlot(slot tariffSlot)
 }
 
 // measurement is used as slice element for publishing structured data
@@ -75,6 +82,10 @@ type Site struct {
 	CircuitRef_                        string  `mapstructure:"circuit"`                           // Circuit reference
 	MaxGridSupplyWhileBatteryCharging_ float64 `mapstructure:"maxGridSupplyWhileBatteryCharging"` // ignore battery charging if AC consumption is above this value
 
+	BatteryScheduler battery.Config `mapstructure:"batteryScheduler"` // Battery scheduler
+	MainFuse         MainFuseConfig `mapstructure:"mainFuse"`         // Main fuse protection
+	History          history.Config `mapstructure:"history"`          // Energy history
+
 	// meters
 	circuit       api.Circuit // Circuit
 	gridMeter     api.Meter   // Grid usage meter
@@ -84,26 +95,37 @@ type Site struct {
 	auxMeters     []api.Meter // Auxiliary meters
 
 	// battery settings
-	prioritySoc             float64  // prefer battery up to this Soc
-	bufferSoc               float64  // continue charging on battery above this Soc
-	bufferStartSoc          float64  // start charging on battery above this Soc
-	batteryDischargeControl bool     // prevent battery discharge for fast and planned charging
-	batteryGridChargeLimit  *float64 // grid charging limit
+	prioritySoc               float64  // prefer battery up to this Soc
+	bufferSoc                 float64  // continue charging on battery above this Soc
+	bufferStartSoc            float64  // start charging on battery above this Soc
+	batteryDischargeControl   bool     // prevent battery discharge for fast and planned charging
+	batteryGridChargeLimit    *float64 // grid charging limit
+	batteryGridDischargeLimit *float64 // grid discharging limit
+	batteryDischargePower     float64  // forced discharge power
+	batteryMaxChargePower     float64  // maximum charge power
+
+	batteryScheduler *battery.Scheduler // Battery scheduler
 
 	loadpoints  []*Loadpoint             // Loadpoints
 	tariffs     *tariff.Tariffs          // Tariffs
 	coordinator *coordinator.Coordinator // Vehicles
 	prioritizer *prioritizer.Prioritizer // Power budgets
 	stats       *Stats                   // Stats
+	history     *history.Store           // Energy history
 
 	// cached state
-	gridPower     float64         // Grid power
-	pvPower       float64         // PV power
-	excessDCPower float64         // PV excess DC charge power (hybrid only)
-	auxPower      float64         // Aux power
-	batteryPower  float64         // Battery power (charge negative, discharge positive)
-	batterySoc    float64         // Battery soc
-	batteryMode   api.BatteryMode // Battery mode (runtime only, not persisted)
+	gridPower        float64         // Grid power
+	pvPower          float64         // PV power
+	excessDCPower    float64         // PV excess DC charge power (hybrid only)
+	auxPower         float64         // Aux power
+	batteryPower     float64         // Battery power (charge negative, discharge positive)
+	batterySoc       float64         // Battery soc
+	batteryCapacity  float64         // Battery capacity
+	batteryPlan      battery.Plan    // Battery plan
+	homePower        float64         // Home power
+	homeBaseLoad     *float64        // Average home power, expected consumption for solar planning
+	batteryMode      api.BatteryMode // Battery mode (runtime only, not persisted)
+	batteryModePower float64         // Battery mode power setpoint (runtime only, not persisted)
 }
 
 // MetersConfig contains the site's meter configuration
@@ -157,6 +179,12 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 	for _, lp := range loadpoints {
 		lp.coordinator = coordinator.NewAdapter(lp, site.coordinator)
 		lp.planner = planner.New(lp.log, tariff)
+		if lp.PlanStrategy == loadpoint.PlanStrategySolar {
+			lp.planner = planner.New(lp.log, tariff,
+				planner.WithSolarForecast(site.GetTariff(api.TariffUsageSolar)),
+				planner.WithBaseLoad(site.getHomeBaseLoad),
+			)
+		}
 
 		if db.Instance != nil {
 			var err error
@@ -173,6 +201,16 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 		}
 	}
 
+	// energy history
+	if db.Instance != nil {
+		var err error
+		if site.history, err = history.NewStore(db.Instance, site.History); err != nil {
+			return err
+		}
+
+		shutdown.Register(site.history.Persist)
+	}
+
 	// circuit
 	if c := circuit.Root(); c != nil {
 		site.circuit = c
@@ -223,6 +261,20 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 		site.auxMeters = append(site.auxMeters, dev.Instance())
 	}
 
+	// main fuse protection
+	if err := site.configureMainFuse(); err != nil {
+		return err
+	}
+
+	// battery scheduler
+	if site.BatteryScheduler != (battery.Config{}) {
+		s, err := battery.New(site.log, site.BatteryScheduler)
+		if err != nil {
+			return fmt.Errorf("battery scheduler: %w", err)
+		}
+		site.batteryScheduler = s
+	}
+
 	if site.MaxGridSupplyWhileBatteryCharging_ != 0 {
 		site.log.WARN.Println("`MaxGridSupplyWhileBatteryCharging` is deprecated- use `maxACPower` in pv configuration instead")
 	}
@@ -230,7 +282,7 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 	// revert battery mode on shutdown
 	shutdown.Register(func() {
 		if mode := site.GetBatteryMode(); batteryModeModified(mode) {
-			if err := site.applyBatteryMode(api.BatteryNormal); err != nil {
+			if err := site.applyBatteryMode(api.BatteryNormal, 0); err != nil {
 				site.log.ERROR.Println("battery mode:", err)
 			}
 		}
@@ -308,6 +360,19 @@ func (site *Site) restoreSettings() error {
 	if v, err := settings.Float(keys.BatteryGridChargeLimit); err == nil {
 		site.SetBatteryGridChargeLimit(&v)
 	}
+	if v, err := settings.Float(keys.BatteryGridDischargeLimit); err == nil {
+		site.SetBatteryGridDischargeLimit(&v)
+	}
+	if v, err := settings.Float(keys.BatteryDischargePower); err == nil {
+		if err := site.SetBatteryDischargePower(v); err != nil {
+			return err
+		}
+	}
+	if v, err := settings.Float(keys.BatteryMaxChargePower); err == nil {
+		if err := site.SetBatteryMaxChargePower(v); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -498,6 +563,8 @@ func (site *Site) updatePvMeters() {
 		site.log.DEBUG.Printf("pv power: %.0fW"+excessStr, site.pvPower)
 	}
 
+	site.recordHistory(history.PV, mm)
+
 	site.publish(keys.PvPower, site.pvPower)
 	site.publish(keys.PvEnergy, totalEnergy)
 	site.publish(keys.Pv, mm)
@@ -547,6 +614,8 @@ func (site *Site) updateBatteryMeters() {
 		return *m.Capacity
 	})
 
+	site.batteryCapacity = totalCapacity
+
 	// convert weighed socs to total soc
 	if totalCapacity == 0 {
 		totalCapacity = float64(len(site.batteryMeters))
@@ -565,6 +634,8 @@ func (site *Site) updateBatteryMeters() {
 		site.log.DEBUG.Printf("battery soc: %.0f%%", math.Round(site.batterySoc))
 	}
 
+	site.recordHistory(history.Battery, mm)
+
 	site.publish(keys.BatteryCapacity, totalCapacity)
 	site.publish(keys.BatterySoc, site.batterySoc)
 
@@ -588,6 +659,8 @@ func (site *Site) updateAuxMeters() {
 		site.log.DEBUG.Printf("aux power: %.0fW", site.auxPower)
 	}
 
+	site.recordHistory(history.Aux, mm)
+
 	site.publish(keys.AuxPower, site.auxPower)
 	site.publish(keys.Aux, mm)
 }
@@ -640,6 +713,11 @@ func (site *Site) updateGridMeter() error {
 		}
 	}
 
+	// unbalanced load limit of the root circuit without meter is based on grid phase currents
+	if c, ok := site.circuit.(*circuit.Circuit); ok && !c.HasMeter() {
+		c.SetGridCurrents(mm.Currents)
+	}
+
 	// grid energy (import)
 	if energyMeter, ok := site.gridMeter.(api.MeterEnergy); ok {
 		if f, err := energyMeter.TotalEnergy(); err == nil {
@@ -649,11 +727,28 @@ func (site *Site) updateGridMeter() error {
 		}
 	}
 
+	site.recordHistory(history.Grid, []measurement{mm})
+
 	site.publish(keys.Grid, mm)
 
 	return nil
 }
 
+// recordHistory adds the meter group's measurements to the energy history.
+// Meter totals are only used if available for all meters of the group.
+func (site *Site) recordHistory(meter string, mm []measurement) {
+	if site.history == nil {
+		return
+	}
+
+	var total float64
+	if lo.EveryBy(mm, func(m measurement) bool { return m.Energy > 0 }) {
+		total = lo.SumBy(mm, func(m measurement) float64 { return m.Energy })
+	}
+
+	site.history.Add(meter, "", lo.SumBy(mm, func(m measurement) float64 { return m.Power }), total)
+}
+
 func (site *Site) updateMeters() error {
 	var eg errgroup.Group
 
@@ -738,7 +833,16 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 //   - the current green share, calculated for the part of the consumption between powerFrom and powerTo
 //     the consumption below powerFrom will get the available green power first
 func (site *Site) greenShare(powerFrom float64, powerTo float64) float64 {
-	greenPower := math.Max(0, site.pvPower) + math.Max(0, site.batteryPower)
+	return powerShare(math.Max(0, site.pvPower)+math.Max(0, site.batteryPower), powerFrom, powerTo)
+}
+
+// solarShare returns the current solar share like greenShare. Solar power is consumed before battery power.
+func (site *Site) solarShare(powerFrom float64, powerTo float64) float64 {
+	return powerShare(math.Max(0, site.pvPower), powerFrom, powerTo)
+}
+
+// powerShare returns the share of green power for the consumption between powerFrom and powerTo
+func powerShare(greenPower, powerFrom, powerTo float64) float64 {
 	greenPowerAvailable := math.Max(0, greenPower-powerFrom)
 
 	power := powerTo - powerFrom
@@ -755,54 +859,57 @@ func (site *Site) greenShare(powerFrom float64, powerTo float64) float64 {
 	return share
 }
 
-// effectivePrice calculates the real energy price based on self-produced and grid-imported energy.
-func (site *Site) effectivePrice(greenShare float64) *float64 {
-	if grid, err := tariff.Now(site.GetTariff(api.TariffUsageGrid)); err == nil {
-		feedin, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn))
-		if err != nil {
-			feedin = 0
+// tariffSlot returns the current grid tariff slot. It is determined once per cycle for
+// session accounting, effective prices and publishing.
+func (site *Site) tariffSlot(solarShare float64) tariffSlot {
+	res := tariffSlot{SolarShare: solarShare}
+
+	if t := site.GetTariff(api.TariffUsageGrid); t != nil {
+		if rr, err := t.Rates(); err == nil {
+			if r, err := rr.Current(time.Now()); err == nil {
+				res.Start, res.End = r.Start, r.End
+				res.GridPrice = &r.Price
+			}
 		}
-		effPrice := grid*(1-greenShare) + feedin*greenShare
-		return &effPrice
 	}
-	return nil
-}
 
-// effectiveCo2 calculates the amount of emitted co2 based on self-produced and grid-imported energy.
-func (site *Site) effectiveCo2(greenShare float64) *float64 {
-	if co2, err := tariff.Now(site.GetTariff(api.TariffUsageCo2)); err == nil {
-		effCo2 := co2 * (1 - greenShare)
-		return &effCo2
+	if v, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn)); err == nil {
+		res.FeedInPrice = &v
 	}
-	return nil
+
+	if v, err := tariff.Now(site.GetTariff(api.TariffUsageCo2)); err == nil {
+		res.GridCo2 = &v
+	}
+
+	return res
 }
 
-func (site *Site) publishTariffs(greenShareHome float64, greenShareLoadpoints float64) {
+func (site *Site) publishTariffs(slot tariffSlot, greenShareHome float64, greenShareLoadpoints float64) {
 	site.publish(keys.GreenShareHome, greenShareHome)
 	site.publish(keys.GreenShareLoadpoints, greenShareLoadpoints)
 
-	if v, err := tariff.Now(site.GetTariff(api.TariffUsageGrid)); err == nil {
-		site.publish(keys.TariffGrid, v)
+	if slot.GridPrice != nil {
+		site.publish(keys.TariffGrid, *slot.GridPrice)
 	}
-	if v, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn)); err == nil {
-		site.publish(keys.TariffFeedIn, v)
+	if slot.FeedInPrice != nil {
+		site.publish(keys.TariffFeedIn, *slot.FeedInPrice)
 	}
-	if v, err := tariff.Now(site.GetTariff(api.TariffUsageCo2)); err == nil {
-		site.publish(keys.TariffCo2, v)
+	if slot.GridCo2 != nil {
+		site.publish(keys.TariffCo2, *slot.GridCo2)
 	}
 	if v, err := tariff.Now(site.GetTariff(api.TariffUsageSolar)); err == nil {
 		site.publish(keys.TariffSolar, v)
 	}
-	if v := site.effectivePrice(greenShareHome); v != nil {
+	if v := slot.effectivePrice(greenShareHome); v != nil {
 		site.publish(keys.TariffPriceHome, v)
 	}
-	if v := site.effectiveCo2(greenShareHome); v != nil {
+	if v := slot.effectiveCo2(greenShareHome); v != nil {
 		site.publish(keys.TariffCo2Home, v)
 	}
-	if v := site.effectivePrice(greenShareLoadpoints); v != nil {
+	if v := slot.effectivePrice(greenShareLoadpoints); v != nil {
 		site.publish(keys.TariffPriceLoadpoints, v)
 	}
-	if v := site.effectiveCo2(greenShareLoadpoints); v != nil {
+	if v := slot.effectiveCo2(greenShareLoadpoints); v != nil {
 		site.publish(keys.TariffCo2Loadpoints, v)
 	}
 
@@ -829,11 +936,16 @@ func (site *Site) updateLoadpoints() float64 {
 	)
 
 	wg.Add(len(site.loadpoints))
-	for _, lp := range site.loadpoints {
+	for i, lp := range site.loadpoints {
 		go func() {
 			power := lp.UpdateChargePowerAndCurrents()
 			site.prioritizer.UpdateChargePowerFlexibility(lp)
 
+			// history is keyed by loadpoint id since titles may be empty or repeated
+			if site.history != nil {
+				site.history.Add(history.Loadpoint, strconv.Itoa(i+1), power, lp.sampleChargeTotalImport())
+			}
+
 			mu.Lock()
 			sum += power
 			mu.Unlock()
@@ -846,6 +958,31 @@ func (site *Site) updateLoadpoints() float64 {
 	return sum
 }
 
+// updateHomeBaseLoad updates the moving average of home power
+func (site *Site) updateHomeBaseLoad(homePower float64) {
+	site.Lock()
+	defer site.Unlock()
+
+	if site.homeBaseLoad == nil {
+		site.homeBaseLoad = &homePower
+		return
+	}
+
+	*site.homeBaseLoad += homeBaseLoadDecay * (homePower - *site.homeBaseLoad)
+}
+
+// getHomeBaseLoad returns the average home power
+func (site *Site) getHomeBaseLoad() float64 {
+	site.RLock()
+	defer site.RUnlock()
+
+	if site.homeBaseLoad == nil {
+		return 0
+	}
+
+	return *site.homeBaseLoad
+}
+
 func (site *Site) update(lp updater) {
 	site.log.DEBUG.Println("----")
 
@@ -861,6 +998,9 @@ func (site *Site) update(lp updater) {
 		site.publishCircuits()
 	}
 
+	// plan loadpoints sharing circuits, resets plans of loadpoints without circuit
+	site.updatePlans()
+
 	// prioritize if possible
 	var flexiblePower float64
 	if lp.GetMode() == api.ModePV {
@@ -880,10 +1020,15 @@ func (site *Site) update(lp updater) {
 
 	batteryGridChargeActive := site.batteryGridChargeActive(rate)
 	site.publish(keys.BatteryGridChargeActive, batteryGridChargeActive)
+	site.publish(keys.BatteryGridDischargeActive, site.batteryGridDischargeActive())
+
+	site.updateBatteryPlan(rates)
 
 	if batteryMode := site.requiredBatteryMode(batteryGridChargeActive, rate); batteryMode != api.BatteryUnknown {
-		if err := site.applyBatteryMode(batteryMode); err == nil {
+		power := site.requiredBatteryModePower(batteryMode)
+		if err := site.applyBatteryMode(batteryMode, power); err == nil {
 			site.SetBatteryMode(batteryMode)
+			site.batteryModePower = power
 		} else {
 			site.log.ERROR.Println("battery mode:", err)
 		}
@@ -894,6 +1039,8 @@ func (site *Site) update(lp updater) {
 		homePower := site.gridPower + max(0, site.pvPower) + site.batteryPower - totalChargePower
 		homePower = max(homePower, 0)
 		site.publish(keys.HomePower, homePower)
+		site.homePower = homePower
+		site.updateHomeBaseLoad(homePower)
 
 		// add battery charging power to homePower to ignore all consumption which does not occur on loadpoints
 		// fix for: https://github.com/evcc-io/evcc/issues/11032
@@ -901,14 +1048,21 @@ func (site *Site) update(lp updater) {
 		greenShareHome := site.greenShare(0, homePower)
 		greenShareLoadpoints := site.greenShare(nonChargePower, nonChargePower+totalChargePower)
 
+		slot := site.tariffSlot(site.solarShare(nonChargePower, nonChargePower+totalChargePower))
+		lp.setTariffSlot(slot)
+
+		if site.history != nil {
+			site.history.SetTariff(slot.GridPrice, slot.FeedInPrice, slot.GridCo2)
+		}
+
 		lp.Update(
 			sitePower, max(0, site.batteryPower), rates, batteryBuffered, batteryStart,
-			greenShareLoadpoints, site.effectivePrice(greenShareLoadpoints), site.effectiveCo2(greenShareLoadpoints),
+			greenShareLoadpoints, slot.effectivePrice(greenShareLoadpoints), slot.effectiveCo2(greenShareLoadpoints),
 		)
 
 		site.Health.Update()
 
-		site.publishTariffs(greenShareHome, greenShareLoadpoints)
+		site.publishTariffs(slot, greenShareHome, greenShareLoadpoints)
 
 		if telemetry.Enabled() && totalChargePower > standbyPower {
 			go telemetry.UpdateChargeProgress(site.log, totalChargePower, greenShareLoadpoints)
@@ -939,7 +1093,10 @@ func (site *Site) prepare() {
 	site.publish(keys.BufferStartSoc, site.bufferStartSoc)
 	site.publish(keys.BatteryMode, site.batteryMode)
 	site.publish(keys.BatteryDischargeControl, site.batteryDischargeControl)
+	site.publish(keys.BatteryDischargePower, site.batteryDischargePower)
+	site.publish(keys.BatteryMaxChargePower, site.batteryMaxChargePower)
 	site.publish(keys.ResidualPower, site.GetResidualPower())
+	site.publish(keys.MainFuseActive, false)
 
 	site.publish(keys.Currency, site.tariffs.Currency)
 	if tariff := site.GetTariff(api.TariffUsagePlanner); tariff != nil {
@@ -1019,10 +1176,18 @@ func (site *Site) Run(stopC chan struct{}, interval time.Duration) {
 
 	site.update(<-loadpointChan) // start immediately
 
+	// main fuse guard reacts faster than the control loop
+	var fuseTick <-chan time.Time
+	if site.mainFuseConfigured() {
+		fuseTick = time.Tick(site.MainFuse.Interval)
+	}
+
 	for tick := time.Tick(interval); ; {
 		select {
 		case <-tick:
 			site.update(<-loadpointChan)
+		case <-fuseTick:
+			site.guardMainFuse()
 		case lp := <-site.lpUpdateChan:
 			site.update(lp)
 		case <-stopC:
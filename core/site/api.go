@@ -2,6 +2,7 @@ package site
 
 import (
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/battery"
 	"github.com/evcc-io/evcc/core/loadpoint"
 )
 
@@ -42,6 +43,19 @@ type API interface {
 	GetBatteryGridChargeLimit() *float64
 	// SetBatteryGridChargeLimit sets the grid charge limit
 	SetBatteryGridChargeLimit(limit *float64)
+	// GetBatteryGridDischargeLimit get the grid discharge limit
+	GetBatteryGridDischargeLimit() *float64
+	// SetBatteryGridDischargeLimit sets the grid discharge limit
+	SetBatteryGridDischargeLimit(limit *float64)
+
+	// GetBatteryDischargePower returns the forced discharge power
+	GetBatteryDischargePower() float64
+	// SetBatteryDischargePower sets the forced discharge power
+	SetBatteryDischargePower(float64) error
+	// GetBatteryMaxChargePower returns the maximum charge power
+	GetBatteryMaxChargePower() float64
+	// SetBatteryMaxChargePower sets the maximum charge power
+	SetBatteryMaxChargePower(float64) error
 
 	//
 	// power and energy
@@ -63,4 +77,7 @@ type API interface {
 
 	GetBatteryDischargeControl() bool
 	SetBatteryDischargeControl(bool) error
+
+	// GetBatteryPlan returns the battery plan
+	GetBatteryPlan() battery.Plan
 }
@@ -319,3 +319,82 @@ func (site *Site) SetBatteryGridChargeLimit(val *float64) {
 		}
 	}
 }
+
+func (site *Site) GetBatteryGridDischargeLimit() *float64 {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryGridDischargeLimit
+}
+
+func (site *Site) SetBatteryGridDischargeLimit(val *float64) {
+	site.log.DEBUG.Println("set grid discharge limit:", printPtr("%.1f", val))
+
+	site.Lock()
+	defer site.Unlock()
+
+	if !ptrValueEqual(site.batteryGridDischargeLimit, val) {
+		site.batteryGridDischargeLimit = val
+
+		if val == nil {
+			settings.SetString(keys.BatteryGridDischargeLimit, "")
+			site.publish(keys.BatteryGridDischargeLimit, nil)
+		} else {
+			settings.SetFloat(keys.BatteryGridDischargeLimit, *val)
+			site.publish(keys.BatteryGridDischargeLimit, *val)
+		}
+	}
+}
+
+// GetBatteryDischargePower returns the forced discharge power
+func (site *Site) GetBatteryDischargePower() float64 {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryDischargePower
+}
+
+// SetBatteryDischargePower sets the forced discharge power. Zero uses the battery's default power.
+func (site *Site) SetBatteryDischargePower(power float64) error {
+	if power < 0 {
+		return errors.New("discharge power must not be negative")
+	}
+
+	site.log.DEBUG.Println("set battery discharge power:", power)
+
+	site.Lock()
+	defer site.Unlock()
+
+	if site.batteryDischargePower != power {
+		site.batteryDischargePower = power
+		settings.SetFloat(keys.BatteryDischargePower, power)
+		site.publish(keys.BatteryDischargePower, power)
+	}
+
+	return nil
+}
+
+// GetBatteryMaxChargePower returns the maximum battery charge power
+func (site *Site) GetBatteryMaxChargePower() float64 {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryMaxChargePower
+}
+
+// SetBatteryMaxChargePower sets the maximum battery charge power. Zero disables the limit.
+func (site *Site) SetBatteryMaxChargePower(power float64) error {
+	if power < 0 {
+		return errors.New("max charge power must not be negative")
+	}
+
+	site.log.DEBUG.Println("set battery max charge power:", power)
+
+	site.Lock()
+	defer site.Unlock()
+
+	if site.batteryMaxChargePower != power {
+		site.batteryMaxChargePower = power
+		settings.SetFloat(keys.BatteryMaxChargePower, power)
+		site.publish(keys.BatteryMaxChargePower, power)
+	}
+
+	return nil
+}
@@ -2,10 +2,13 @@ package core
 
 import (
 	"errors"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/battery"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/tariff"
 )
 
 func batteryModeModified(mode api.BatteryMode) bool {
@@ -41,22 +44,71 @@ func (site *Site) SetBatteryMode(batMode api.BatteryMode) {
 	}
 }
 
-// requiredBatteryMode determines required battery mode based on grid charge and rate
+// GetBatteryPlan returns the battery plan
+func (site *Site) GetBatteryPlan() battery.Plan {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryPlan
+}
+
+// updateBatteryPlan creates the battery plan from planner rates, feed-in tariff and solar forecast
+func (site *Site) updateBatteryPlan(rates api.Rates) {
+	if site.batteryScheduler == nil {
+		return
+	}
+
+	forecast := func(usage api.TariffUsage) api.Rates {
+		if t := site.GetTariff(usage); t != nil {
+			if rr, err := t.Rates(); err == nil {
+				return rr
+			}
+		}
+		return nil
+	}
+
+	var plan battery.Plan
+	if site.batteryConfigured() && len(rates) > 0 {
+		plan = site.batteryScheduler.Plan(site.batterySoc, site.batteryCapacity, site.homePower,
+			rates, forecast(api.TariffUsageFeedIn), forecast(api.TariffUsageSolar))
+	}
+
+	site.Lock()
+	site.batteryPlan = plan
+	site.Unlock()
+
+	site.publish(keys.BatteryPlan, plan)
+}
+
+// plannedBatteryMode returns the battery mode of the current plan slot if plan control is enabled
+func (site *Site) plannedBatteryMode() api.BatteryMode {
+	if site.batteryScheduler == nil || !site.batteryScheduler.Control {
+		return api.BatteryUnknown
+	}
+	return site.GetBatteryPlan().Mode(time.Now())
+}
+
+// requiredBatteryMode determines required battery mode based on grid charge, rate and battery plan
 func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rate) api.BatteryMode {
 	var res api.BatteryMode
 	batMode := site.GetBatteryMode()
+	planned := site.plannedBatteryMode()
 
 	mapper := func(s api.BatteryMode) api.BatteryMode {
-		return map[bool]api.BatteryMode{false: s, true: api.BatteryUnknown}[batMode == s]
+		unchanged := batMode == s && site.batteryModePower == site.requiredBatteryModePower(s)
+		return map[bool]api.BatteryMode{false: s, true: api.BatteryUnknown}[unchanged]
 	}
 
 	switch {
 	case !site.batteryConfigured():
 		res = api.BatteryUnknown
-	case batteryGridChargeActive:
+	case batteryGridChargeActive || planned == api.BatteryCharge:
 		res = mapper(api.BatteryCharge)
-	case site.dischargeControlActive(rate):
+	case site.batteryGridDischargeActive() || planned == api.BatteryDischarge:
+		res = mapper(api.BatteryDischarge)
+	case site.dischargeControlActive(rate) || planned == api.BatteryHold:
 		res = mapper(api.BatteryHold)
+	case site.GetBatteryMaxChargePower() > 0:
+		res = mapper(api.BatteryChargeLimit)
 	case batteryModeModified(batMode):
 		res = api.BatteryNormal
 	}
@@ -64,11 +116,48 @@ func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rat
 	return res
 }
 
-// applyBatteryMode applies the mode to each battery
-func (site *Site) applyBatteryMode(mode api.BatteryMode) error {
+// requiredBatteryModePower returns the power setpoint for battery modes supporting it
+func (site *Site) requiredBatteryModePower(mode api.BatteryMode) float64 {
+	switch mode {
+	case api.BatteryDischarge:
+		if power := site.GetBatteryDischargePower(); power > 0 || site.batteryScheduler == nil {
+			return power
+		}
+		return site.batteryScheduler.DischargePower
+	case api.BatteryChargeLimit:
+		return site.GetBatteryMaxChargePower()
+	default:
+		return 0
+	}
+}
+
+// batteryPowerMode returns true if the mode requires a battery supporting power setpoints
+func batteryPowerMode(mode api.BatteryMode) bool {
+	return mode == api.BatteryDischarge || mode == api.BatteryChargeLimit
+}
+
+// applyBatteryMode applies the mode to each battery. Power setpoint is applied if supported and non-zero.
+// Batteries not supporting power controlled modes fall back to normal mode.
+func (site *Site) applyBatteryMode(mode api.BatteryMode, power float64) error {
 	for _, meter := range site.batteryMeters {
+		batMode := mode
+
+		if batteryPowerMode(mode) {
+			if batCtrl, ok := meter.(api.BatteryPowerController); ok {
+				err := batCtrl.SetBatteryModePower(mode, power)
+				if err == nil {
+					continue
+				}
+				if !errors.Is(err, api.ErrNotAvailable) {
+					return err
+				}
+			}
+
+			batMode = api.BatteryNormal
+		}
+
 		if batCtrl, ok := meter.(api.BatteryController); ok {
-			if err := batCtrl.SetBatteryMode(mode); err != nil && !errors.Is(err, api.ErrNotAvailable) {
+			if err := batCtrl.SetBatteryMode(batMode); err != nil && !errors.Is(err, api.ErrNotAvailable) {
 				return err
 			}
 		}
@@ -96,6 +185,17 @@ func (site *Site) batteryGridChargeActive(rate api.Rate) bool {
 	return limit != nil && !rate.IsEmpty() && rate.Price <= *limit
 }
 
+// batteryGridDischargeActive returns true if the feed-in price reaches the grid discharge limit
+func (site *Site) batteryGridDischargeActive() bool {
+	limit := site.GetBatteryGridDischargeLimit()
+	if limit == nil {
+		return false
+	}
+
+	feedin, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn))
+	return err == nil && feedin >= *limit
+}
+
 func (site *Site) dischargeControlActive(rate api.Rate) bool {
 	if !site.GetBatteryDischargeControl() {
 		return false
@@ -7,10 +7,12 @@ import (
 )
 
 type circuitStruct struct {
-	Power      float64  `json:"power"`
-	Current    *float64 `json:"current,omitempty"`
-	MaxPower   float64  `json:"maxPower,omitempty"`
-	MaxCurrent float64  `json:"maxCurrent,omitempty"`
+	Power        float64   `json:"power"`
+	Current      *float64  `json:"current,omitempty"`
+	Currents     []float64 `json:"currents,omitempty"`
+	MaxPower     float64   `json:"maxPower,omitempty"`
+	MaxCurrent   float64   `json:"maxCurrent,omitempty"`
+	MaxImbalance float64   `json:"maxImbalance,omitempty"`
 }
 
 // publishCircuits returns a list of circuit titles
@@ -22,13 +24,17 @@ func (site *Site) publishCircuits() {
 		instance := c.Instance()
 
 		data := circuitStruct{
-			Power:      instance.GetChargePower(),
-			MaxPower:   instance.GetMaxPower(),
-			MaxCurrent: instance.GetMaxCurrent(),
+			Power:        instance.GetChargePower(),
+			MaxPower:     instance.GetMaxPower(),
+			MaxCurrent:   instance.GetMaxCurrent(),
+			MaxImbalance: instance.GetMaxImbalance(),
 		}
 
-		if instance.GetMaxCurrent() > 0 {
+		if instance.GetMaxCurrent() > 0 || instance.GetMaxImbalance() > 0 {
 			data.Current = lo.EmptyableToPtr(instance.GetMaxPhaseCurrent())
+
+			l1, l2, l3 := instance.GetPhaseCurrents()
+			data.Currents = []float64{l1, l2, l3}
 		}
 
 		res[c.Config().Name] = data
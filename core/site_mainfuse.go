@@ -0,0 +1,232 @@
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+)
+
+// MainFuseConfig is the main fuse protection configuration
+type MainFuseConfig struct {
+	MaxCurrent float64       `mapstructure:"maxCurrent"` // maximum grid import current per phase (A)
+	Margin     float64       `mapstructure:"margin"`     // reduce loadpoints when current exceeds max current minus margin (A)
+	Interval   time.Duration `mapstructure:"interval"`   // guard interval
+	Settle     time.Duration `mapstructure:"settle"`     // time for grid currents to reflect a limit change
+	Hysteresis float64       `mapstructure:"hysteresis"` // headroom kept before raising limits (A)
+}
+
+const (
+	mainFuseMargin     = 1                // A
+	mainFuseInterval   = 5 * time.Second  // guard interval
+	mainFuseSettle     = 15 * time.Second // charger and grid meter delay
+	mainFuseHysteresis = 2                // A
+)
+
+// mainFuseEvent is published whenever the main fuse guard reduces loadpoints
+type mainFuseEvent struct {
+	Time     time.Time `json:"time"`
+	Currents []float64 `json:"currents"` // grid currents
+	Limit    float64   `json:"limit"`    // per-phase limit including margin
+	Reduced  []int     `json:"reduced"`  // reduced loadpoints
+}
+
+// configureMainFuse validates main fuse configuration and applies defaults
+func (site *Site) configureMainFuse() error {
+	if site.MainFuse.MaxCurrent <= 0 {
+		return nil
+	}
+
+	if _, ok := site.gridMeter.(api.PhaseCurrents); !ok {
+		return errors.New("main fuse: grid meter does not provide phase currents")
+	}
+
+	if site.MainFuse.Margin <= 0 {
+		site.MainFuse.Margin = mainFuseMargin
+	}
+	if site.MainFuse.Margin >= site.MainFuse.MaxCurrent {
+		return fmt.Errorf("main fuse: margin %.3gA exceeds max current %.3gA", site.MainFuse.Margin, site.MainFuse.MaxCurrent)
+	}
+	if site.MainFuse.Interval <= 0 {
+		site.MainFuse.Interval = mainFuseInterval
+	}
+	if site.MainFuse.Settle <= 0 {
+		site.MainFuse.Settle = mainFuseSettle
+	}
+	if site.MainFuse.Hysteresis <= 0 {
+		site.MainFuse.Hysteresis = mainFuseHysteresis
+	}
+
+	return nil
+}
+
+// mainFuseConfigured returns true if main fuse protection is active
+func (site *Site) mainFuseConfigured() bool {
+	return site.MainFuse.MaxCurrent > 0
+}
+
+// gridPhaseCurrents returns the signed grid phase currents
+func (site *Site) gridPhaseCurrents() ([3]float64, error) {
+	var res [3]float64
+
+	phaseMeter, ok := site.gridMeter.(api.PhaseCurrents)
+	if !ok {
+		return res, api.ErrNotAvailable
+	}
+
+	i1, i2, i3, err := phaseMeter.Currents()
+	if err != nil {
+		return res, err
+	}
+
+	var p1, p2, p3 float64
+	if phaseMeter, ok := site.gridMeter.(api.PhasePowers); ok {
+		if p1, p2, p3, err = phaseMeter.Powers(); err != nil {
+			return res, err
+		}
+	}
+
+	return [3]float64{util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3)}, nil
+}
+
+// guardMainFuse reduces loadpoints in reverse priority order if any grid phase current approaches the main fuse limit.
+// Since grid currents lag behind charger changes, reductions not yet settled are accounted for instead of
+// reducing again. Reductions are released one loadpoint at a time once the grid currents provide headroom
+// beyond the hysteresis.
+func (site *Site) guardMainFuse() {
+	currents, err := site.gridPhaseCurrents()
+	if err != nil {
+		site.log.ERROR.Printf("main fuse: %v", err)
+		return
+	}
+
+	limit := site.MainFuse.MaxCurrent - site.MainFuse.Margin
+	settle := site.MainFuse.Settle
+
+	var (
+		overload [3]float64
+		exceeded bool
+	)
+
+	for i, current := range currents {
+		if current > limit {
+			overload[i] = current - limit
+			exceeded = true
+		}
+	}
+
+	if !exceeded {
+		site.releaseMainFuse(limit - max(currents[0], currents[1], currents[2]))
+		site.publish(keys.MainFuseActive, site.mainFuseLimited())
+		return
+	}
+
+	site.log.WARN.Printf("main fuse: grid currents %.3gA exceed limit %.3gA", currents, limit)
+
+	// account for reductions not yet reflected by grid currents
+	for _, lp := range site.loadpoints {
+		if pending := lp.pendingFuseReduction(settle); pending > 0 {
+			for i, loaded := range lp.GridPhases(lp.ActivePhases()) {
+				if loaded {
+					overload[i] -= pending
+				}
+			}
+		}
+	}
+
+	// lowest priority first
+	lps := slices.Clone(site.loadpoints)
+	slices.SortStableFunc(lps, func(a, b *Loadpoint) int {
+		return cmp.Compare(a.EffectivePriority(), b.EffectivePriority())
+	})
+
+	var reduced []int
+	for _, lp := range lps {
+		// pending reduction
+		if lp.fuseLimit != nil && !lp.fuseSettled(settle) {
+			continue
+		}
+
+		phases := lp.GridPhases(lp.ActivePhases())
+
+		var required float64
+		for i, loaded := range phases {
+			if loaded {
+				required = max(required, overload[i])
+			}
+		}
+
+		if required <= 0 {
+			continue
+		}
+
+		delta, err := lp.reduceFuseLimit(required)
+		if err != nil {
+			lp.log.ERROR.Printf("main fuse: %v", err)
+		}
+
+		if delta <= 0 {
+			continue
+		}
+
+		lp.log.WARN.Printf("main fuse: reduced charge current by %.3gA", delta)
+		reduced = append(reduced, slices.Index(site.loadpoints, lp))
+
+		for i, loaded := range phases {
+			if loaded {
+				overload[i] -= delta
+			}
+		}
+	}
+
+	if slices.ContainsFunc(overload[:], func(f float64) bool { return f > 0 }) {
+		site.log.ERROR.Printf("main fuse: unable to reduce grid currents below limit, remaining overload %.3gA", overload)
+	}
+
+	site.publish(keys.MainFuseEvent, mainFuseEvent{
+		Time:     time.Now(),
+		Currents: currents[:],
+		Limit:    limit,
+		Reduced:  reduced,
+	})
+	site.publish(keys.MainFuseActive, site.mainFuseLimited())
+}
+
+// releaseMainFuse raises the main fuse limit of the highest priority settled loadpoint by the headroom
+// exceeding the hysteresis. Limits are not raised while any limit change has not settled.
+func (site *Site) releaseMainFuse(headroom float64) {
+	headroom -= site.MainFuse.Hysteresis
+	if headroom <= 0 {
+		return
+	}
+
+	if slices.ContainsFunc(site.loadpoints, func(lp *Loadpoint) bool {
+		return !lp.fuseSettled(site.MainFuse.Settle)
+	}) {
+		return
+	}
+
+	// highest priority first
+	lps := slices.Clone(site.loadpoints)
+	slices.SortStableFunc(lps, func(a, b *Loadpoint) int {
+		return cmp.Compare(b.EffectivePriority(), a.EffectivePriority())
+	})
+
+	for _, lp := range lps {
+		if lp.releaseFuseLimit(headroom) {
+			return
+		}
+	}
+}
+
+// mainFuseLimited returns true if any loadpoint is limited by the main fuse guard
+func (site *Site) mainFuseLimited() bool {
+	return slices.ContainsFunc(site.loadpoints, func(lp *Loadpoint) bool {
+		return lp.fuseLimit != nil
+	})
+}
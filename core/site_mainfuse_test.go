@@ -0,0 +1,133 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMainFuseGuard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	gridCurrents := api.NewMockPhaseCurrents(ctrl)
+	clck := clock.NewMock()
+
+	newLoadpoint := func(priority int) (*Loadpoint, *api.MockCharger) {
+		charger := api.NewMockCharger(ctrl)
+
+		lp := NewLoadpoint(util.NewLogger("foo"), nil)
+		lp.clock = clck
+		lp.charger = charger
+		lp.priority = priority
+		lp.phases = 3
+		lp.enabled = true
+		lp.chargeCurrent = 16
+
+		return lp, charger
+	}
+
+	low, lowCharger := newLoadpoint(0)
+	high, _ := newLoadpoint(1)
+
+	site := &Site{
+		log:        util.NewLogger("foo"),
+		loadpoints: []*Loadpoint{high, low},
+		gridMeter: struct {
+			api.Meter
+			api.PhaseCurrents
+		}{nil, gridCurrents},
+		MainFuse: MainFuseConfig{MaxCurrent: 35},
+	}
+	require.NoError(t, site.configureMainFuse())
+
+	// within limit
+	gridCurrents.EXPECT().Currents().Return(30.0, 30.0, 30.0, nil)
+	site.guardMainFuse()
+	assert.False(t, site.mainFuseLimited())
+
+	// overload reduces lowest priority loadpoint first
+	gridCurrents.EXPECT().Currents().Return(40.0, 30.0, 30.0, nil)
+	lowCharger.EXPECT().MaxCurrent(int64(10))
+	site.guardMainFuse()
+
+	assert.True(t, site.mainFuseLimited())
+	assert.Equal(t, 10.0, low.chargeCurrent)
+	assert.Equal(t, 16.0, high.chargeCurrent)
+
+	// limit is enforced by control loop
+	assert.Equal(t, 10.0, low.applyFuseLimit(16))
+
+	// pending reduction not yet reflected by grid currents
+	gridCurrents.EXPECT().Currents().Return(40.0, 30.0, 30.0, nil)
+	site.guardMainFuse()
+	assert.Equal(t, 10.0, low.applyFuseLimit(16))
+	assert.Equal(t, 16.0, high.chargeCurrent)
+
+	clck.Add(site.MainFuse.Settle)
+
+	// headroom within hysteresis
+	gridCurrents.EXPECT().Currents().Return(32.0, 30.0, 30.0, nil)
+	site.guardMainFuse()
+	assert.Equal(t, 10.0, low.applyFuseLimit(16))
+
+	// limit raised by headroom exceeding hysteresis
+	gridCurrents.EXPECT().Currents().Return(30.0, 30.0, 30.0, nil)
+	site.guardMainFuse()
+	assert.Equal(t, 12.0, low.applyFuseLimit(16))
+
+	// raise not yet settled
+	gridCurrents.EXPECT().Currents().Return(20.0, 20.0, 20.0, nil)
+	site.guardMainFuse()
+	assert.Equal(t, 12.0, low.applyFuseLimit(16))
+
+	clck.Add(site.MainFuse.Settle)
+
+	// limit released
+	gridCurrents.EXPECT().Currents().Return(20.0, 20.0, 20.0, nil)
+	site.guardMainFuse()
+	assert.False(t, site.mainFuseLimited())
+}
+
+func TestMainFuseGuardDisable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	gridCurrents := api.NewMockPhaseCurrents(ctrl)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.charger = charger
+	lp.wakeUpTimer = NewTimer() // silence nil panics
+	lp.phases = 1
+	lp.phaseRotation = 2
+	lp.enabled = true
+	lp.chargeCurrent = 8
+
+	site := &Site{
+		log:        util.NewLogger("foo"),
+		loadpoints: []*Loadpoint{lp},
+		gridMeter: struct {
+			api.Meter
+			api.PhaseCurrents
+		}{nil, gridCurrents},
+		MainFuse: MainFuseConfig{MaxCurrent: 25},
+	}
+	require.NoError(t, site.configureMainFuse())
+
+	// overload on unrelated phase
+	gridCurrents.EXPECT().Currents().Return(30.0, 20.0, 20.0, nil)
+	site.guardMainFuse()
+	assert.False(t, site.mainFuseLimited())
+
+	// reduction below min current disables charging
+	gridCurrents.EXPECT().Currents().Return(20.0, 27.0, 20.0, nil)
+	charger.EXPECT().Enable(false)
+	site.guardMainFuse()
+
+	assert.True(t, site.mainFuseLimited())
+	assert.False(t, lp.enabled)
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/planner"
+)
+
+const evPlanConflict = "planconflict" // plan goal can't be met due to shared circuit
+
+// planReservation is a loadpoint's planned power within a circuit
+type planReservation struct {
+	plan   planner.Plan
+	phases int
+}
+
+// planDemand is a loadpoint's pending plan
+type planDemand struct {
+	lp       *Loadpoint
+	priority int
+	time     time.Time
+	duration time.Duration
+}
+
+// circuitCapacity returns the available power along the loadpoint's circuit chain
+// after deducting reservations of previously planned loadpoints
+func circuitCapacity(circuit api.Circuit, phases int, reservations map[api.Circuit][]planReservation) planner.Capacity {
+	// snapshot, reservations of subsequently planned loadpoints don't apply
+	reservations = maps.Clone(reservations)
+	for c, r := range reservations {
+		reservations[c] = slices.Clone(r)
+	}
+
+	return func(start, end time.Time) float64 {
+		res := math.MaxFloat64
+
+		for c := circuit; c != nil; c = c.GetParent() {
+			var power, current float64
+			for _, r := range reservations[c] {
+				p := r.plan.AveragePower(start, end)
+				power += p
+				current += powerToCurrent(p, r.phases)
+			}
+
+			if maxPower := c.GetMaxPower(); maxPower > 0 {
+				res = min(res, maxPower-power)
+			}
+
+			if maxCurrent := c.GetMaxCurrent(); maxCurrent > 0 {
+				res = min(res, (maxCurrent-current)*Voltage*float64(phases))
+			}
+		}
+
+		return max(0, res)
+	}
+}
+
+// planConstrained returns true if reservations of previously planned loadpoints reduce
+// the power available to any of the plan's slots
+func planConstrained(plan planner.Plan, capacity, unreserved planner.Capacity) bool {
+	for _, s := range plan {
+		if capacity(s.Start, s.End) < min(s.Power, unreserved(s.Start, s.End)) {
+			return true
+		}
+	}
+	return false
+}
+
+// updatePlans jointly plans all loadpoints on circuits in order of priority and plan time.
+// Loadpoints with higher priority reserve circuit capacity first, remaining loadpoints
+// are planned with the remaining capacity. Loadpoints without circuit are reset.
+func (site *Site) updatePlans() {
+	var demands []planDemand
+
+	for _, lp := range site.loadpoints {
+		var (
+			planTime time.Time
+			duration time.Duration
+		)
+
+		if lp.circuit != nil {
+			planTime, duration = lp.planDemand()
+		}
+
+		if duration <= 0 {
+			lp.setPlanCapacity(nil, capacityPlan{})
+			lp.setPlanConflict(false)
+			continue
+		}
+
+		demands = append(demands, planDemand{
+			lp:       lp,
+			priority: lp.EffectivePriority(),
+			time:     planTime,
+			duration: duration,
+		})
+	}
+
+	slices.SortStableFunc(demands, func(a, b planDemand) int {
+		return cmp.Or(b.priority-a.priority, a.time.Compare(b.time))
+	})
+
+	reservations := make(map[api.Circuit][]planReservation)
+
+	for _, d := range demands {
+		lp := d.lp
+		phases := lp.MaxActivePhases()
+
+		reserve := func(plan planner.Plan) {
+			for c := lp.circuit; c != nil; c = c.GetParent() {
+				reservations[c] = append(reservations[c], planReservation{plan: plan, phases: phases})
+			}
+		}
+
+		capacity := circuitCapacity(lp.circuit, phases, reservations)
+
+		// cost plans are only limited if reservations actually constrain them
+		if lp.PlanStrategy != loadpoint.PlanStrategySolar {
+			plan, err := lp.getPlan(d.time, d.duration, nil)
+			if err != nil {
+				lp.log.ERROR.Println("planner:", err)
+				lp.setPlanCapacity(nil, capacityPlan{})
+				continue
+			}
+
+			if !planConstrained(plan, capacity, circuitCapacity(lp.circuit, phases, nil)) {
+				lp.setPlanCapacity(nil, capacityPlan{})
+				lp.setPlanConflict(false)
+				reserve(plan)
+				continue
+			}
+		}
+
+		plan, err := lp.getPlan(d.time, d.duration, capacity)
+		if err != nil {
+			lp.log.ERROR.Println("planner:", err)
+			lp.setPlanCapacity(capacity, capacityPlan{})
+			continue
+		}
+
+		lp.setPlanCapacity(capacity, capacityPlan{time: d.time, duration: d.duration, plan: plan})
+
+		// plan without other loadpoints to detect conflicts if the goal is not reached
+		var conflict bool
+		if required := d.duration.Hours() * lp.EffectiveMaxPower(); plan.Energy() < 0.99*required {
+			if single, err := lp.getPlan(d.time, d.duration, circuitCapacity(lp.circuit, phases, nil)); err != nil {
+				lp.log.ERROR.Println("planner:", err)
+			} else {
+				// missing energy caused by higher priority loadpoints
+				missing := single.Energy() - plan.Energy()
+				if conflict = missing > 0.01*single.Energy(); conflict {
+					lp.log.WARN.Printf("plan: goal not reachable due to circuit %s, missing %.1fkWh", lp.circuit.GetTitle(), missing/1e3)
+				}
+			}
+		}
+		lp.setPlanConflict(conflict)
+
+		reserve(plan)
+	}
+}
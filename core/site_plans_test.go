@@ -0,0 +1,104 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/circuit"
+	"github.com/evcc-io/evcc/core/planner"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitCapacity(t *testing.T) {
+	Voltage = 230 // V
+	log := util.NewLogger("foo")
+
+	parent, err := circuit.New(log, "parent", 0, 20000, nil, 0)
+	require.NoError(t, err)
+
+	child, err := circuit.New(log, "child", 16, 0, nil, 0)
+	require.NoError(t, err)
+	require.NoError(t, child.Wrap(parent))
+
+	start := time.Now().Truncate(time.Hour)
+	slot := func(h int, power float64) planner.Slot {
+		return planner.Slot{
+			Rate: api.Rate{
+				Start: start.Add(time.Duration(h) * time.Hour),
+				End:   start.Add(time.Duration(h+1) * time.Hour),
+			},
+			Power: power,
+		}
+	}
+
+	reservations := map[api.Circuit][]planReservation{
+		parent: {{plan: planner.Plan{slot(0, 11000), slot(1, 11000)}, phases: 3}},
+	}
+
+	// parent power limit
+	capacity := circuitCapacity(child, 3, reservations)
+	assert.Equal(t, 9000.0, capacity(start, start.Add(time.Hour)))
+
+	// child current limit
+	assert.Equal(t, 16*Voltage*3, capacity(start.Add(2*time.Hour), start.Add(3*time.Hour)))
+
+	// child current limit with reservation
+	reservations[child] = []planReservation{{plan: planner.Plan{slot(2, 8*Voltage)}, phases: 1}}
+	capacity = circuitCapacity(child, 1, reservations)
+	assert.InDelta(t, 8*Voltage, capacity(start.Add(2*time.Hour), start.Add(3*time.Hour)), 1e-6)
+
+	// subsequent reservations don't reduce capacity
+	reservations[child] = append(reservations[child], planReservation{plan: planner.Plan{slot(2, 8*Voltage)}, phases: 1})
+	assert.InDelta(t, 8*Voltage, capacity(start.Add(2*time.Hour), start.Add(3*time.Hour)), 1e-6)
+}
+
+func TestPlanConstrained(t *testing.T) {
+	Voltage = 230 // V
+	log := util.NewLogger("foo")
+
+	c, err := circuit.New(log, "circuit", 16, 0, nil, 0)
+	require.NoError(t, err)
+
+	start := time.Now().Truncate(time.Hour)
+	slot := func(h int, power float64) planner.Slot {
+		return planner.Slot{
+			Rate: api.Rate{
+				Start: start.Add(time.Duration(h) * time.Hour),
+				End:   start.Add(time.Duration(h+1) * time.Hour),
+			},
+			Power: power,
+		}
+	}
+
+	unreserved := circuitCapacity(c, 3, nil)
+	plan := planner.Plan{slot(1, 22000)}
+
+	// circuit limit alone doesn't constrain
+	assert.False(t, planConstrained(plan, unreserved, unreserved))
+
+	// reservation outside the plan's slots doesn't constrain
+	reservations := map[api.Circuit][]planReservation{
+		c: {{plan: planner.Plan{slot(0, 11000)}, phases: 3}},
+	}
+	assert.False(t, planConstrained(plan, circuitCapacity(c, 3, reservations), unreserved))
+
+	// overlapping reservation constrains
+	reservations[c] = append(reservations[c], planReservation{plan: planner.Plan{slot(1, 4000)}, phases: 3})
+	assert.True(t, planConstrained(plan, circuitCapacity(c, 3, reservations), unreserved))
+}
+
+func TestUpdatePlansWithoutCircuit(t *testing.T) {
+	lp := &Loadpoint{
+		log:          util.NewLogger("foo"),
+		planConflict: true,
+	}
+
+	site := &Site{loadpoints: []*Loadpoint{lp}}
+	site.updatePlans()
+
+	assert.False(t, lp.planConflict)
+	assert.False(t, lp.hasPlanCapacity())
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 )
 
 func TestGreenShare(t *testing.T) {
@@ -154,3 +155,81 @@ func TestRequiredBatteryMode(t *testing.T) {
 		assert.Equal(t, tc.res, res, "expected %s, got %s", tc.res, res)
 	}
 }
+
+func TestRequiredBatteryModePower(t *testing.T) {
+	tc := []struct {
+		mode      api.BatteryMode
+		modePower float64
+		res       api.BatteryMode
+	}{
+		{api.BatteryNormal, 0, api.BatteryChargeLimit},
+		{api.BatteryChargeLimit, 3000, api.BatteryUnknown}, // ignore
+		{api.BatteryChargeLimit, 2000, api.BatteryChargeLimit},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		s := &Site{
+			batteryMeters:         []api.Meter{nil},
+			batteryMode:           tc.mode,
+			batteryModePower:      tc.modePower,
+			batteryMaxChargePower: 3000,
+		}
+
+		res := s.requiredBatteryMode(false, api.Rate{})
+		assert.Equal(t, tc.res, res, "expected %s, got %s", tc.res, res)
+	}
+}
+
+func TestApplyBatteryModePower(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	bat := api.NewMockBatteryPowerController(ctrl)
+	s := &Site{
+		batteryMeters: []api.Meter{struct {
+			api.Meter
+			api.BatteryPowerController
+		}{nil, bat}},
+	}
+
+	bat.EXPECT().SetBatteryModePower(api.BatteryDischarge, 5000.0)
+	assert.NoError(t, s.applyBatteryMode(api.BatteryDischarge, 5000))
+}
+
+func TestApplyBatteryModeFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	// battery without power control
+	bat1 := api.NewMockBatteryController(ctrl)
+
+	// battery with power control not supporting the mode
+	bat2 := api.NewMockBatteryController(ctrl)
+	bat2Power := api.NewMockBatteryPowerController(ctrl)
+
+	s := &Site{
+		batteryMeters: []api.Meter{
+			struct {
+				api.Meter
+				api.BatteryController
+			}{nil, bat1},
+			struct {
+				api.Meter
+				api.BatteryController
+				api.BatteryPowerController
+			}{nil, bat2, bat2Power},
+		},
+	}
+
+	for _, mode := range []api.BatteryMode{api.BatteryDischarge, api.BatteryChargeLimit} {
+		bat1.EXPECT().SetBatteryMode(api.BatteryNormal)
+		bat2Power.EXPECT().SetBatteryModePower(mode, 3000.0).Return(api.ErrNotAvailable)
+		bat2.EXPECT().SetBatteryMode(api.BatteryNormal)
+
+		assert.NoError(t, s.applyBatteryMode(mode, 3000))
+	}
+
+	bat1.EXPECT().SetBatteryMode(api.BatteryHold)
+	bat2.EXPECT().SetBatteryMode(api.BatteryHold)
+	assert.NoError(t, s.applyBatteryMode(api.BatteryHold, 0))
+}
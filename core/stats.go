@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/evcc-io/evcc/core/history"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/util"
@@ -27,17 +28,19 @@ func NewStats() *Stats {
 	}
 }
 
-// Update publishes stats based on charging sessions
+// Update publishes stats based on charging sessions and energy history
 func (s *Stats) Update(p publisher) {
 	if time.Since(s.updated) < time.Hour {
 		return
 	}
 
 	stats := map[string]map[string]float64{
-		"30d":      s.calculate(time.Now().AddDate(0, 0, -30)),
-		"365d":     s.calculate(time.Now().AddDate(0, 0, -365)),
-		"thisYear": s.calculate(now.BeginningOfYear()),
-		"total":    s.calculate(time.Time{}),
+		"today":     s.calculate(now.BeginningOfDay()),
+		"thisMonth": s.calculate(now.BeginningOfMonth()),
+		"30d":       s.calculate(time.Now().AddDate(0, 0, -30)),
+		"365d":      s.calculate(time.Now().AddDate(0, 0, -365)),
+		"thisYear":  s.calculate(now.BeginningOfYear()),
+		"total":     s.calculate(time.Time{}),
 	}
 	p.publish(keys.Statistics, stats)
 
@@ -72,5 +75,20 @@ func (s *Stats) calculate(fromDate time.Time) map[string]float64 {
 	result["avgPrice"] = avgPrice
 	result["avgCo2"] = avgCo2
 
+	// site kpis from energy history
+	kpi, err := history.SiteKpi(db.Instance, fromDate)
+	if err != nil {
+		s.log.ERROR.Printf("error reading history: %v", err)
+		return result
+	}
+
+	result["gridImportKWh"] = kpi.GridImport
+	result["gridExportKWh"] = kpi.GridExport
+	result["selfSufficiency"] = kpi.SelfSufficiency
+	result["selfConsumption"] = kpi.SelfConsumption
+	result["feedInRevenue"] = kpi.FeedInRevenue
+	result["netCost"] = kpi.NetCost
+	result["co2AvoidedKg"] = kpi.Co2Avoided
+
 	return result
 }
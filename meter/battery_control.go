@@ -30,3 +30,33 @@ func (m *battery) LimitController(socG func() (float64, error), limitSocS func(f
 		}
 	}
 }
+
+// batteryPowerController returns an api.BatteryPowerController decorator.
+// Modes without power setter are not available. The power setpoint, or default power if zero, is applied after switching the battery mode.
+// The mode setter must not write the power registers since refreshing the mode would revert the setpoint.
+func batteryPowerController(modeS func(api.BatteryMode) error, dischargePowerS, maxChargePowerS func(float64) error, defaultPower float64) func(api.BatteryMode, float64) error {
+	return func(mode api.BatteryMode, power float64) error {
+		var powerS func(float64) error
+
+		switch mode {
+		case api.BatteryDischarge:
+			powerS = dischargePowerS
+		case api.BatteryChargeLimit:
+			powerS = maxChargePowerS
+		}
+
+		if powerS == nil {
+			return api.ErrNotAvailable
+		}
+
+		if power == 0 {
+			power = defaultPower
+		}
+
+		if err := modeS(mode); err != nil || power == 0 {
+			return err
+		}
+
+		return powerS(power)
+	}
+}
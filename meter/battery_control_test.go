@@ -0,0 +1,96 @@
+package meter
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andig/mbserver"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerHandler stores holding register writes
+type registerHandler struct {
+	mbserver.DummyHandler
+	mu   sync.Mutex
+	regs map[uint16]uint16
+}
+
+func (h *registerHandler) HandleHoldingRegisters(req *mbserver.HoldingRegistersRequest) ([]uint16, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if req.IsWrite {
+		for i, v := range req.Args {
+			h.regs[req.Addr+uint16(i)] = v
+		}
+		return req.Args, nil
+	}
+
+	res := make([]uint16, req.Quantity)
+	for i := range res {
+		res[i] = h.regs[req.Addr+uint16(i)]
+	}
+
+	return res, nil
+}
+
+func (h *registerHandler) uint32(addr uint16) uint32 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return uint32(h.regs[addr])<<16 | uint32(h.regs[addr+1])
+}
+
+func TestBatteryModePowerSurvivesWatchdog(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	h := &registerHandler{regs: make(map[uint16]uint16)}
+
+	srv, _ := mbserver.New(h)
+	require.NoError(t, srv.Start(l))
+	defer func() { _ = srv.Stop() }()
+
+	m, err := NewFromConfig(context.TODO(), "template", map[string]any{
+		"template": "sma-hybrid",
+		"usage":    "battery",
+		"modbus":   "tcpip",
+		"host":     "localhost",
+		"port":     l.Addr().(*net.TCPAddr).Port,
+		"id":       1,
+		"watchdog": "50ms",
+	})
+	require.NoError(t, err)
+
+	bat, ok := m.(api.BatteryPowerController)
+	require.True(t, ok)
+
+	// refresh must keep the requested power
+	require.NoError(t, bat.SetBatteryModePower(api.BatteryDischarge, 3000))
+	time.Sleep(175 * time.Millisecond)
+
+	assert.Equal(t, uint32(2290), h.uint32(40236))
+	assert.Equal(t, uint32(3000), h.uint32(40797))
+	assert.Equal(t, uint32(3000), h.uint32(40799))
+
+	require.NoError(t, bat.SetBatteryModePower(api.BatteryChargeLimit, 1500))
+	time.Sleep(175 * time.Millisecond)
+
+	assert.Equal(t, uint32(2424), h.uint32(40236))
+	assert.Equal(t, uint32(1500), h.uint32(40795))
+
+	// default power without setpoint
+	require.NoError(t, bat.SetBatteryModePower(api.BatteryDischarge, 0))
+	time.Sleep(75 * time.Millisecond)
+
+	assert.Equal(t, uint32(4200), h.uint32(40799))
+
+	// stop watchdog
+	require.NoError(t, m.(api.BatteryController).SetBatteryMode(api.BatteryNormal))
+}
@@ -26,7 +26,7 @@ func init() {
 	registry.Add("e3dc-rscp", NewE3dcFromConfig)
 }
 
-//go:generate go tool decorate -f decorateE3dc -b *E3dc -r api.Meter -t "api.BatteryCapacity,Capacity,func() float64" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error"
+//go:generate go tool decorate -f decorateE3dc -b *E3dc -r api.Meter -t "api.BatteryCapacity,Capacity,func() float64" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error" -t "api.BatteryPowerController,SetBatteryModePower,func(api.BatteryMode, float64) error"
 
 func NewE3dcFromConfig(other map[string]interface{}) (api.Meter, error) {
 	cc := struct {
@@ -93,15 +93,17 @@ func NewE3dc(cfg rscp.ClientConfig, usage templates.Usage, dischargeLimit uint32
 		batteryCapacity func() float64
 		batterySoc      func() (float64, error)
 		batteryMode     func(api.BatteryMode) error
+		batteryPower    func(api.BatteryMode, float64) error
 	)
 
 	if usage == templates.UsageBattery {
 		batteryCapacity = capacity
 		batterySoc = m.batterySoc
 		batteryMode = m.setBatteryMode
+		batteryPower = m.setBatteryModePower
 	}
 
-	return decorateE3dc(m, batteryCapacity, batterySoc, batteryMode), nil
+	return decorateE3dc(m, batteryCapacity, batterySoc, batteryMode, batteryPower), nil
 }
 
 func (m *E3dc) CurrentPower() (float64, error) {
@@ -189,6 +191,31 @@ func (m *E3dc) setBatteryMode(mode api.BatteryMode) error {
 	return err
 }
 
+// setBatteryModePower supports limiting the charge power. Forced discharge requires
+// refreshing the setpoint every 30s and is not available.
+func (m *E3dc) setBatteryModePower(mode api.BatteryMode, power float64) error {
+	if mode != api.BatteryChargeLimit {
+		return api.ErrNotAvailable
+	}
+
+	res, err := m.conn.SendMultiple([]rscp.Message{
+		e3dcChargeBatteryLimit(uint32(power)),
+		e3dcBatteryCharge(0),
+	})
+
+	if err == nil {
+		err = rscpError(res...)
+	}
+	return err
+}
+
+func e3dcChargeBatteryLimit(limit uint32) rscp.Message {
+	return *rscp.NewMessage(rscp.EMS_REQ_SET_POWER_SETTINGS, []rscp.Message{
+		*rscp.NewMessage(rscp.EMS_POWER_LIMITS_USED, true),
+		*rscp.NewMessage(rscp.EMS_MAX_CHARGE_POWER, limit),
+	})
+}
+
 func e3dcDischargeBatteryLimit(active bool, limit uint32) rscp.Message {
 	contents := []rscp.Message{
 		*rscp.NewMessage(rscp.EMS_POWER_LIMITS_USED, active),
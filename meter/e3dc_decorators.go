@@ -6,12 +6,12 @@ import (
 	"github.com/evcc-io/evcc/api"
 )
 
-func decorateE3dc(base *E3dc, batteryCapacity func() float64, battery func() (float64, error), batteryController func(api.BatteryMode) error) api.Meter {
+func decorateE3dc(base *E3dc, batteryCapacity func() float64, battery func() (float64, error), batteryController func(api.BatteryMode) error, batteryPowerController func(api.BatteryMode, float64) error) api.Meter {
 	switch {
-	case battery == nil:
+	case battery == nil && batteryPowerController == nil:
 		return base
 
-	case battery != nil && batteryCapacity == nil && batteryController == nil:
+	case battery != nil && batteryCapacity == nil && batteryController == nil && batteryPowerController == nil:
 		return &struct {
 			*E3dc
 			api.Battery
@@ -22,7 +22,7 @@ func decorateE3dc(base *E3dc, batteryCapacity func() float64, battery func() (fl
 			},
 		}
 
-	case battery != nil && batteryCapacity != nil && batteryController == nil:
+	case battery != nil && batteryCapacity != nil && batteryController == nil && batteryPowerController == nil:
 		return &struct {
 			*E3dc
 			api.Battery
@@ -37,7 +37,7 @@ func decorateE3dc(base *E3dc, batteryCapacity func() float64, battery func() (fl
 			},
 		}
 
-	case battery != nil && batteryCapacity == nil && batteryController != nil:
+	case battery != nil && batteryCapacity == nil && batteryController != nil && batteryPowerController == nil:
 		return &struct {
 			*E3dc
 			api.Battery
@@ -52,7 +52,7 @@ func decorateE3dc(base *E3dc, batteryCapacity func() float64, battery func() (fl
 			},
 		}
 
-	case battery != nil && batteryCapacity != nil && batteryController != nil:
+	case battery != nil && batteryCapacity != nil && batteryController != nil && batteryPowerController == nil:
 		return &struct {
 			*E3dc
 			api.Battery
@@ -70,6 +70,93 @@ func decorateE3dc(base *E3dc, batteryCapacity func() float64, battery func() (fl
 				batteryController: batteryController,
 			},
 		}
+
+	case battery == nil && batteryPowerController != nil:
+		return &struct {
+			*E3dc
+			api.BatteryPowerController
+		}{
+			E3dc: base,
+			BatteryPowerController: &decorateE3dcBatteryPowerControllerImpl{
+				batteryPowerController: batteryPowerController,
+			},
+		}
+
+	case battery != nil && batteryCapacity == nil && batteryController == nil && batteryPowerController != nil:
+		return &struct {
+			*E3dc
+			api.Battery
+			api.BatteryPowerController
+		}{
+			E3dc: base,
+			Battery: &decorateE3dcBatteryImpl{
+				battery: battery,
+			},
+			BatteryPowerController: &decorateE3dcBatteryPowerControllerImpl{
+				batteryPowerController: batteryPowerController,
+			},
+		}
+
+	case battery != nil && batteryCapacity != nil && batteryController == nil && batteryPowerController != nil:
+		return &struct {
+			*E3dc
+			api.Battery
+			api.BatteryCapacity
+			api.BatteryPowerController
+		}{
+			E3dc: base,
+			Battery: &decorateE3dcBatteryImpl{
+				battery: battery,
+			},
+			BatteryCapacity: &decorateE3dcBatteryCapacityImpl{
+				batteryCapacity: batteryCapacity,
+			},
+			BatteryPowerController: &decorateE3dcBatteryPowerControllerImpl{
+				batteryPowerController: batteryPowerController,
+			},
+		}
+
+	case battery != nil && batteryCapacity == nil && batteryController != nil && batteryPowerController != nil:
+		return &struct {
+			*E3dc
+			api.Battery
+			api.BatteryController
+			api.BatteryPowerController
+		}{
+			E3dc: base,
+			Battery: &decorateE3dcBatteryImpl{
+				battery: battery,
+			},
+			BatteryController: &decorateE3dcBatteryControllerImpl{
+				batteryController: batteryController,
+			},
+			BatteryPowerController: &decorateE3dcBatteryPowerControllerImpl{
+				batteryPowerController: batteryPowerController,
+			},
+		}
+
+	case battery != nil && batteryCapacity != nil && batteryController != nil && batteryPowerController != nil:
+		return &struct {
+			*E3dc
+			api.Battery
+			api.BatteryCapacity
+			api.BatteryController
+			api.BatteryPowerController
+		}{
+			E3dc: base,
+			Battery: &decorateE3dcBatteryImpl{
+				battery: battery,
+			},
+			BatteryCapacity: &decorateE3dcBatteryCapacityImpl{
+				batteryCapacity: batteryCapacity,
+			},
+			BatteryController: &decorateE3dcBatteryControllerImpl{
+				batteryController: batteryController,
+			},
+			BatteryPowerController: &decorateE3dcBatteryPowerControllerImpl{
+				batteryPowerController: batteryPowerController,
+			},
+		}
 	}
 
 	return nil
@@ -98,3 +185,11 @@ type decorateE3dcBatteryControllerImpl struct {
 func (impl *decorateE3dcBatteryControllerImpl) SetBatteryMode(p0 api.BatteryMode) error {
 	return impl.batteryController(p0)
 }
+
+type decorateE3dcBatteryPowerControllerImpl struct {
+	batteryPowerController func(api.BatteryMode, float64) error
+}
+
+func (impl *decorateE3dcBatteryPowerControllerImpl) SetBatteryModePower(p0 api.BatteryMode, p1 float64) error {
+	return impl.batteryPowerController(p0, p1)
+}
@@ -0,0 +1,106 @@
+package meter
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/mbus"
+)
+
+// MBus meter implementation for wired and wireless M-Bus meters
+type MBus struct {
+	telegram func() (*mbus.Telegram, error)
+}
+
+func init() {
+	registry.Add("mbus", NewMBusFromConfig)
+}
+
+//go:generate go tool decorate -f decorateMBus -b *MBus -r api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)"
+
+// NewMBusFromConfig creates a M-Bus meter from generic config
+func NewMBusFromConfig(other map[string]interface{}) (api.Meter, error) {
+	cc := struct {
+		mbus.Settings `mapstructure:",squash"`
+		Cache         time.Duration
+	}{
+		Cache: time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	return NewMBus(cc.Settings, cc.Cache)
+}
+
+// NewMBus creates M-Bus meter
+func NewMBus(settings mbus.Settings, cache time.Duration) (api.Meter, error) {
+	telegram, err := settings.Reader()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MBus{
+		telegram: util.Cached(telegram, cache),
+	}
+
+	t, err := m.telegram()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := t.Find(mbus.Power, 0); err != nil {
+		return nil, err
+	}
+
+	// decorate energy reading
+	var totalEnergy func() (float64, error)
+	if _, err := t.Find(mbus.Energy, 0); err == nil {
+		totalEnergy = m.totalEnergy
+	}
+
+	// decorate currents
+	var currents func() (float64, float64, float64, error)
+	if _, err := t.Find(mbus.Current, 2); err == nil {
+		currents = m.currents
+	}
+
+	return decorateMBus(m, totalEnergy, currents), nil
+}
+
+func (m *MBus) value(q mbus.Quantity, index int) (float64, error) {
+	t, err := m.telegram()
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := t.Find(q, index)
+
+	return r.Value, err
+}
+
+// CurrentPower implements the api.Meter interface
+func (m *MBus) CurrentPower() (float64, error) {
+	return m.value(mbus.Power, 0)
+}
+
+// totalEnergy implements the api.MeterEnergy interface
+func (m *MBus) totalEnergy() (float64, error) {
+	return m.value(mbus.Energy, 0)
+}
+
+// currents implements the api.PhaseCurrents interface
+func (m *MBus) currents() (float64, float64, float64, error) {
+	var res [3]float64
+
+	for i := range res {
+		var err error
+		if res[i], err = m.value(mbus.Current, i); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	return res[0], res[1], res[2], nil
+}
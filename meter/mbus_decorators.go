@@ -0,0 +1,69 @@
+package meter
+
+// Code generated by github.com/evcc-io/evcc/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decorateMBus(base *MBus, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error)) api.Meter {
+	switch {
+	case meterEnergy == nil && phaseCurrents == nil:
+		return base
+
+	case meterEnergy != nil && phaseCurrents == nil:
+		return &struct {
+			*MBus
+			api.MeterEnergy
+		}{
+			MBus: base,
+			MeterEnergy: &decorateMBusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case meterEnergy == nil && phaseCurrents != nil:
+		return &struct {
+			*MBus
+			api.PhaseCurrents
+		}{
+			MBus: base,
+			PhaseCurrents: &decorateMBusPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+
+	case meterEnergy != nil && phaseCurrents != nil:
+		return &struct {
+			*MBus
+			api.MeterEnergy
+			api.PhaseCurrents
+		}{
+			MBus: base,
+			MeterEnergy: &decorateMBusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decorateMBusPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decorateMBusMeterEnergyImpl struct {
+	meterEnergy func() (float64, error)
+}
+
+func (impl *decorateMBusMeterEnergyImpl) TotalEnergy() (float64, error) {
+	return impl.meterEnergy()
+}
+
+type decorateMBusPhaseCurrentsImpl struct {
+	phaseCurrents func() (float64, float64, float64, error)
+}
+
+func (impl *decorateMBusPhaseCurrentsImpl) Currents() (float64, float64, float64, error) {
+	return impl.phaseCurrents()
+}
@@ -13,7 +13,7 @@ func init() {
 	registry.AddCtx(api.Custom, NewConfigurableFromConfig)
 }
 
-//go:generate go tool decorate -f decorateMeter -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.MaxACPower,MaxACPower,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error"
+//go:generate go tool decorate -f decorateMeter -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.MaxACPower,MaxACPower,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error" -t "api.BatteryPowerController,SetBatteryModePower,func(api.BatteryMode, float64) error"
 
 // NewConfigurableFromConfig creates api.Meter from config
 func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}) (api.Meter, error) {
@@ -25,12 +25,15 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		Powers   []plugin.Config // optional
 
 		// battery
-		capacity    `mapstructure:",squash"`
-		maxpower    `mapstructure:",squash"`
-		battery     `mapstructure:",squash"`
-		Soc         *plugin.Config // optional
-		LimitSoc    *plugin.Config // optional
-		BatteryMode *plugin.Config // optional
+		capacity       `mapstructure:",squash"`
+		maxpower       `mapstructure:",squash"`
+		battery        `mapstructure:",squash"`
+		Soc            *plugin.Config // optional
+		LimitSoc       *plugin.Config // optional
+		BatteryMode    *plugin.Config // optional
+		DischargePower *plugin.Config // optional
+		MaxChargePower *plugin.Config // optional
+		ModePower      float64        // optional
 	}{
 		battery: battery{
 			MinSoc: 20,
@@ -60,7 +63,10 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		return nil, fmt.Errorf("battery soc: %w", err)
 	}
 
-	var batModeS func(api.BatteryMode) error
+	var (
+		batModeS      func(api.BatteryMode) error
+		batModePowerS func(api.BatteryMode, float64) error
+	)
 
 	switch {
 	case cc.Soc != nil && cc.LimitSoc != nil:
@@ -80,9 +86,23 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		batModeS = func(mode api.BatteryMode) error {
 			return modeS(int64(mode))
 		}
+
+		dischargePowerS, err := cc.DischargePower.FloatSetter(ctx, "dischargePower")
+		if err != nil {
+			return nil, fmt.Errorf("battery discharge power: %w", err)
+		}
+
+		maxChargePowerS, err := cc.MaxChargePower.FloatSetter(ctx, "maxChargePower")
+		if err != nil {
+			return nil, fmt.Errorf("battery max charge power: %w", err)
+		}
+
+		if dischargePowerS != nil || maxChargePowerS != nil {
+			batModePowerS = batteryPowerController(batModeS, dischargePowerS, maxChargePowerS, cc.ModePower)
+		}
 	}
 
-	res := m.Decorate(energyG, currentsG, voltagesG, powersG, socG, cc.capacity.Decorator(), cc.maxpower.Decorator(), batModeS)
+	res := m.Decorate(energyG, currentsG, voltagesG, powersG, socG, cc.capacity.Decorator(), cc.maxpower.Decorator(), batModeS, batModePowerS)
 
 	return res, nil
 }
@@ -110,8 +130,9 @@ func (m *Meter) Decorate(
 	batteryCapacity func() float64,
 	MaxACPower func() float64,
 	setBatteryMode func(api.BatteryMode) error,
+	setBatteryModePower func(api.BatteryMode, float64) error,
 ) api.Meter {
-	return decorateMeter(m, totalEnergy, currents, voltages, powers, batterySoc, batteryCapacity, MaxACPower, setBatteryMode)
+	return decorateMeter(m, totalEnergy, currents, voltages, powers, batterySoc, batteryCapacity, MaxACPower, setBatteryMode, setBatteryModePower)
 }
 
 // CurrentPower implements the api.Meter interface
@@ -20,3 +20,19 @@ const (
 	EnergyConsumptionL3 = "1-0:61.8.0"
 	CurrentL3           = "1-0:71.4.0"
 )
+
+// instantaneous values
+const (
+	Power                   = "1-0:16.7.0" // signed, consumption positive
+	PowerConsumptionInstant = "1-0:1.7.0"
+	PowerFeedInInstant      = "1-0:2.7.0"
+	PowerL1                 = "1-0:36.7.0"
+	PowerL2                 = "1-0:56.7.0"
+	PowerL3                 = "1-0:76.7.0"
+	CurrentL1Instant        = "1-0:31.7.0"
+	CurrentL2Instant        = "1-0:51.7.0"
+	CurrentL3Instant        = "1-0:71.7.0"
+	VoltageL1Instant        = "1-0:32.7.0"
+	VoltageL2Instant        = "1-0:52.7.0"
+	VoltageL3Instant        = "1-0:72.7.0"
+)
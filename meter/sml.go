@@ -0,0 +1,313 @@
+package meter
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/meter/obis"
+	"github.com/evcc-io/evcc/meter/sml"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/serial"
+)
+
+// Sml meter implementation for optical readers speaking SML or IEC 62056-21
+type Sml struct {
+	mu       sync.Mutex
+	log      *util.Logger
+	uri      string
+	serial   *serial.Config
+	iec      bool
+	baudrate int
+	energy   string
+	timeout  time.Duration
+	values   map[string]float64
+	updated  time.Time
+}
+
+var (
+	smlCurrentObis = []string{obis.CurrentL1Instant, obis.CurrentL2Instant, obis.CurrentL3Instant}
+	smlPowerObis   = []string{obis.PowerL1, obis.PowerL2, obis.PowerL3}
+)
+
+func init() {
+	registry.Add("sml", NewSmlFromConfig)
+}
+
+//go:generate go tool decorate -f decorateSml -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)"
+
+// NewSmlFromConfig creates a SML or IEC 62056-21 meter from generic config
+func NewSmlFromConfig(other map[string]interface{}) (api.Meter, error) {
+	cc := struct {
+		URI, Device, Comset string
+		Baudrate            int
+		Protocol            string
+		Energy              string
+		Timeout             time.Duration
+	}{
+		Protocol: "sml",
+		Energy:   obis.EnergyConsumption,
+		Timeout:  15 * time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	var iec bool
+	comset, baudrate := "8N1", 9600
+
+	switch strings.ToLower(cc.Protocol) {
+	case "sml":
+	case "iec", "iec62056":
+		// mode C initial baud rate
+		iec = true
+		comset, baudrate = "7E1", 300
+	default:
+		return nil, fmt.Errorf("invalid protocol: %s", cc.Protocol)
+	}
+
+	if cc.Comset == "" {
+		cc.Comset = comset
+	}
+	if cc.Baudrate == 0 {
+		cc.Baudrate = baudrate
+	}
+
+	return NewSml(cc.URI, cc.Device, cc.Comset, cc.Baudrate, iec, cc.Energy, cc.Timeout)
+}
+
+// NewSml creates SML or IEC 62056-21 meter reading from either a serial device or a TCP serial bridge
+func NewSml(uri, device, comset string, baudrate int, iec bool, energy string, timeout time.Duration) (api.Meter, error) {
+	if (uri == "") == (device == "") {
+		return nil, errors.New("must have either uri or device")
+	}
+
+	m := &Sml{
+		log:      util.NewLogger("sml"),
+		uri:      uri,
+		iec:      iec,
+		baudrate: baudrate,
+		energy:   energy,
+		timeout:  timeout,
+	}
+
+	if device != "" {
+		var err error
+		if m.serial, err = serial.NewConfig(device, comset, baudrate, timeout); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := m.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{}, 1)
+	go m.run(ctx, conn, done)
+
+	// wait for initial value
+	select {
+	case <-done:
+	case <-time.NewTimer(timeout).C:
+		cancel()
+		return nil, os.ErrDeadlineExceeded
+	}
+
+	// reader runs for the meter's lifetime
+	_ = cancel
+
+	// decorate energy reading
+	var totalEnergy func() (float64, error)
+	if _, err := m.get(energy); energy != "" && err == nil {
+		totalEnergy = m.totalEnergy
+	}
+
+	// decorate currents
+	var currents func() (float64, float64, float64, error)
+	if _, _, _, err := m.currents(); err == nil {
+		currents = m.currents
+	}
+
+	return decorateSml(m, totalEnergy, currents), nil
+}
+
+func (m *Sml) connect() (io.ReadWriteCloser, error) {
+	if m.serial != nil {
+		return serial.Open(m.serial)
+	}
+
+	dialer := net.Dialer{Timeout: request.Timeout}
+
+	return dialer.Dial("tcp", m.uri)
+}
+
+// read reads the next telegram
+func (m *Sml) read(conn io.ReadWriteCloser, reader *bufio.Reader) ([]sml.Value, error) {
+	if c, ok := conn.(net.Conn); ok {
+		if err := c.SetReadDeadline(time.Now().Add(m.timeout)); err != nil {
+			return nil, err
+		}
+	}
+
+	if !m.iec {
+		payload, err := sml.ReadFrame(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		return sml.Decode(payload)
+	}
+
+	// meters in push mode ignore the request
+	if _, err := io.WriteString(conn, sml.Request); err != nil {
+		return nil, err
+	}
+
+	return sml.ReadTelegram(reader, conn, m.baudrate)
+}
+
+// sleep waits for the given duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+func (m *Sml) run(ctx context.Context, conn io.ReadWriteCloser, done chan struct{}) {
+	bo := backoff.NewExponentialBackOff(backoff.WithMaxInterval(5 * time.Minute))
+
+	reader := bufio.NewReader(conn)
+
+	// close the connection to abort pending reads when the context is done
+	closeOnDone := func(c io.Closer) func() bool {
+		return context.AfterFunc(ctx, func() { c.Close() })
+	}
+
+	stop := closeOnDone(conn)
+
+	for ctx.Err() == nil {
+		if conn == nil {
+			var err error
+			if conn, err = m.connect(); err != nil {
+				m.log.ERROR.Printf("connect: %v", err)
+				sleep(ctx, bo.NextBackOff().Truncate(time.Second))
+				continue
+			}
+
+			reader.Reset(conn)
+			stop = closeOnDone(conn)
+		}
+
+		values, err := m.read(conn, reader)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+
+			m.log.ERROR.Printf("read: %v", err)
+
+			// frame errors don't require reconnecting
+			if errors.Is(err, sml.ErrCrc) {
+				continue
+			}
+
+			stop()
+			conn.Close()
+			conn = nil
+
+			sleep(ctx, bo.NextBackOff().Truncate(time.Second))
+			continue
+		}
+
+		bo.Reset()
+
+		res := make(map[string]float64, len(values))
+		for _, v := range values {
+			m.log.TRACE.Printf("%s: %.4f", v.Obis, v.Value)
+			res[v.Obis] = v.Value
+		}
+
+		m.mu.Lock()
+		m.values = res
+		m.updated = time.Now()
+		m.mu.Unlock()
+
+		select {
+		case done <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (m *Sml) get(id string) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if time.Since(m.updated) > m.timeout {
+		return 0, os.ErrDeadlineExceeded
+	}
+
+	res, ok := m.values[id]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", api.ErrNotAvailable, id)
+	}
+
+	return res, nil
+}
+
+// CurrentPower implements the api.Meter interface
+func (m *Sml) CurrentPower() (float64, error) {
+	if res, err := m.get(obis.Power); !errors.Is(err, api.ErrNotAvailable) {
+		return res, err
+	}
+
+	bezug, err1 := m.get(obis.PowerConsumptionInstant)
+	lief, err2 := m.get(obis.PowerFeedInInstant)
+
+	// allow one value to be missing
+	if err1 == nil && errors.Is(err2, api.ErrNotAvailable) || err2 == nil && errors.Is(err1, api.ErrNotAvailable) {
+		err1 = nil
+		err2 = nil
+	}
+
+	return bezug - lief, errors.Join(err1, err2)
+}
+
+// totalEnergy implements the api.MeterEnergy interface
+func (m *Sml) totalEnergy() (float64, error) {
+	return m.get(m.energy)
+}
+
+// currents implements the api.PhaseCurrents interface
+func (m *Sml) currents() (float64, float64, float64, error) {
+	var res [3]float64
+
+	for i := range res {
+		var err error
+		if res[i], err = m.get(smlCurrentObis[i]); err != nil {
+			return 0, 0, 0, err
+		}
+
+		// correct import/export sign if phase powers are available
+		if f, err := m.get(smlPowerObis[i]); err == nil && f < 0 {
+			res[i] = -res[i]
+		}
+	}
+
+	return res[0], res[1], res[2], nil
+}
@@ -0,0 +1,123 @@
+package sml
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// IEC 62056-21 data readout
+
+// Request is the IEC 62056-21 request message sent to meters in mode A, B or C
+const Request = "/?!\r\n"
+
+const maxDatasets = 1024
+
+// mode C baud rate identification
+var modeCBaudrates = []int{300, 600, 1200, 2400, 4800, 9600, 19200}
+
+// Ack returns the mode C acknowledgement requesting the data readout at the given baud rate.
+// It returns false if the identification is not mode C or the meter does not support the baud rate.
+func Ack(ident string, baudrate int) (string, bool) {
+	// identification: /XXXZ...
+	if len(ident) < 5 || ident[4] < '0' || ident[4] > '6' {
+		return "", false
+	}
+
+	z := slices.Index(modeCBaudrates, baudrate)
+	if z < 0 || z > int(ident[4]-'0') {
+		return "", false
+	}
+
+	// protocol control 0 (normal), baud rate, mode 0 (data readout)
+	return fmt.Sprintf("\x060%d0\r\n", z), true
+}
+
+// ReadTelegram reads the next IEC 62056-21 data readout.
+// Mode C meters are acknowledged via w to send the readout at the current baud rate.
+func ReadTelegram(r *bufio.Reader, w io.Writer, baudrate int) ([]Value, error) {
+	var (
+		res     []Value
+		started bool
+	)
+
+	for {
+		b, err := r.ReadSlice('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line := strings.TrimFunc(string(b), func(r rune) bool {
+			return r < ' '
+		})
+
+		switch {
+		// identification
+		case strings.HasPrefix(line, "/"):
+			res, started = nil, true
+
+			if ack, ok := Ack(line, baudrate); ok && w != nil {
+				if _, err := io.WriteString(w, ack); err != nil {
+					return nil, err
+				}
+			}
+
+		// end of data
+		case strings.HasPrefix(line, "!"):
+			if !started {
+				continue
+			}
+			if len(res) == 0 {
+				return nil, errors.New("empty telegram")
+			}
+			return res, nil
+
+		case started:
+			if len(res) >= maxDatasets {
+				return nil, errors.New("telegram too large")
+			}
+
+			if v, ok := parseDataset(line); ok {
+				res = append(res, v)
+			}
+		}
+	}
+}
+
+// parseDataset parses a data set like 1-0:1.8.0*255(001234.5678*kWh)
+func parseDataset(line string) (Value, bool) {
+	id, val, ok := strings.Cut(line, "(")
+	if !ok {
+		return Value{}, false
+	}
+
+	// omit billing period
+	id, _, _ = strings.Cut(id, "*")
+	id, _, _ = strings.Cut(id, "&")
+
+	// electricity is implied if medium and channel are missing
+	if !strings.Contains(id, ":") {
+		id = "1-0:" + id
+	}
+
+	val, _, _ = strings.Cut(val, ")")
+	val, unit, _ := strings.Cut(val, "*")
+
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return Value{}, false
+	}
+
+	switch strings.ToLower(unit) {
+	case "wh":
+		f /= 1e3
+	case "kw", "kvar", "kva":
+		f *= 1e3
+	}
+
+	return Value{Obis: id, Value: f}, true
+}
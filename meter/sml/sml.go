@@ -0,0 +1,348 @@
+package sml
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// SML (Smart Message Language) transport and message decoding
+// https://www.bsi.bund.de/SharedDocs/Downloads/DE/BSI/Publikationen/TechnischeRichtlinien/TR03109/TR-03109-1_Anlage_Feinspezifikation_Drahtgebundene_LMN-Schnittstelle_Teilb.pdf
+
+var (
+	escape = []byte{0x1b, 0x1b, 0x1b, 0x1b}
+	start  = []byte{0x01, 0x01, 0x01, 0x01}
+)
+
+const (
+	typeOctets   = 0x00
+	typeInt      = 0x50
+	typeUint     = 0x60
+	typeList     = 0x70
+	typeMask     = 0x70
+	moreTL       = 0x80
+	endOfMessage = 0x00
+
+	getListResponse = 0x0701
+
+	unitWh = 30 // DLMS unit code
+
+	maxFrameSize = 8 << 10 // upper limit for escaped frames
+	maxDepth     = 16      // upper limit for nested lists
+)
+
+// ErrCrc indicates a corrupted frame
+var ErrCrc = errors.New("crc mismatch")
+
+// ReadFrame reads the next SML transport frame and returns the unescaped message payload
+func ReadFrame(r *bufio.Reader) ([]byte, error) {
+	if err := seek(r); err != nil {
+		return nil, err
+	}
+
+	raw := append(bytes.Clone(escape), start...)
+	var payload []byte
+
+	block := make([]byte, 4)
+
+	for {
+		if _, err := io.ReadFull(r, block); err != nil {
+			return nil, err
+		}
+		raw = append(raw, block...)
+
+		if len(raw) > maxFrameSize {
+			return nil, fmt.Errorf("frame too large: %d bytes", len(raw))
+		}
+
+		if !bytes.Equal(block, escape) {
+			payload = append(payload, block...)
+			continue
+		}
+
+		if _, err := io.ReadFull(r, block); err != nil {
+			return nil, err
+		}
+
+		switch {
+		// escaped escape sequence
+		case bytes.Equal(block, escape):
+			raw = append(raw, block...)
+			payload = append(payload, block...)
+
+		// restart
+		case bytes.Equal(block, start):
+			raw = append(bytes.Clone(escape), start...)
+			payload = payload[:0]
+
+		// end of frame
+		case block[0] == 0x1a:
+			raw = append(raw, block[:2]...)
+
+			if crc := Crc16(raw); crc != binary.LittleEndian.Uint16(block[2:]) {
+				return nil, fmt.Errorf("%w: %04x != %04x", ErrCrc, crc, binary.LittleEndian.Uint16(block[2:]))
+			}
+
+			pad := int(block[1])
+			if pad > len(payload) {
+				return nil, fmt.Errorf("invalid padding: %d", pad)
+			}
+
+			return payload[:len(payload)-pad], nil
+
+		default:
+			return nil, fmt.Errorf("invalid escape sequence: % x", block)
+		}
+	}
+}
+
+// seek discards input until the frame start sequence has been read
+func seek(r *bufio.Reader) error {
+	seq := append(bytes.Clone(escape), start...)
+
+	for matched := 0; matched < len(seq); {
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case b == seq[matched]:
+			matched++
+		case b == seq[0] && matched == len(escape):
+			// longer run of escape bytes
+		case b == seq[0]:
+			matched = 1
+		default:
+			matched = 0
+		}
+	}
+
+	return nil
+}
+
+// Crc16 is the CRC-16/X-25 checksum used by SML
+func Crc16(b []byte) uint16 {
+	crc := uint16(0xffff)
+
+	for _, c := range b {
+		crc ^= uint16(c)
+		for range 8 {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0x8408
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+
+	return ^crc
+}
+
+// node is a decoded SML type-length-value element
+type node struct {
+	typ  byte
+	data []byte // empty for optional elements not present
+	list []node
+}
+
+func (n node) uint() (uint64, bool) {
+	if n.typ != typeUint || len(n.data) == 0 || len(n.data) > 8 {
+		return 0, false
+	}
+
+	var res uint64
+	for _, b := range n.data {
+		res = res<<8 | uint64(b)
+	}
+
+	return res, true
+}
+
+func (n node) int() (int64, bool) {
+	if n.typ != typeInt || len(n.data) == 0 || len(n.data) > 8 {
+		return 0, false
+	}
+
+	// sign extension
+	var res int64
+	if n.data[0]&0x80 != 0 {
+		res = -1
+	}
+	for _, b := range n.data {
+		res = res<<8 | int64(b)
+	}
+
+	return res, true
+}
+
+// number returns integer values as float
+func (n node) number() (float64, bool) {
+	if i, ok := n.int(); ok {
+		return float64(i), true
+	}
+	if u, ok := n.uint(); ok {
+		return float64(u), true
+	}
+	return 0, false
+}
+
+type decoder struct {
+	buf []byte
+	pos int
+}
+
+func (d *decoder) byte() (byte, error) {
+	if d.pos >= len(d.buf) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	b := d.buf[d.pos]
+	d.pos++
+
+	return b, nil
+}
+
+// next decodes the next element. The end of message marker is returned as empty octet string.
+func (d *decoder) next() (node, error) {
+	return d.element(0)
+}
+
+func (d *decoder) element(depth int) (node, error) {
+	if depth > maxDepth {
+		return node{}, errors.New("nesting too deep")
+	}
+
+	b, err := d.byte()
+	if err != nil {
+		return node{}, err
+	}
+
+	if b == endOfMessage {
+		return node{typ: typeOctets}, nil
+	}
+
+	typ := b & typeMask
+	length := int(b & 0x0f)
+	tl := 1
+
+	for b&moreTL != 0 {
+		if b, err = d.byte(); err != nil {
+			return node{}, err
+		}
+
+		length = length<<4 | int(b&0x0f)
+		tl++
+
+		if length > len(d.buf) {
+			return node{}, io.ErrUnexpectedEOF
+		}
+	}
+
+	if typ == typeList {
+		// each element takes at least one byte
+		if length > len(d.buf)-d.pos {
+			return node{}, io.ErrUnexpectedEOF
+		}
+
+		res := node{typ: typ, list: make([]node, 0, length)}
+
+		for range length {
+			n, err := d.element(depth + 1)
+			if err != nil {
+				return node{}, err
+			}
+			res.list = append(res.list, n)
+		}
+
+		return res, nil
+	}
+
+	// length includes type-length field
+	length -= tl
+	if length < 0 || d.pos+length > len(d.buf) {
+		return node{}, io.ErrUnexpectedEOF
+	}
+
+	res := node{typ: typ, data: d.buf[d.pos : d.pos+length]}
+	d.pos += length
+
+	return res, nil
+}
+
+// Value is a decoded meter reading. Power is in W, energy in kWh.
+type Value struct {
+	Obis  string
+	Value float64
+}
+
+// Obis formats the OBIS code omitting the billing period
+func Obis(b []byte) string {
+	if len(b) < 5 {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d:%d.%d.%d", b[0], b[1], b[2], b[3], b[4])
+}
+
+// Decode decodes the numeric values of all get list response messages in the payload
+func Decode(payload []byte) ([]Value, error) {
+	d := &decoder{buf: payload}
+
+	var res []Value
+
+	for d.pos < len(d.buf) {
+		msg, err := d.next()
+		if err != nil {
+			return nil, err
+		}
+
+		// padding
+		if msg.typ != typeList {
+			continue
+		}
+
+		// transaction id, group no, abort on error, body, crc, end of message
+		if len(msg.list) < 4 || len(msg.list[3].list) != 2 {
+			return nil, errors.New("invalid message")
+		}
+
+		body := msg.list[3].list
+		if tag, _ := body[0].uint(); tag != getListResponse {
+			continue
+		}
+
+		// client id, server id, list name, sensor time, value list, signature, gateway time
+		if len(body[1].list) < 5 {
+			return nil, errors.New("invalid get list response")
+		}
+
+		for _, entry := range body[1].list[4].list {
+			// obis, status, time, unit, scaler, value, signature
+			if len(entry.list) < 6 {
+				continue
+			}
+
+			obis := Obis(entry.list[0].data)
+			val, ok := entry.list[5].number()
+			if obis == "" || !ok {
+				continue
+			}
+
+			if scaler, ok := entry.list[4].int(); ok {
+				val *= math.Pow10(int(scaler))
+			}
+
+			if unit, _ := entry.list[3].uint(); unit == unitWh {
+				val /= 1e3
+			}
+
+			res = append(res, Value{Obis: obis, Value: val})
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,158 @@
+package sml
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tl encodes a single byte type-length element
+func tl(typ byte, data ...byte) []byte {
+	return append([]byte{typ | byte(len(data)+1)}, data...)
+}
+
+func list(elements ...[]byte) []byte {
+	return append([]byte{typeList | byte(len(elements))}, bytes.Join(elements, nil)...)
+}
+
+var optional = []byte{0x01}
+
+// entry encodes a value list entry
+func entry(obis []byte, unit, scaler byte, value []byte) []byte {
+	return list(tl(typeOctets, obis...), optional, optional, tl(typeUint, unit), tl(typeInt, scaler), value, optional)
+}
+
+// frame wraps payload into an escaped transport frame
+func frame(payload []byte) []byte {
+	pad := (4 - len(payload)%4) % 4
+	payload = append(payload, make([]byte, pad)...)
+
+	res := append(bytes.Clone(escape), start...)
+	for i := 0; i < len(payload); i += 4 {
+		block := payload[i : i+4]
+		res = append(res, block...)
+		if bytes.Equal(block, escape) {
+			res = append(res, escape...)
+		}
+	}
+
+	res = append(res, escape...)
+	res = append(res, 0x1a, byte(pad))
+
+	return binary.LittleEndian.AppendUint16(res, Crc16(res))
+}
+
+func getListResponseMessage(entries ...[]byte) []byte {
+	body := list(optional, tl(typeOctets, 0x0a, 0x01), optional, optional, list(entries...), optional, optional)
+	return list(tl(typeOctets, 0x01), tl(typeUint, 0x00), tl(typeUint, 0x00), list(tl(typeUint, 0x07, 0x01), body), tl(typeUint, 0x12, 0x34), []byte{endOfMessage})
+}
+
+func TestCrc16(t *testing.T) {
+	assert.Equal(t, uint16(0x906e), Crc16([]byte("123456789")))
+}
+
+func TestDecode(t *testing.T) {
+	openResponse := list(tl(typeOctets, 0x01), tl(typeUint, 0x00), tl(typeUint, 0x00), list(tl(typeUint, 0x01, 0x01), list(optional)), tl(typeUint, 0x00, 0x00), []byte{endOfMessage})
+
+	msg := getListResponseMessage(
+		// 12345678.9 Wh
+		entry([]byte{1, 0, 1, 8, 0, 255}, unitWh, 0xff, tl(typeUint, 0x07, 0x5b, 0xcd, 0x15)),
+		// -345 W
+		entry([]byte{1, 0, 16, 7, 0, 255}, 27, 0x00, tl(typeInt, 0xfe, 0xa7)),
+		// escape sequence in payload, 0x1b1b1b1b * 0.01 A
+		entry([]byte{1, 0, 31, 7, 0, 255}, 33, 0xfe, tl(typeUint, 0x1b, 0x1b, 0x1b, 0x1b)),
+		// non-numeric value
+		entry([]byte{1, 0, 96, 1, 0, 255}, 0, 0, tl(typeOctets, 'x')),
+	)
+
+	r := bufio.NewReader(bytes.NewReader(append([]byte{0x00, 0x1b, 0x1b}, frame(append(openResponse, msg...))...)))
+
+	payload, err := ReadFrame(r)
+	require.NoError(t, err)
+
+	res, err := Decode(payload)
+	require.NoError(t, err)
+
+	require.Len(t, res, 3)
+	assert.Equal(t, "1-0:1.8.0", res[0].Obis)
+	assert.InDelta(t, 12345.6789, res[0].Value, 1e-6)
+	assert.Equal(t, Value{Obis: "1-0:16.7.0", Value: -345}, res[1])
+	assert.InDelta(t, float64(0x1b1b1b1b)/100, res[2].Value, 1e-6)
+}
+
+func TestReadFrameCrc(t *testing.T) {
+	b := frame(getListResponseMessage())
+	b[len(b)-1] ^= 0xff
+
+	_, err := ReadFrame(bufio.NewReader(bytes.NewReader(b)))
+	assert.ErrorIs(t, err, ErrCrc)
+}
+
+func TestReadTelegram(t *testing.T) {
+	telegram := strings.Join([]string{
+		"garbage",
+		"/ESY5Q3DA1004 V3.04",
+		"",
+		"\x021-0:0.0.0*255(1ESY1160000000)",
+		"1-0:1.8.0*255(00012345.6789*kWh)",
+		"1-0:2.8.0*255(00000012.3000*kWh)",
+		"1-0:16.7.0*255(-000345.12*W)",
+		"31.7.0(001.23*A)",
+		"1-0:1.7.0(0.45*kW)",
+		"!",
+		"\x03x",
+	}, "\r\n")
+
+	res, err := ReadTelegram(bufio.NewReader(strings.NewReader(telegram)), nil, 300)
+	require.NoError(t, err)
+
+	// non-numeric values are skipped
+	assert.Equal(t, []Value{
+		{"1-0:1.8.0", 12345.6789},
+		{"1-0:2.8.0", 12.3},
+		{"1-0:16.7.0", -345.12},
+		{"1-0:31.7.0", 1.23},
+		{"1-0:1.7.0", 450},
+	}, res)
+}
+
+func TestDecodeInvalidLength(t *testing.T) {
+	// list length exceeding the payload
+	_, err := Decode(append(append([]byte{0xff}, bytes.Repeat([]byte{0x8f}, 15)...), 0x0f))
+	assert.Error(t, err)
+
+	// deeply nested lists
+	_, err = Decode(bytes.Repeat([]byte{0x71}, 100))
+	assert.Error(t, err)
+}
+
+func TestReadFrameTooLarge(t *testing.T) {
+	b := append(bytes.Clone(escape), start...)
+	b = append(b, bytes.Repeat([]byte{0x01}, 2*maxFrameSize)...)
+
+	_, err := ReadFrame(bufio.NewReader(bytes.NewReader(b)))
+	assert.ErrorContains(t, err, "frame too large")
+}
+
+func TestAck(t *testing.T) {
+	ack, ok := Ack("/ESY5Q3DA1004 V3.04", 300)
+	assert.True(t, ok)
+	assert.Equal(t, "\x06000\r\n", ack)
+
+	ack, ok = Ack("/ESY5Q3DA1004 V3.04", 9600)
+	assert.True(t, ok)
+	assert.Equal(t, "\x06050\r\n", ack)
+
+	// exceeds meter baud rate
+	_, ok = Ack("/ESY3Q3DA1004 V3.04", 9600)
+	assert.False(t, ok)
+
+	// mode B
+	_, ok = Ack("/ESYEQ3DA1004 V3.04", 300)
+	assert.False(t, ok)
+}
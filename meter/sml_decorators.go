@@ -0,0 +1,69 @@
+package meter
+
+// Code generated by github.com/evcc-io/evcc/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decorateSml(base api.Meter, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error)) api.Meter {
+	switch {
+	case meterEnergy == nil && phaseCurrents == nil:
+		return base
+
+	case meterEnergy != nil && phaseCurrents == nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+		}{
+			Meter: base,
+			MeterEnergy: &decorateSmlMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case meterEnergy == nil && phaseCurrents != nil:
+		return &struct {
+			api.Meter
+			api.PhaseCurrents
+		}{
+			Meter: base,
+			PhaseCurrents: &decorateSmlPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+
+	case meterEnergy != nil && phaseCurrents != nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.PhaseCurrents
+		}{
+			Meter: base,
+			MeterEnergy: &decorateSmlMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decorateSmlPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decorateSmlMeterEnergyImpl struct {
+	meterEnergy func() (float64, error)
+}
+
+func (impl *decorateSmlMeterEnergyImpl) TotalEnergy() (float64, error) {
+	return impl.meterEnergy()
+}
+
+type decorateSmlPhaseCurrentsImpl struct {
+	phaseCurrents func() (float64, float64, float64, error)
+}
+
+func (impl *decorateSmlPhaseCurrentsImpl) Currents() (float64, float64, float64, error) {
+	return impl.phaseCurrents()
+}
@@ -0,0 +1,86 @@
+package plugin
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/mbus"
+)
+
+// MBus implements wired and wireless M-Bus access
+type MBus struct {
+	telegram func() (*mbus.Telegram, error)
+	value    mbus.Quantity
+	index    int
+	scale    float64
+}
+
+func init() {
+	registry.Add("mbus", NewMBusFromConfig)
+}
+
+// NewMBusFromConfig creates M-Bus plugin
+func NewMBusFromConfig(other map[string]interface{}) (Plugin, error) {
+	cc := struct {
+		mbus.Settings `mapstructure:",squash"`
+		Value         string // quantity
+		Index         int    // index of records of same quantity, e.g. phase
+		Scale         float64
+		Cache         time.Duration
+	}{
+		Scale: 1,
+		Cache: time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Value == "" {
+		return nil, errors.New("missing value")
+	}
+
+	telegram, err := cc.Settings.Reader()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &MBus{
+		telegram: util.Cached(telegram, cc.Cache),
+		value:    mbus.Quantity(cc.Value),
+		index:    cc.Index,
+		scale:    cc.Scale,
+	}
+
+	return p, nil
+}
+
+var _ FloatGetter = (*MBus)(nil)
+
+// FloatGetter implements func() (float64, error)
+func (p *MBus) FloatGetter() (func() (float64, error), error) {
+	return func() (float64, error) {
+		t, err := p.telegram()
+		if err != nil {
+			return 0, err
+		}
+
+		r, err := t.Find(p.value, p.index)
+
+		return p.scale * r.Value, err
+	}, nil
+}
+
+var _ IntGetter = (*MBus)(nil)
+
+// IntGetter implements func() (int64, error)
+func (p *MBus) IntGetter() (func() (int64, error), error) {
+	g, err := p.FloatGetter()
+
+	return func() (int64, error) {
+		res, err := g()
+		return int64(math.Round(res)), err
+	}, err
+}
@@ -7,6 +7,7 @@ import (
 
 	eapi "github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/evcc-io/evcc/charger/ocpp"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/assets"
@@ -101,22 +102,29 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 
 	// site api
 	routes := map[string]route{
-		"health":                  {"GET", "/health", healthHandler(site)},
-		"buffersoc":               {"POST", "/buffersoc/{value:[0-9.]+}", floatHandler(site.SetBufferSoc, site.GetBufferSoc)},
-		"bufferstartsoc":          {"POST", "/bufferstartsoc/{value:[0-9.]+}", floatHandler(site.SetBufferStartSoc, site.GetBufferStartSoc)},
-		"batterydischargecontrol": {"POST", "/batterydischargecontrol/{value:[01truefalse]+}", boolHandler(site.SetBatteryDischargeControl, site.GetBatteryDischargeControl)},
-		"batterygridcharge":       {"POST", "/batterygridchargelimit/{value:-?[0-9.]+}", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
-		"batterygridchargedelete": {"DELETE", "/batterygridchargelimit", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
-		"prioritysoc":             {"POST", "/prioritysoc/{value:[0-9.]+}", floatHandler(site.SetPrioritySoc, site.GetPrioritySoc)},
-		"residualpower":           {"POST", "/residualpower/{value:-?[0-9.]+}", floatHandler(site.SetResidualPower, site.GetResidualPower)},
-		"smartcost":               {"POST", "/smartcostlimit/{value:-?[0-9.]+}", updateSmartCostLimit(site)},
-		"smartcostdelete":         {"DELETE", "/smartcostlimit", updateSmartCostLimit(site)},
-		"tariff":                  {"GET", "/tariff/{tariff:[a-z]+}", tariffHandler(site)},
-		"sessions":                {"GET", "/sessions", sessionHandler},
-		"updatesession":           {"PUT", "/session/{id:[0-9]+}", updateSessionHandler},
-		"deletesession":           {"DELETE", "/session/{id:[0-9]+}", deleteSessionHandler},
-		"telemetry":               {"GET", "/settings/telemetry", getHandler(telemetry.Enabled)},
-		"telemetry2":              {"POST", "/settings/telemetry/{value:[01truefalse]+}", boolHandler(telemetry.Enable, telemetry.Enabled)},
+		"health":                     {"GET", "/health", healthHandler(site)},
+		"buffersoc":                  {"POST", "/buffersoc/{value:[0-9.]+}", floatHandler(site.SetBufferSoc, site.GetBufferSoc)},
+		"bufferstartsoc":             {"POST", "/bufferstartsoc/{value:[0-9.]+}", floatHandler(site.SetBufferStartSoc, site.GetBufferStartSoc)},
+		"batterydischargecontrol":    {"POST", "/batterydischargecontrol/{value:[01truefalse]+}", boolHandler(site.SetBatteryDischargeControl, site.GetBatteryDischargeControl)},
+		"batterygridcharge":          {"POST", "/batterygridchargelimit/{value:-?[0-9.]+}", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
+		"batterygridchargedelete":    {"DELETE", "/batterygridchargelimit", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
+		"batterygriddischarge":       {"POST", "/batterygriddischargelimit/{value:-?[0-9.]+}", floatPtrHandler(pass(site.SetBatteryGridDischargeLimit), site.GetBatteryGridDischargeLimit)},
+		"batterygriddischargedelete": {"DELETE", "/batterygriddischargelimit", floatPtrHandler(pass(site.SetBatteryGridDischargeLimit), site.GetBatteryGridDischargeLimit)},
+		"batterydischargepower":      {"POST", "/batterydischargepower/{value:[0-9.]+}", floatHandler(site.SetBatteryDischargePower, site.GetBatteryDischargePower)},
+		"batterymaxchargepower":      {"POST", "/batterymaxchargepower/{value:[0-9.]+}", floatHandler(site.SetBatteryMaxChargePower, site.GetBatteryMaxChargePower)},
+		"batteryplan":                {"GET", "/battery/plan", getHandler(site.GetBatteryPlan)},
+		"prioritysoc":                {"POST", "/prioritysoc/{value:[0-9.]+}", floatHandler(site.SetPrioritySoc, site.GetPrioritySoc)},
+		"residualpower":              {"POST", "/residualpower/{value:-?[0-9.]+}", floatHandler(site.SetResidualPower, site.GetResidualPower)},
+		"smartcost":                  {"POST", "/smartcostlimit/{value:-?[0-9.]+}", updateSmartCostLimit(site)},
+		"smartcostdelete":            {"DELETE", "/smartcostlimit", updateSmartCostLimit(site)},
+		"tariff":                     {"GET", "/tariff/{tariff:[a-z]+}", tariffHandler(site)},
+		"history":                    {"GET", "/history", historyHandler},
+		"sessions":                   {"GET", "/sessions", sessionHandler},
+		"sessionreport":              {"GET", "/sessions/report", sessionReportHandler},
+		"updatesession":              {"PUT", "/session/{id:[0-9]+}", updateSessionHandler},
+		"deletesession":              {"DELETE", "/session/{id:[0-9]+}", deleteSessionHandler},
+		"telemetry":                  {"GET", "/settings/telemetry", getHandler(telemetry.Enabled)},
+		"telemetry2":                 {"POST", "/settings/telemetry/{value:[01truefalse]+}", boolHandler(telemetry.Enable, telemetry.Enabled)},
 	}
 
 	for _, r := range routes {
@@ -167,6 +175,7 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 			"mincurrent":           {"POST", "/mincurrent/{value:[0-9.]+}", floatHandler(lp.SetMinCurrent, lp.GetMinCurrent)},
 			"maxcurrent":           {"POST", "/maxcurrent/{value:[0-9.]+}", floatHandler(lp.SetMaxCurrent, lp.GetMaxCurrent)},
 			"phases":               {"POST", "/phases/{value:[0-9]+}", intHandler(lp.SetPhasesConfigured, lp.GetPhasesConfigured)},
+			"phaserotation":        {"POST", "/phaserotation/{value:[0-3]}", intHandler(lp.SetPhaseRotation, lp.GetPhaseRotation)},
 			"plan":                 {"GET", "/plan", planHandler(lp)},
 			"staticPlanPreview":    {"GET", "/plan/static/preview/{type:(?:soc|energy)}/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", staticPlanPreviewHandler(lp)},
 			"repeatingPlanPreview": {"GET", "/plan/repeating/preview/{soc:[0-9]+}/{weekdays:[0-6,]+}/{time:[0-2][0-9]:[0-5][0-9]}/{tz:[a-zA-Z0-9_./:-]+}", repeatingPlanPreviewHandler(lp)},
@@ -207,6 +216,11 @@ func (s *HTTPd) RegisterSystemHandler(valueChan chan<- util.Param, cache *util.P
 	{ // /api
 		routes := map[string]route{
 			"state": {"GET", "/state", stateHandler(cache)},
+
+			// ocpp file transfer, charge points authenticate by token
+			"ocppdownload": {"GET", "/ocpp/files/{token:[0-9a-f]+}/{name}", ocppFileDownloadHandler},
+			"ocppupload":   {"POST", "/ocpp/files/{token:[0-9a-f]+}/{name:.*}", ocppFileUploadHandler},
+			"ocppupload2":  {"PUT", "/ocpp/files/{token:[0-9a-f]+}/{name:.*}", ocppFileUploadHandler},
 		}
 
 		for _, r := range routes {
@@ -235,25 +249,34 @@ func (s *HTTPd) RegisterSystemHandler(valueChan chan<- util.Param, cache *util.P
 		api.Use(ensureAuthHandler(auth))
 
 		routes := map[string]route{
-			"templates":          {"GET", "/templates/{class:[a-z]+}", templatesHandler},
-			"products":           {"GET", "/products/{class:[a-z]+}", productsHandler},
-			"devices":            {"GET", "/devices/{class:[a-z]+}", devicesConfigHandler},
-			"device":             {"GET", "/devices/{class:[a-z]+}/{id:[0-9.]+}", deviceConfigHandler},
-			"devicestatus":       {"GET", "/devices/{class:[a-z]+}/{name:[a-zA-Z0-9_.:-]+}/status", deviceStatusHandler},
-			"dirty":              {"GET", "/dirty", getHandler(ConfigDirty)},
-			"newdevice":          {"POST", "/devices/{class:[a-z]+}", newDeviceHandler},
-			"updatedevice":       {"PUT", "/devices/{class:[a-z]+}/{id:[0-9.]+}", updateDeviceHandler},
-			"deletedevice":       {"DELETE", "/devices/{class:[a-z]+}/{id:[0-9.]+}", deleteDeviceHandler},
-			"testconfig":         {"POST", "/test/{class:[a-z]+}", testConfigHandler},
-			"testmerged":         {"POST", "/test/{class:[a-z]+}/merge/{id:[0-9.]+}", testConfigHandler},
-			"interval":           {"POST", "/interval/{value:[0-9.]+}", settingsSetDurationHandler(keys.Interval)},
-			"updatesponsortoken": {"POST", "/sponsortoken", updateSponsortokenHandler},
-			"deletesponsortoken": {"DELETE", "/sponsortoken", deleteSponsorTokenHandler},
+			"templates":           {"GET", "/templates/{class:[a-z]+}", templatesHandler},
+			"products":            {"GET", "/products/{class:[a-z]+}", productsHandler},
+			"devices":             {"GET", "/devices/{class:[a-z]+}", devicesConfigHandler},
+			"device":              {"GET", "/devices/{class:[a-z]+}/{id:[0-9.]+}", deviceConfigHandler},
+			"devicestatus":        {"GET", "/devices/{class:[a-z]+}/{name:[a-zA-Z0-9_.:-]+}/status", deviceStatusHandler},
+			"dirty":               {"GET", "/dirty", getHandler(ConfigDirty)},
+			"newdevice":           {"POST", "/devices/{class:[a-z]+}", newDeviceHandler},
+			"updatedevice":        {"PUT", "/devices/{class:[a-z]+}/{id:[0-9.]+}", updateDeviceHandler},
+			"deletedevice":        {"DELETE", "/devices/{class:[a-z]+}/{id:[0-9.]+}", deleteDeviceHandler},
+			"testconfig":          {"POST", "/test/{class:[a-z]+}", testConfigHandler},
+			"testmerged":          {"POST", "/test/{class:[a-z]+}/merge/{id:[0-9.]+}", testConfigHandler},
+			"interval":            {"POST", "/interval/{value:[0-9.]+}", settingsSetDurationHandler(keys.Interval)},
+			"updatesponsortoken":  {"POST", "/sponsortoken", updateSponsortokenHandler},
+			"deletesponsortoken":  {"DELETE", "/sponsortoken", deleteSponsorTokenHandler},
+			"updateocpppassword":  {"POST", "/ocpp/{id:[a-zA-Z0-9_.:-]+}/password", updateOcppPasswordHandler},
+			"deleteocpppassword":  {"DELETE", "/ocpp/{id:[a-zA-Z0-9_.:-]+}/password", deleteOcppPasswordHandler},
+			"ocppaudit":           {"GET", "/ocpp/audit", ocppAuditHandler},
+			"ocppmaintenance":     {"GET", "/ocpp/{id:[a-zA-Z0-9_.:-]+}/maintenance", ocppMaintenanceHandler},
+			"ocppfirmware":        {"POST", "/ocpp/{id:[a-zA-Z0-9_.:-]+}/firmware", ocppUpdateFirmwareHandler},
+			"ocppgetdiagnostics":  {"POST", "/ocpp/{id:[a-zA-Z0-9_.:-]+}/diagnostics", ocppGetDiagnosticsHandler},
+			"ocppdiagnostics":     {"GET", "/ocpp/{id:[a-zA-Z0-9_.:-]+}/diagnostics", ocppDiagnosticsHandler},
+			"ocppdiagnosticsfile": {"GET", "/ocpp/{id:[a-zA-Z0-9_.:-]+}/diagnostics/{name}", ocppDiagnosticsFileHandler},
 		}
 
 		// yaml handlers
 		for key, fun := range map[string]func() (any, any){
 			keys.EEBus:       func() (any, any) { return map[string]any{}, eebus.Config{} },
+			keys.Ocpp:        func() (any, any) { return map[string]any{}, ocpp.Config{} },
 			keys.Hems:        func() (any, any) { return map[string]any{}, config.Typed{} },
 			keys.Tariffs:     func() (any, any) { return map[string]any{}, globalconfig.Tariffs{} },
 			keys.Messaging:   func() (any, any) { return map[string]any{}, globalconfig.Messaging{} },       // has default
@@ -27,11 +27,13 @@ func getLoadpointStaticConfig(lp loadpoint.API) loadpoint.StaticConfig {
 
 func getLoadpointDynamicConfig(lp loadpoint.API) loadpoint.DynamicConfig {
 	planTime, planEnergy := lp.GetPlanEnergy()
+	phaseRotation := lp.GetPhaseRotation()
 	return loadpoint.DynamicConfig{
 		Title:            lp.GetTitle(),
 		DefaultMode:      string(lp.GetDefaultMode()),
 		Priority:         lp.GetPriority(),
 		PhasesConfigured: lp.GetPhasesConfigured(),
+		PhaseRotation:    &phaseRotation,
 		MinCurrent:       lp.GetMinCurrent(),
 		MaxCurrent:       lp.GetMaxCurrent(),
 		SmartCostLimit:   lp.GetSmartCostLimit(),
@@ -0,0 +1,244 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/charger/ocpp"
+	"github.com/gorilla/mux"
+)
+
+// updateOcppPasswordHandler sets the charge point's basic auth password
+func updateOcppPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Password string `json:"password"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if err := ocpp.SetPassword(id, req.Password); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, ocpp.HasPassword(id))
+}
+
+// deleteOcppPasswordHandler removes the charge point's basic auth password
+func deleteOcppPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	if err := ocpp.SetPassword(mux.Vars(r)["id"], ""); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, true)
+}
+
+// ocppAuditHandler returns the most recent charge point connection attempts
+func ocppAuditHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResult(w, ocpp.Audit())
+}
+
+// ocppMaintenanceHandler returns the charge point's firmware and diagnostics state
+func ocppMaintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	cp, err := ocpp.Instance().ChargepointByID(mux.Vars(r)["id"])
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	jsonResult(w, cp.Maintenance())
+}
+
+// ocppUpdateFirmwareHandler triggers a firmware update either from an external location or an uploaded firmware image
+func ocppUpdateFirmwareHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	cp, err := ocpp.Instance().ChargepointByID(id)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var req struct {
+		Location     string    `json:"location"`
+		RetrieveDate time.Time `json:"retrieveDate"`
+	}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+		defer file.Close()
+
+		path, err := ocpp.PublishFirmware(id, header.Filename, file)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		req.Location = ocpp.TransferURL(path)
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Location == "" {
+		jsonError(w, http.StatusBadRequest, errors.New("missing location"))
+		return
+	}
+
+	if err := cp.UpdateFirmwareRequest(req.Location, req.RetrieveDate); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, cp.Maintenance())
+}
+
+// ocppGetDiagnosticsHandler requests diagnostics upload, by default to the evcc file transfer endpoint
+func ocppGetDiagnosticsHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	cp, err := ocpp.Instance().ChargepointByID(id)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var req struct {
+		Location string `json:"location"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Location == "" {
+		path, err := ocpp.DiagnosticsUpload(id)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		req.Location = ocpp.TransferURL(path)
+	}
+
+	if _, err := cp.GetDiagnosticsRequest(req.Location); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, cp.Maintenance())
+}
+
+// ocppDiagnosticsHandler lists the charge point's received diagnostics files
+func ocppDiagnosticsHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := ocpp.Diagnostics(mux.Vars(r)["id"])
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// ocppDiagnosticsFileHandler downloads a received diagnostics file
+func ocppDiagnosticsFileHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	f, err := ocpp.OpenDiagnostics(vars["id"], vars["name"])
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+fi.Name()+`"`)
+	http.ServeContent(w, r, fi.Name(), fi.ModTime(), f)
+}
+
+// ocppFileDownloadHandler serves published firmware images to charge points
+func ocppFileDownloadHandler(w http.ResponseWriter, r *http.Request) {
+	f, err := ocpp.OpenFirmware(mux.Vars(r)["token"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, fi.Name(), fi.ModTime(), f)
+}
+
+// ocppMaxUploadSize limits the size of diagnostics uploaded by charge points
+const ocppMaxUploadSize = 64 << 20
+
+// ocppFileUploadHandler receives diagnostics uploaded by charge points either as raw body or multipart form
+func ocppFileUploadHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token, name := vars["token"], vars["name"]
+
+	r.Body = http.MaxBytesReader(w, r.Body, ocppMaxUploadSize)
+
+	if mr, err := r.MultipartReader(); err == nil {
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				http.Error(w, "missing file", http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if part.FileName() == "" {
+				continue
+			}
+
+			if name == "" {
+				name = part.FileName()
+			}
+
+			_, err = ocpp.ReceiveDiagnostics(token, name, part)
+			part.Close()
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			break
+		}
+	} else if _, err := ocpp.ReceiveDiagnostics(token, name, r.Body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
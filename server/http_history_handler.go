@@ -0,0 +1,70 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/core/history"
+	"github.com/evcc-io/evcc/server/db"
+)
+
+// parseHistoryTime parses RFC3339 timestamps or local dates
+func parseHistoryTime(s string) (time.Time, bool, error) {
+	if ts, err := time.Parse(time.RFC3339, s); err == nil {
+		return ts, false, nil
+	}
+
+	ts, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	return ts, true, err
+}
+
+// historyHandler returns the aggregated energy history
+func historyHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	q := r.URL.Query()
+
+	aggregate, err := history.ParseAggregate(q.Get("aggregate"))
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	query := history.Query{
+		Meter:     q.Get("meter"),
+		Name:      q.Get("name"),
+		Aggregate: aggregate,
+	}
+
+	if from := q.Get("from"); from != "" {
+		if query.From, _, err = parseHistoryTime(from); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	if to := q.Get("to"); to != "" {
+		var date bool
+		if query.To, date, err = parseHistoryTime(to); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		// inclusive end date
+		if date {
+			query.To = query.To.AddDate(0, 0, 1)
+		}
+	}
+
+	res, err := history.Entries(db.Instance, query)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
@@ -8,8 +8,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/util"
 	"github.com/gorilla/mux"
@@ -41,6 +41,28 @@ func remoteDemandHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// planResponse is the plan and its key figures as returned by the plan and preview handlers
+type planResponse struct {
+	PlanId    *int         `json:"planId,omitempty"` // active plan only
+	PlanTime  time.Time    `json:"planTime"`
+	Duration  int64        `json:"duration"`
+	Plan      planner.Plan `json:"plan"`
+	Power     float64      `json:"power"`
+	GridShare float64      `json:"gridShare"`
+	Cost      float64      `json:"cost"`
+}
+
+func newPlanResponse(planTime time.Time, requiredDuration time.Duration, plan planner.Plan, maxPower float64) planResponse {
+	return planResponse{
+		PlanTime:  planTime,
+		Duration:  int64(requiredDuration.Seconds()),
+		Plan:      plan,
+		Power:     maxPower,
+		GridShare: plan.GridShare(),
+		Cost:      plan.Cost(),
+	}
+}
+
 // planHandler returns the current plan
 func planHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -56,19 +78,8 @@ func planHandler(lp loadpoint.API) http.HandlerFunc {
 			return
 		}
 
-		res := struct {
-			PlanId   int       `json:"planId"`
-			PlanTime time.Time `json:"planTime"`
-			Duration int64     `json:"duration"`
-			Plan     api.Rates `json:"plan"`
-			Power    float64   `json:"power"`
-		}{
-			PlanId:   id,
-			PlanTime: planTime,
-			Duration: int64(requiredDuration.Seconds()),
-			Plan:     plan,
-			Power:    maxPower,
-		}
+		res := newPlanResponse(planTime, requiredDuration, plan, maxPower)
+		res.PlanId = &id
 
 		jsonResult(w, res)
 	}
@@ -115,17 +126,7 @@ func staticPlanPreviewHandler(lp loadpoint.API) http.HandlerFunc {
 			return
 		}
 
-		res := struct {
-			PlanTime time.Time `json:"planTime"`
-			Duration int64     `json:"duration"`
-			Plan     api.Rates `json:"plan"`
-			Power    float64   `json:"power"`
-		}{
-			PlanTime: planTime,
-			Duration: int64(requiredDuration.Seconds()),
-			Plan:     plan,
-			Power:    maxPower,
-		}
+		res := newPlanResponse(planTime, requiredDuration, plan, maxPower)
 
 		jsonResult(w, res)
 	}
@@ -168,17 +169,7 @@ func repeatingPlanPreviewHandler(lp loadpoint.API) http.HandlerFunc {
 			return
 		}
 
-		res := struct {
-			PlanTime time.Time `json:"planTime"`
-			Duration int64     `json:"duration"`
-			Plan     api.Rates `json:"plan"`
-			Power    float64   `json:"power"`
-		}{
-			PlanTime: planTime,
-			Duration: int64(requiredDuration.Seconds()),
-			Plan:     plan,
-			Power:    maxPower,
-		}
+		res := newPlanResponse(planTime, requiredDuration, plan, maxPower)
 
 		jsonResult(w, res)
 	}
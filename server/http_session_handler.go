@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,6 +9,7 @@ import (
 	"math"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/session"
@@ -28,6 +30,20 @@ func csvResult(ctx context.Context, w http.ResponseWriter, res any, filename str
 	}
 }
 
+// localeContext returns a context with the request's language
+func localeContext(r *http.Request) context.Context {
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		// get request language
+		lang = r.Header.Get("Accept-Language")
+		if tags, _, err := language.ParseAcceptLanguage(lang); err == nil && len(tags) > 0 {
+			lang = tags[0].String()
+		}
+	}
+
+	return context.WithValue(context.Background(), locale.Locale, lang)
+}
+
 // sessionHandler returns the list of charging sessions
 func sessionHandler(w http.ResponseWriter, r *http.Request) {
 	if db.Instance == nil {
@@ -59,7 +75,7 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 
 	// TODO support other databases than Sqlite
 	query := strings.Join(append([]string{"charged_kwh>=0.05"}, cond...), " AND ")
-	if txn := db.Instance.Where(query, args...).Order("created DESC").Find(&res); txn.Error != nil {
+	if txn := db.Instance.Preload("Slots").Where(query, args...).Order("created DESC").Find(&res); txn.Error != nil {
 		jsonError(w, http.StatusInternalServerError, txn.Error)
 		return
 	}
@@ -73,16 +89,14 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.URL.Query().Get("format") == "csv" {
-		lang := r.URL.Query().Get("lang")
-		if lang == "" {
-			// get request language
-			lang = r.Header.Get("Accept-Language")
-			if tags, _, err := language.ParseAcceptLanguage(lang); err == nil && len(tags) > 0 {
-				lang = tags[0].String()
-			}
+		ctx := localeContext(r)
+
+		// one row per tariff slot
+		if r.URL.Query().Get("slots") == "true" {
+			csvResult(ctx, w, (*session.SlotSessions)(&res), filename+"-slots")
+			return
 		}
 
-		ctx := context.WithValue(context.Background(), locale.Locale, lang)
 		csvResult(ctx, w, &res, filename)
 		return
 	}
@@ -90,6 +104,121 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 	jsonResult(w, res)
 }
 
+// sessionReportHandler returns the filtered and grouped charging sessions with totals
+func sessionReportHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	q := r.URL.Query()
+
+	groupBy, err := session.ParseGrouping(q.Get("group"))
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	filter := session.Filter{
+		Vehicle:    q.Get("vehicle"),
+		Loadpoint:  q.Get("loadpoint"),
+		Identifier: q.Get("identifier"),
+	}
+
+	if from := q.Get("from"); from != "" {
+		if filter.From, err = time.ParseInLocation(time.DateOnly, from, time.Local); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	if to := q.Get("to"); to != "" {
+		if filter.To, err = time.ParseInLocation(time.DateOnly, to, time.Local); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		// inclusive end date
+		filter.To = filter.To.AddDate(0, 0, 1)
+	}
+
+	var (
+		res  session.Sessions
+		cond = []string{"charged_kwh>=0.05"}
+		args []any
+	)
+
+	push := func(field string, val any) {
+		cond = append(cond, field)
+		args = append(args, val)
+	}
+
+	if filter.Vehicle != "" {
+		push("vehicle = ?", filter.Vehicle)
+	}
+	if filter.Loadpoint != "" {
+		push("loadpoint = ?", filter.Loadpoint)
+	}
+	if filter.Identifier != "" {
+		push("identifier = ?", filter.Identifier)
+	}
+
+	// compare normalized to utc since sessions may be stored with different offsets
+	if !filter.From.IsZero() {
+		push("DATETIME(created) >= DATETIME(?)", filter.From)
+	}
+	if !filter.To.IsZero() {
+		push("DATETIME(created) < DATETIME(?)", filter.To)
+	}
+
+	// TODO support other databases than Sqlite
+	query := strings.Join(cond, " AND ")
+	if txn := db.Instance.Preload("Slots").Where(query, args...).Order("created").Find(&res); txn.Error != nil {
+		jsonError(w, http.StatusInternalServerError, txn.Error)
+		return
+	}
+
+	report := session.NewReport(res, filter, groupBy)
+
+	var (
+		b           bytes.Buffer
+		contentType string
+	)
+
+	filename := "sessions-" + string(groupBy)
+	ctx := localeContext(r)
+
+	switch format := q.Get("format"); format {
+	case "", "json":
+		// sessions are only included on request
+		if q.Get("sessions") != "true" {
+			for i := range report.Groups {
+				report.Groups[i].Sessions = nil
+			}
+		}
+
+		jsonResult(w, report)
+		return
+
+	case "xlsx":
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		err = report.WriteXlsx(ctx, &b)
+
+	default:
+		jsonError(w, http.StatusBadRequest, fmt.Errorf("invalid format: %s", format))
+		return
+	}
+
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.`+q.Get("format")+`"`)
+	_, _ = b.WriteTo(w)
+}
+
 // deleteSessionHandler removes session in sessions table with given id
 func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
 	if db.Instance == nil {
@@ -107,6 +236,11 @@ func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if txn := db.Instance.Where("session_id = ?", id).Delete(new(session.Slot)); txn.Error != nil {
+		jsonError(w, http.StatusBadRequest, txn.Error)
+		return
+	}
+
 	jsonResult(w, res)
 }
 
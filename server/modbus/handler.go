@@ -3,7 +3,9 @@ package modbus
 import (
 	"encoding/binary"
 	"errors"
+	"log"
 	"math/bits"
+	"slices"
 
 	"github.com/andig/mbserver"
 	"github.com/evcc-io/evcc/util"
@@ -14,6 +16,7 @@ import (
 type handler struct {
 	log      *util.Logger
 	readOnly ReadOnlyMode
+	rules    *ruleSet
 	conn     *modbus.Connection
 }
 
@@ -33,6 +36,20 @@ func asBytes(u []uint16) []byte {
 	return b
 }
 
+// writeLog returns the logger for rejected writes. Rejections by write rule are logged
+// prominently, rejections by the proxy's readonly mode are expected and only traced.
+func (h *handler) writeLog(mode ReadOnlyMode, typ registerType, addr, qty uint16) *log.Logger {
+	if h.rules.writeMode(ReadOnlyFalse, typ, addr, qty) != mode {
+		return h.log.TRACE
+	}
+
+	if mode == ReadOnlyDeny {
+		return h.log.WARN
+	}
+
+	return h.log.DEBUG
+}
+
 func (h *handler) logResult(op string, b []byte, err error) {
 	if err == nil {
 		h.log.TRACE.Printf(op+": %0x", b)
@@ -96,21 +113,25 @@ LOOP:
 
 func (h *handler) HandleDiscreteInputs(req *mbserver.DiscreteInputsRequest) ([]bool, error) {
 	h.log.TRACE.Printf("read discrete: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
-	b, err := h.conn.Clone(req.UnitId).ReadDiscreteInputs(req.Addr, req.Quantity)
+	b, err := h.rules.read(typeDiscrete, req.UnitId, req.Addr, req.Quantity, func() ([]byte, error) {
+		return h.conn.Clone(req.UnitId).ReadDiscreteInputs(req.Addr, req.Quantity)
+	})
 	return h.bytesToBoolResult("read discrete", req.Quantity, b, err)
 }
 
 func (h *handler) HandleCoils(req *mbserver.CoilsRequest) ([]bool, error) {
 	if req.IsWrite {
-		switch h.readOnly {
+		switch mode := h.rules.writeMode(h.readOnly, typeCoil, req.Addr, req.Quantity); mode {
 		case ReadOnlyDeny:
-			h.log.TRACE.Printf("deny: write coils: id %d addr %d qty %d val %v", req.UnitId, req.Addr, req.Quantity, req.Args)
+			h.writeLog(mode, typeCoil, req.Addr, req.Quantity).Printf("deny: write coils: id %d addr %d qty %d val %v", req.UnitId, req.Addr, req.Quantity, req.Args)
 			return nil, mbserver.ErrIllegalFunction
 		case ReadOnlyTrue:
-			h.log.TRACE.Printf("ignore: write coils: id %d addr %d qty %d val %v", req.UnitId, req.Addr, req.Quantity, req.Args)
+			h.writeLog(mode, typeCoil, req.Addr, req.Quantity).Printf("ignore: write coils: id %d addr %d qty %d val %v", req.UnitId, req.Addr, req.Quantity, req.Args)
 			return req.Args, nil
 		}
 
+		h.rules.invalidate(typeCoil, req.UnitId, req.Addr, req.Quantity)
+
 		if req.WriteFuncCode == gridx.FuncCodeWriteSingleCoil {
 			h.log.TRACE.Printf("write coil: id %d addr %d val %t", req.UnitId, req.Addr, req.Args[0])
 			var u uint16
@@ -129,39 +150,52 @@ func (h *handler) HandleCoils(req *mbserver.CoilsRequest) ([]bool, error) {
 	}
 
 	h.log.TRACE.Printf("read coils: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
-	b, err := h.conn.Clone(req.UnitId).ReadCoils(req.Addr, req.Quantity)
+	b, err := h.rules.read(typeCoil, req.UnitId, req.Addr, req.Quantity, func() ([]byte, error) {
+		return h.conn.Clone(req.UnitId).ReadCoils(req.Addr, req.Quantity)
+	})
 	return h.bytesToBoolResult("read coils", req.Quantity, b, err)
 }
 
 func (h *handler) HandleInputRegisters(req *mbserver.InputRegistersRequest) ([]uint16, error) {
 	h.log.TRACE.Printf("read input: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
-	b, err := h.conn.Clone(req.UnitId).ReadInputRegisters(req.Addr, req.Quantity)
+	b, err := h.rules.read(typeInput, req.UnitId, req.Addr, req.Quantity, func() ([]byte, error) {
+		return h.conn.Clone(req.UnitId).ReadInputRegisters(req.Addr, req.Quantity)
+	})
 	return h.exceptionToUint16AndError("read input", b, err)
 }
 
 func (h *handler) HandleHoldingRegisters(req *mbserver.HoldingRegistersRequest) ([]uint16, error) {
 	if req.IsWrite {
-		switch h.readOnly {
+		switch mode := h.rules.writeMode(h.readOnly, typeHolding, req.Addr, req.Quantity); mode {
 		case ReadOnlyDeny:
-			h.log.TRACE.Printf("deny: write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
+			h.writeLog(mode, typeHolding, req.Addr, req.Quantity).Printf("deny: write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
 			return nil, mbserver.ErrIllegalFunction
 		case ReadOnlyTrue:
-			h.log.TRACE.Printf("ignore: write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
+			h.writeLog(mode, typeHolding, req.Addr, req.Quantity).Printf("ignore: write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
 			return req.Args, nil
 		}
 
+		args := h.rules.clamp(req.Addr, req.Args)
+		if !slices.Equal(args, req.Args) {
+			h.log.DEBUG.Printf("clamp: write holdings: id %d addr %d qty %d val %0x to %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args), asBytes(args))
+		}
+
+		h.rules.invalidate(typeHolding, req.UnitId, req.Addr, req.Quantity)
+
 		if req.WriteFuncCode == gridx.FuncCodeWriteSingleRegister {
-			h.log.TRACE.Printf("write holding: id %d addr %d val %04x", req.UnitId, req.Addr, req.Args[0])
-			b, err := h.conn.Clone(req.UnitId).WriteSingleRegister(req.Addr, req.Args[0])
+			h.log.TRACE.Printf("write holding: id %d addr %d val %04x", req.UnitId, req.Addr, args[0])
+			b, err := h.conn.Clone(req.UnitId).WriteSingleRegister(req.Addr, args[0])
 			return h.exceptionToUint16AndError("write holding", b, err)
 		}
 
-		h.log.TRACE.Printf("write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
-		b, err := h.conn.Clone(req.UnitId).WriteMultipleRegisters(req.Addr, req.Quantity, asBytes(req.Args))
+		h.log.TRACE.Printf("write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(args))
+		b, err := h.conn.Clone(req.UnitId).WriteMultipleRegisters(req.Addr, req.Quantity, asBytes(args))
 		return h.exceptionToUint16AndError("write multiple holding", b, err)
 	}
 
 	h.log.TRACE.Printf("read holdings: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
-	b, err := h.conn.Clone(req.UnitId).ReadHoldingRegisters(req.Addr, req.Quantity)
+	b, err := h.rules.read(typeHolding, req.UnitId, req.Addr, req.Quantity, func() ([]byte, error) {
+		return h.conn.Clone(req.UnitId).ReadHoldingRegisters(req.Addr, req.Quantity)
+	})
 	return h.exceptionToUint16AndError("read holding", b, err)
 }
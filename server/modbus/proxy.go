@@ -6,12 +6,18 @@ import (
 
 	"github.com/andig/mbserver"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/api/globalconfig"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/modbus"
 	"github.com/evcc-io/evcc/util/sponsor"
 )
 
-func StartProxy(port int, config modbus.Settings, readOnly ReadOnlyMode) error {
+func StartProxy(port int, config modbus.Settings, readOnly ReadOnlyMode, rules []globalconfig.ModbusProxyRule) error {
+	rs, err := newRuleSet(rules)
+	if err != nil {
+		return err
+	}
+
 	conn, err := modbus.NewConnection(config.URI, config.Device, config.Comset, config.Baudrate, config.Protocol(), config.ID)
 	if err != nil {
 		return err
@@ -24,6 +30,7 @@ func StartProxy(port int, config modbus.Settings, readOnly ReadOnlyMode) error {
 	h := &handler{
 		log:      util.NewLogger(fmt.Sprintf("proxy-%d", port)),
 		readOnly: readOnly,
+		rules:    rs,
 		conn:     conn,
 	}
 
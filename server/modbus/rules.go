@@ -0,0 +1,304 @@
+package modbus
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api/globalconfig"
+)
+
+type registerType string
+
+const (
+	typeHolding  registerType = "holding"
+	typeInput    registerType = "input"
+	typeCoil     registerType = "coil"
+	typeDiscrete registerType = "discrete"
+)
+
+type rule struct {
+	typ      registerType
+	from, to uint16
+	write    *ReadOnlyMode
+	min, max *int
+	cache    time.Duration
+}
+
+func (r rule) contains(typ registerType, addr uint16) bool {
+	return r.typ == typ && addr >= r.from && addr <= r.to
+}
+
+// clamp limits the register value to the rule's min and max
+func (r rule) clamp(u uint16) uint16 {
+	if r.min == nil && r.max == nil {
+		return u
+	}
+
+	signed := r.min != nil && *r.min < 0
+
+	v := int(u)
+	if signed {
+		v = int(int16(u))
+	}
+
+	if r.min != nil {
+		v = max(v, *r.min)
+	}
+	if r.max != nil {
+		v = min(v, *r.max)
+	}
+
+	if signed {
+		return uint16(int16(v))
+	}
+
+	return uint16(v)
+}
+
+func parseRegisters(s string) (uint16, uint16, error) {
+	from, to, isRange := strings.Cut(s, "-")
+
+	f, err := strconv.ParseUint(strings.TrimSpace(from), 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid registers: %s", s)
+	}
+
+	t := f
+	if isRange {
+		if t, err = strconv.ParseUint(strings.TrimSpace(to), 10, 16); err != nil || t < f {
+			return 0, 0, fmt.Errorf("invalid registers: %s", s)
+		}
+	}
+
+	return uint16(f), uint16(t), nil
+}
+
+func parseWrite(s string) (*ReadOnlyMode, error) {
+	var mode ReadOnlyMode
+
+	switch strings.ToLower(s) {
+	case "":
+		return nil, nil
+	case "allow":
+		mode = ReadOnlyFalse
+	case "deny":
+		mode = ReadOnlyDeny
+	case "ignore":
+		mode = ReadOnlyTrue
+	default:
+		return nil, fmt.Errorf("invalid write: %s", s)
+	}
+
+	return &mode, nil
+}
+
+// validateLimits checks that min and max are within the register's unsigned or signed range
+func validateLimits(typ registerType, minVal, maxVal *int) error {
+	if minVal == nil && maxVal == nil {
+		return nil
+	}
+
+	if typ != typeHolding {
+		return errors.New("min and max require holding registers")
+	}
+
+	if minVal != nil && maxVal != nil && *minVal > *maxVal {
+		return errors.New("min must not exceed max")
+	}
+
+	// negative min treats values as signed
+	lo, hi := 0, math.MaxUint16
+	if minVal != nil && *minVal < 0 {
+		lo, hi = math.MinInt16, math.MaxInt16
+	}
+
+	for _, v := range []*int{minVal, maxVal} {
+		if v != nil && (*v < lo || *v > hi) {
+			return fmt.Errorf("limit %d out of range %d..%d", *v, lo, hi)
+		}
+	}
+
+	return nil
+}
+
+type cacheKey struct {
+	typ       registerType
+	id        uint8
+	addr, qty uint16
+}
+
+type cacheEntry struct {
+	b       []byte
+	updated time.Time
+}
+
+// ruleSet applies the proxy's access rules. A nil rule set has no rules.
+type ruleSet struct {
+	mu    sync.Mutex
+	clock clock.Clock
+	rules []rule
+	cache map[cacheKey]cacheEntry
+}
+
+func newRuleSet(rules []globalconfig.ModbusProxyRule) (*ruleSet, error) {
+	rs := &ruleSet{
+		clock: clock.New(),
+		cache: make(map[cacheKey]cacheEntry),
+	}
+
+	for _, r := range rules {
+		from, to, err := parseRegisters(r.Registers)
+		if err != nil {
+			return nil, err
+		}
+
+		typ := registerType(strings.ToLower(r.Type))
+		switch typ {
+		case "":
+			typ = typeHolding
+		case typeHolding, typeInput, typeCoil, typeDiscrete:
+		default:
+			return nil, fmt.Errorf("invalid type: %s", r.Type)
+		}
+
+		write, err := parseWrite(r.Write)
+		if err != nil {
+			return nil, err
+		}
+
+		if write != nil && (typ == typeInput || typ == typeDiscrete) {
+			return nil, fmt.Errorf("registers %s: write requires holding or coil type", r.Registers)
+		}
+
+		if err := validateLimits(typ, r.Min, r.Max); err != nil {
+			return nil, fmt.Errorf("registers %s: %w", r.Registers, err)
+		}
+
+		rs.rules = append(rs.rules, rule{
+			typ:   typ,
+			from:  from,
+			to:    to,
+			write: write,
+			min:   r.Min,
+			max:   r.Max,
+			cache: r.Cache,
+		})
+	}
+
+	return rs, nil
+}
+
+// find returns the first rule containing the register
+func (rs *ruleSet) find(typ registerType, addr uint16, match func(rule) bool) (rule, bool) {
+	if rs == nil {
+		return rule{}, false
+	}
+
+	for _, r := range rs.rules {
+		if r.contains(typ, addr) && match(r) {
+			return r, true
+		}
+	}
+
+	return rule{}, false
+}
+
+// writeMode returns the most restrictive write mode of all written registers.
+// Registers without write rule use the proxy's readonly mode.
+func (rs *ruleSet) writeMode(readOnly ReadOnlyMode, typ registerType, addr, qty uint16) ReadOnlyMode {
+	var res ReadOnlyMode
+
+	for a := uint32(addr); a < uint32(addr)+uint32(qty); a++ {
+		mode := readOnly
+
+		if r, ok := rs.find(typ, uint16(a), func(r rule) bool { return r.write != nil }); ok {
+			mode = *r.write
+		}
+
+		// deny takes precedence over ignore
+		switch mode {
+		case ReadOnlyDeny:
+			return mode
+		case ReadOnlyTrue:
+			res = mode
+		}
+	}
+
+	return res
+}
+
+// clamp limits the written holding register values
+func (rs *ruleSet) clamp(addr uint16, values []uint16) []uint16 {
+	res := make([]uint16, len(values))
+
+	for i, u := range values {
+		res[i] = u
+
+		if r, ok := rs.find(typeHolding, addr+uint16(i), func(r rule) bool { return r.min != nil || r.max != nil }); ok {
+			res[i] = r.clamp(u)
+		}
+	}
+
+	return res
+}
+
+// cacheDuration returns the cache duration if all registers are covered by a single cache rule
+func (rs *ruleSet) cacheDuration(typ registerType, addr, qty uint16) time.Duration {
+	r, ok := rs.find(typ, addr, func(r rule) bool { return r.cache > 0 })
+	if !ok || uint32(addr)+uint32(qty)-1 > uint32(r.to) {
+		return 0
+	}
+
+	return r.cache
+}
+
+// read serves reads from cache if configured
+func (rs *ruleSet) read(typ registerType, id uint8, addr, qty uint16, read func() ([]byte, error)) ([]byte, error) {
+	duration := rs.cacheDuration(typ, addr, qty)
+	if duration == 0 {
+		return read()
+	}
+
+	key := cacheKey{typ, id, addr, qty}
+
+	rs.mu.Lock()
+	e, ok := rs.cache[key]
+	rs.mu.Unlock()
+
+	if ok && rs.clock.Since(e.updated) < duration {
+		return e.b, nil
+	}
+
+	b, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	rs.mu.Lock()
+	rs.cache[key] = cacheEntry{b: b, updated: rs.clock.Now()}
+	rs.mu.Unlock()
+
+	return b, nil
+}
+
+// invalidate removes cached reads overlapping written registers
+func (rs *ruleSet) invalidate(typ registerType, id uint8, addr, qty uint16) {
+	if rs == nil {
+		return
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	for k := range rs.cache {
+		if k.typ == typ && k.id == id && uint32(k.addr) < uint32(addr)+uint32(qty) && uint32(addr) < uint32(k.addr)+uint32(k.qty) {
+			delete(rs.cache, k)
+		}
+	}
+}
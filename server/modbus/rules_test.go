@@ -0,0 +1,138 @@
+package modbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleSetInvalid(t *testing.T) {
+	negative, small, large, overflow := -1, 6, 40000, 70000
+
+	for _, r := range []globalconfig.ModbusProxyRule{
+		{Registers: ""},
+		{Registers: "10-5"},
+		{Registers: "70000"},
+		{Registers: "1", Type: "foo"},
+		{Registers: "1", Write: "foo"},
+		{Registers: "1", Type: "input", Max: new(int)},
+		{Registers: "1", Type: "input", Write: "allow"},
+		{Registers: "1", Type: "discrete", Write: "deny"},
+		{Registers: "1", Max: &negative},
+		{Registers: "1", Min: &large, Max: &small},
+		{Registers: "1", Max: &overflow},
+		{Registers: "1", Min: &negative, Max: &large},
+	} {
+		_, err := newRuleSet([]globalconfig.ModbusProxyRule{r})
+		assert.Error(t, err, r)
+	}
+}
+
+func TestRuleSetWriteMode(t *testing.T) {
+	rs, err := newRuleSet([]globalconfig.ModbusProxyRule{
+		{Registers: "1000-1001", Write: "allow"},
+		{Registers: "1002", Write: "ignore"},
+		{Registers: "1", Type: "coil", Write: "allow"},
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		typ       registerType
+		addr, qty uint16
+		mode      ReadOnlyMode
+	}{
+		{typeHolding, 1000, 2, ReadOnlyFalse},
+		{typeHolding, 1001, 2, ReadOnlyTrue},
+		{typeHolding, 1001, 3, ReadOnlyDeny},
+		{typeHolding, 1, 1, ReadOnlyDeny},
+		{typeCoil, 1, 1, ReadOnlyFalse},
+		{typeCoil, 1000, 1, ReadOnlyDeny},
+	} {
+		assert.Equal(t, tc.mode, rs.writeMode(ReadOnlyDeny, tc.typ, tc.addr, tc.qty), tc)
+	}
+
+	// without rules
+	var nilRs *ruleSet
+	assert.Equal(t, ReadOnlyFalse, nilRs.writeMode(ReadOnlyFalse, typeHolding, 1, 1))
+	assert.Equal(t, ReadOnlyTrue, nilRs.writeMode(ReadOnlyTrue, typeHolding, 1, 1))
+}
+
+func TestRuleSetClamp(t *testing.T) {
+	minCurrent, maxCurrent, minPower := 6, 16, -1000
+
+	rs, err := newRuleSet([]globalconfig.ModbusProxyRule{
+		{Registers: "1000", Min: &minCurrent, Max: &maxCurrent},
+		{Registers: "1001", Min: &minPower, Max: &minCurrent},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint16{16, 0xfc18, 32}, rs.clamp(1000, []uint16{32, 0xf000, 32}))
+	assert.Equal(t, []uint16{6, 6}, rs.clamp(1000, []uint16{0, 100}))
+	assert.Equal(t, []uint16{0xffff}, rs.clamp(1001, []uint16{0xffff}))
+}
+
+func TestRuleSetCache(t *testing.T) {
+	rs, err := newRuleSet([]globalconfig.ModbusProxyRule{
+		{Registers: "100-109", Type: "input", Cache: time.Second},
+	})
+	require.NoError(t, err)
+
+	clock := clock.NewMock()
+	rs.clock = clock
+
+	var reads int
+	read := func() ([]byte, error) {
+		reads++
+		return []byte{0, byte(reads)}, nil
+	}
+
+	b, err := rs.read(typeInput, 1, 100, 1, read)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 1}, b)
+
+	// cached
+	b, _ = rs.read(typeInput, 1, 100, 1, read)
+	assert.Equal(t, []byte{0, 1}, b)
+
+	// different unit id
+	b, _ = rs.read(typeInput, 2, 100, 1, read)
+	assert.Equal(t, []byte{0, 2}, b)
+
+	// exceeding rule
+	_, _ = rs.read(typeInput, 1, 105, 10, read)
+	_, _ = rs.read(typeInput, 1, 105, 10, read)
+	assert.Equal(t, 4, reads)
+
+	// expired
+	clock.Add(time.Second)
+	b, _ = rs.read(typeInput, 1, 100, 1, read)
+	assert.Equal(t, []byte{0, 5}, b)
+
+	// invalidated
+	rs.invalidate(typeInput, 1, 99, 2)
+	b, _ = rs.read(typeInput, 1, 100, 1, read)
+	assert.Equal(t, []byte{0, 6}, b)
+}
+
+func TestHandlerWriteLog(t *testing.T) {
+	rs, err := newRuleSet([]globalconfig.ModbusProxyRule{
+		{Registers: "1", Write: "deny"},
+		{Registers: "2", Write: "ignore"},
+	})
+	require.NoError(t, err)
+
+	h := &handler{log: util.NewLogger("foo"), readOnly: ReadOnlyDeny, rules: rs}
+
+	// rejected by rule
+	assert.Same(t, h.log.WARN, h.writeLog(ReadOnlyDeny, typeHolding, 1, 1))
+	assert.Same(t, h.log.DEBUG, h.writeLog(ReadOnlyTrue, typeHolding, 2, 1))
+
+	// rejected by readonly mode
+	assert.Same(t, h.log.TRACE, h.writeLog(ReadOnlyDeny, typeHolding, 3, 1))
+	assert.Same(t, h.log.TRACE, h.writeLog(ReadOnlyDeny, typeHolding, 2, 2))
+}
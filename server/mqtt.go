@@ -204,6 +204,9 @@ func (m *MQTT) listenSiteSetters(topic string, site site.API) error {
 			}
 		}))},
 		{"batteryGridChargeLimit", floatPtrSetter(pass(site.SetBatteryGridChargeLimit))},
+		{"batteryGridDischargeLimit", floatPtrSetter(pass(site.SetBatteryGridDischargeLimit))},
+		{"batteryDischargePower", floatSetter(site.SetBatteryDischargePower)},
+		{"batteryMaxChargePower", floatSetter(site.SetBatteryMaxChargePower)},
 	} {
 		if err := m.Handler.ListenSetter(topic+"/"+s.topic, s.fun); err != nil {
 			return err
@@ -217,6 +220,7 @@ func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.
 	for _, s := range []setter{
 		{"mode", setterFunc(api.ChargeModeString, pass(lp.SetMode))},
 		{"phases", intSetter(lp.SetPhasesConfigured)},
+		{"phaseRotation", intSetter(lp.SetPhaseRotation)},
 		{"limitSoc", intSetter(pass(lp.SetLimitSoc))},
 		{"priority", intSetter(pass(lp.SetPriority))},
 		{"minCurrent", floatSetter(lp.SetMinCurrent)},
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// haEntity is a Home Assistant entity backed by an evcc topic
+type haEntity struct {
+	component   string // sensor, binary_sensor, number, select or switch
+	key         string // topic relative to the device topic
+	name        string
+	unit        string
+	deviceClass string
+	stateClass  string
+	settable    bool // command topic is the setter topic
+	min, max    float64
+	step        float64
+	options     []string
+}
+
+var haSiteEntities = []haEntity{
+	{component: "sensor", key: "grid/power", name: "Grid power", unit: "W", deviceClass: "power", stateClass: "measurement"},
+	{component: "sensor", key: "pvPower", name: "PV power", unit: "W", deviceClass: "power", stateClass: "measurement"},
+	{component: "sensor", key: "pvEnergy", name: "PV energy", unit: "kWh", deviceClass: "energy", stateClass: "total_increasing"},
+	{component: "sensor", key: "homePower", name: "Home power", unit: "W", deviceClass: "power", stateClass: "measurement"},
+	{component: "sensor", key: "auxPower", name: "Aux power", unit: "W", deviceClass: "power", stateClass: "measurement"},
+	{component: "sensor", key: "batteryPower", name: "Battery power", unit: "W", deviceClass: "power", stateClass: "measurement"},
+	{component: "sensor", key: "batterySoc", name: "Battery soc", unit: "%", deviceClass: "battery", stateClass: "measurement"},
+	{component: "sensor", key: "tariffGrid", name: "Grid price", stateClass: "measurement"},
+	{component: "sensor", key: "tariffFeedIn", name: "Feed-in price", stateClass: "measurement"},
+	{component: "sensor", key: "tariffCo2", name: "Grid CO₂", unit: "g/kWh", stateClass: "measurement"},
+	{component: "sensor", key: "greenShareHome", name: "Green share home", stateClass: "measurement"},
+	{component: "number", key: "prioritySoc", name: "Priority soc", unit: "%", settable: true, max: 100, step: 1},
+	{component: "number", key: "bufferSoc", name: "Buffer soc", unit: "%", settable: true, max: 100, step: 1},
+	{component: "number", key: "bufferStartSoc", name: "Buffer start soc", unit: "%", settable: true, max: 100, step: 1},
+	{component: "number", key: "residualPower", name: "Residual power", unit: "W", settable: true, min: -10000, max: 10000, step: 10},
+	{component: "switch", key: "batteryDischargeControl", name: "Battery discharge control", settable: true},
+}
+
+var haLoadpointEntities = []haEntity{
+	{component: "sensor", key: "chargePower", name: "Charge power", unit: "W", deviceClass: "power", stateClass: "measurement"},
+	{component: "sensor", key: "chargeTotalImport", name: "Charge meter total", unit: "kWh", deviceClass: "energy", stateClass: "total_increasing"},
+	{component: "sensor", key: "sessionEnergy", name: "Session energy", unit: "Wh", deviceClass: "energy", stateClass: "total"},
+	{component: "sensor", key: "sessionPrice", name: "Session price", stateClass: "total"},
+	{component: "sensor", key: "sessionSolarPercentage", name: "Session solar", unit: "%", stateClass: "measurement"},
+	{component: "sensor", key: "chargeDuration", name: "Charge duration", unit: "s", deviceClass: "duration"},
+	{component: "sensor", key: "chargeRemainingDuration", name: "Remaining duration", unit: "s", deviceClass: "duration"},
+	{component: "sensor", key: "phasesActive", name: "Active phases", stateClass: "measurement"},
+	{component: "sensor", key: "vehicleName", name: "Vehicle"},
+	{component: "sensor", key: "vehicleSoc", name: "Vehicle soc", unit: "%", deviceClass: "battery", stateClass: "measurement"},
+	{component: "sensor", key: "vehicleRange", name: "Vehicle range", unit: "km", deviceClass: "distance", stateClass: "measurement"},
+	{component: "binary_sensor", key: "connected", name: "Connected", deviceClass: "plug"},
+	{component: "binary_sensor", key: "charging", name: "Charging", deviceClass: "battery_charging"},
+	{component: "binary_sensor", key: "enabled", name: "Enabled", deviceClass: "power"},
+	{component: "select", key: "mode", name: "Mode", settable: true, options: []string{
+		string(api.ModeOff), string(api.ModeNow), string(api.ModeMinPV), string(api.ModePV),
+	}},
+	{component: "select", key: "phases", name: "Phases", settable: true, options: []string{"0", "1", "3"}},
+	{component: "number", key: "limitSoc", name: "Limit soc", unit: "%", settable: true, max: 100, step: 1},
+	{component: "number", key: "limitEnergy", name: "Limit energy", unit: "kWh", settable: true, max: 200, step: 1},
+	{component: "number", key: "minCurrent", name: "Min current", unit: "A", deviceClass: "current", settable: true, max: 32, step: 1},
+	{component: "number", key: "maxCurrent", name: "Max current", unit: "A", deviceClass: "current", settable: true, max: 32, step: 1},
+	{component: "number", key: "priority", name: "Priority", settable: true, max: 10, step: 1},
+	{component: "number", key: "smartCostLimit", name: "Smart cost limit", settable: true, min: -1, max: 1, step: 0.01},
+	{component: "number", key: "enableThreshold", name: "Enable threshold", unit: "W", settable: true, min: -10000, max: 10000, step: 10},
+	{component: "number", key: "disableThreshold", name: "Disable threshold", unit: "W", settable: true, min: -10000, max: 10000, step: 10},
+	{component: "switch", key: "batteryBoost", name: "Battery boost", settable: true},
+}
+
+var haVehicleEntities = []haEntity{
+	{component: "number", key: "limitSoc", name: "Limit soc", unit: "%", settable: true, max: 100, step: 1},
+	{component: "number", key: "minSoc", name: "Min soc", unit: "%", settable: true, max: 100, step: 1},
+}
+
+// haStateTopic returns the state topic for entities whose setter and state topics differ
+func haStateTopic(key string) string {
+	if key == "phases" {
+		return keys.PhasesConfigured
+	}
+	return key
+}
+
+// haDevice is a Home Assistant device with its entities
+type haDevice struct {
+	id, name, topic string
+	entities        []haEntity
+}
+
+// discovery publishes Home Assistant MQTT discovery configs
+type discovery struct {
+	mu        sync.Mutex
+	m         *MQTT
+	prefix    string
+	node      string
+	published map[string]bool
+}
+
+func (d *discovery) config(dev haDevice, e haEntity) (string, map[string]any) {
+	uid := fmt.Sprintf("%s_%s_%s", d.node, dev.id, strings.ReplaceAll(e.key, "/", "_"))
+
+	res := map[string]any{
+		"name":                  e.name,
+		"unique_id":             uid,
+		"object_id":             uid,
+		"state_topic":           fmt.Sprintf("%s/%s", dev.topic, haStateTopic(e.key)),
+		"availability_topic":    d.m.root + "/status",
+		"payload_available":     "online",
+		"payload_not_available": "offline",
+		"device": map[string]any{
+			"identifiers":  []string{d.node + "_" + dev.id},
+			"name":         dev.name,
+			"manufacturer": "evcc",
+		},
+	}
+
+	if dev.id != "site" {
+		res["device"].(map[string]any)["via_device"] = d.node + "_site"
+	}
+
+	if e.unit != "" {
+		res["unit_of_measurement"] = e.unit
+	}
+	if e.deviceClass != "" {
+		res["device_class"] = e.deviceClass
+	}
+	if e.stateClass != "" {
+		res["state_class"] = e.stateClass
+	}
+
+	if e.settable {
+		res["command_topic"] = fmt.Sprintf("%s/%s/set", dev.topic, e.key)
+	}
+
+	switch e.component {
+	case "binary_sensor":
+		res["payload_on"], res["payload_off"] = "true", "false"
+	case "switch":
+		res["payload_on"], res["payload_off"] = "true", "false"
+		res["state_on"], res["state_off"] = "true", "false"
+	case "number":
+		res["min"], res["max"], res["step"] = e.min, e.max, e.step
+		res["mode"] = "box"
+	case "select":
+		res["options"] = e.options
+	}
+
+	topic := fmt.Sprintf("%s/%s/%s/%s/config", d.prefix, e.component, d.node, uid)
+
+	return topic, res
+}
+
+// publish publishes discovery configs for site, loadpoints and vehicles and removes obsolete ones
+func (d *discovery) publish(site site.API) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	devices := []haDevice{
+		{"site", "evcc", d.m.root + "/site", haSiteEntities},
+	}
+
+	for id, lp := range site.Loadpoints() {
+		devices = append(devices, haDevice{
+			fmt.Sprintf("loadpoint%d", id+1), lp.GetTitle(), fmt.Sprintf("%s/loadpoints/%d", d.m.root, id+1), haLoadpointEntities,
+		})
+	}
+
+	for _, v := range site.Vehicles().Settings() {
+		title := v.Instance().Title()
+		if title == "" {
+			title = v.Name()
+		}
+
+		devices = append(devices, haDevice{
+			"vehicle_" + v.Name(), title, fmt.Sprintf("%s/vehicles/%s", d.m.root, v.Name()), haVehicleEntities,
+		})
+	}
+
+	published := make(map[string]bool)
+
+	for _, dev := range devices {
+		for _, e := range dev.entities {
+			topic, payload := d.config(dev, e)
+
+			b, err := json.Marshal(payload)
+			if err != nil {
+				d.m.log.ERROR.Printf("discovery: %v", err)
+				continue
+			}
+
+			d.m.publisher(topic, true, string(b))
+			published[topic] = true
+		}
+	}
+
+	// remove entities of deleted devices
+	for topic := range d.published {
+		if !published[topic] {
+			d.m.publisher(topic, true, "")
+		}
+	}
+
+	d.published = published
+}
+
+// Discovery publishes Home Assistant discovery configs below prefix and
+// republishes them when loadpoints or vehicles are changed via the config ui
+func (m *MQTT) Discovery(prefix string, site site.API) {
+	d := &discovery{
+		m:      m,
+		prefix: strings.Trim(prefix, "/"),
+		node:   strings.ReplaceAll(m.root, "/", "_"),
+	}
+
+	d.publish(site)
+
+	config.Vehicles().Subscribe(func(op config.Operation, dev config.Device[api.Vehicle]) {
+		// new vehicles need setters
+		if op == config.OpAdd {
+			name := dev.Config().Name
+			if v, err := site.Vehicles().ByName(name); err == nil {
+				if err := m.listenVehicleSetters(fmt.Sprintf("%s/vehicles/%s", m.root, name), v); err != nil {
+					m.log.ERROR.Printf("discovery: %v", err)
+				}
+			}
+		}
+
+		d.publish(site)
+	})
+
+	config.Loadpoints().Subscribe(func(config.Operation, config.Device[loadpoint.API]) {
+		d.publish(site)
+	})
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type discoverySite struct {
+	site.API
+	loadpoints []loadpoint.API
+	vehicles   discoveryVehicles
+}
+
+func (s *discoverySite) Loadpoints() []loadpoint.API { return s.loadpoints }
+func (s *discoverySite) Vehicles() site.Vehicles     { return s.vehicles }
+
+type discoveryVehicles struct {
+	site.Vehicles
+	vv []vehicle.API
+}
+
+func (v discoveryVehicles) Settings() []vehicle.API { return v.vv }
+
+func TestDiscovery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetTitle().Return("Garage").AnyTimes()
+
+	v := api.NewMockVehicle(ctrl)
+	v.EXPECT().Title().Return("Blue").AnyTimes()
+
+	va := vehicle.NewMockAPI(ctrl)
+	va.EXPECT().Name().Return("blue").AnyTimes()
+	va.EXPECT().Instance().Return(v).AnyTimes()
+
+	res := make(map[string]string)
+
+	m := &MQTT{
+		log:  util.NewLogger("foo"),
+		root: "evcc",
+		publisher: func(topic string, retained bool, payload string) {
+			assert.True(t, retained)
+			res[topic] = payload
+		},
+	}
+
+	d := &discovery{m: m, prefix: "homeassistant", node: "evcc"}
+	site := &discoverySite{
+		loadpoints: []loadpoint.API{lp},
+		vehicles:   discoveryVehicles{vv: []vehicle.API{va}},
+	}
+
+	d.publish(site)
+	assert.Len(t, res, len(haSiteEntities)+len(haLoadpointEntities)+len(haVehicleEntities))
+
+	// loadpoint mode select
+	var mode map[string]any
+	require.NoError(t, json.Unmarshal([]byte(res["homeassistant/select/evcc/evcc_loadpoint1_mode/config"]), &mode))
+	assert.Equal(t, "evcc/loadpoints/1/mode", mode["state_topic"])
+	assert.Equal(t, "evcc/loadpoints/1/mode/set", mode["command_topic"])
+	assert.Equal(t, []any{"off", "now", "minpv", "pv"}, mode["options"])
+	assert.Equal(t, "Garage", mode["device"].(map[string]any)["name"])
+
+	// phases state differs from setter topic
+	var phases map[string]any
+	require.NoError(t, json.Unmarshal([]byte(res["homeassistant/select/evcc/evcc_loadpoint1_phases/config"]), &phases))
+	assert.Equal(t, "evcc/loadpoints/1/phasesConfigured", phases["state_topic"])
+	assert.Equal(t, "evcc/loadpoints/1/phases/set", phases["command_topic"])
+
+	// vehicle number
+	var limit map[string]any
+	require.NoError(t, json.Unmarshal([]byte(res["homeassistant/number/evcc/evcc_vehicle_blue_limitSoc/config"]), &limit))
+	assert.Equal(t, "evcc/vehicles/blue/limitSoc/set", limit["command_topic"])
+
+	// deleted vehicle is removed
+	site.vehicles.vv = nil
+	d.publish(site)
+	assert.Equal(t, "", res["homeassistant/number/evcc/evcc_vehicle_blue_limitSoc/config"])
+}
@@ -0,0 +1,302 @@
+package server
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const promNamespace = "evcc"
+
+// Prometheus is a prometheus metrics publisher
+type Prometheus struct {
+	log *util.Logger
+
+	// site
+	site         map[string]prometheus.Gauge // simple values by key
+	gridCurrents *prometheus.GaugeVec
+
+	// loadpoints are labelled by id, titles are published as info metric
+	loadpoint      map[string]*prometheus.GaugeVec // simple values by key
+	loadpointInfo  *prometheus.GaugeVec
+	chargeCurrents *prometheus.GaugeVec
+	mode           *prometheus.GaugeVec
+	chargeEnergy   *prometheus.CounterVec
+	chargeSessions *prometheus.CounterVec
+
+	// circuits
+	circuitPower       *prometheus.GaugeVec
+	circuitCurrent     *prometheus.GaugeVec
+	circuitUtilization *prometheus.GaugeVec
+
+	titles   map[string]string  // last title by loadpoint
+	totals   map[string]float64 // last charge meter totals by loadpoint
+	charging map[string]bool    // last charging status by loadpoint
+}
+
+func promGauge(subsystem, name, help string) prometheus.Gauge {
+	return prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: promNamespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	})
+}
+
+func promGaugeVec(subsystem, name, help string, labels ...string) *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: promNamespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+}
+
+func promCounterVec(subsystem, name, help string, labels ...string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: promNamespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+}
+
+// NewPrometheus creates prometheus metrics publisher and registers its metrics
+func NewPrometheus(reg prometheus.Registerer) (*Prometheus, error) {
+	m := &Prometheus{
+		log: util.NewLogger("prometheus"),
+
+		site: map[string]prometheus.Gauge{
+			keys.PvPower:      promGauge("site", "pv_power_watts", "PV power"),
+			keys.BatteryPower: promGauge("site", "battery_power_watts", "Battery power, discharging positive"),
+			keys.BatterySoc:   promGauge("site", "battery_soc_percent", "Battery soc"),
+			keys.HomePower:    promGauge("site", "home_power_watts", "Home power"),
+			keys.AuxPower:     promGauge("site", "aux_power_watts", "Auxiliary power"),
+			keys.TariffGrid:   promGauge("tariff", "grid_price", "Grid price per kWh"),
+			keys.TariffFeedIn: promGauge("tariff", "feedin_price", "Feed-in price per kWh"),
+			keys.TariffCo2:    promGauge("tariff", "co2_grams_per_kwh", "Grid CO2 emissions per kWh"),
+			keys.Grid:         promGauge("site", "grid_power_watts", "Grid power, import positive"),
+		},
+		gridCurrents: promGaugeVec("site", "grid_current_amperes", "Grid phase currents", "phase"),
+
+		loadpoint: map[string]*prometheus.GaugeVec{
+			keys.ChargePower:   promGaugeVec("loadpoint", "charge_power_watts", "Charge power", "loadpoint"),
+			keys.PhasesActive:  promGaugeVec("loadpoint", "phases_active", "Active phases", "loadpoint"),
+			keys.Connected:     promGaugeVec("loadpoint", "connected", "Vehicle connected", "loadpoint"),
+			keys.Charging:      promGaugeVec("loadpoint", "charging", "Vehicle charging", "loadpoint"),
+			keys.Enabled:       promGaugeVec("loadpoint", "enabled", "Charger enabled", "loadpoint"),
+			keys.VehicleSoc:    promGaugeVec("loadpoint", "vehicle_soc_percent", "Vehicle soc", "loadpoint"),
+			"sessionEnergy":    promGaugeVec("loadpoint", "session_energy_wh", "Energy charged in current session", "loadpoint"),
+			"sessionPrice":     promGaugeVec("loadpoint", "session_price", "Price of current session", "loadpoint"),
+			"sessionCo2PerKWh": promGaugeVec("loadpoint", "session_co2_grams_per_kwh", "CO2 emissions per kWh of current session", "loadpoint"),
+		},
+		loadpointInfo:  promGaugeVec("loadpoint", "info", "Loadpoint title, always 1", "loadpoint", "title"),
+		chargeCurrents: promGaugeVec("loadpoint", "charge_current_amperes", "Charge phase currents", "loadpoint", "phase"),
+		mode:           promGaugeVec("loadpoint", "mode", "Charge mode, 1 for the active mode", "loadpoint", "mode"),
+		chargeEnergy:   promCounterVec("loadpoint", "charge_energy_kwh_total", "Energy measured by the charge meter", "loadpoint"),
+		chargeSessions: promCounterVec("loadpoint", "charge_starts_total", "Number of times charging started", "loadpoint"),
+
+		circuitPower:       promGaugeVec("circuit", "power_watts", "Circuit power", "circuit"),
+		circuitCurrent:     promGaugeVec("circuit", "current_amperes", "Circuit maximum phase current", "circuit"),
+		circuitUtilization: promGaugeVec("circuit", "utilization_ratio", "Circuit utilization of power or current limit", "circuit"),
+
+		titles:   make(map[string]string),
+		totals:   make(map[string]float64),
+		charging: make(map[string]bool),
+	}
+
+	collectors := []prometheus.Collector{
+		m.gridCurrents, m.loadpointInfo, m.chargeCurrents, m.mode, m.chargeEnergy, m.chargeSessions,
+		m.circuitPower, m.circuitCurrent, m.circuitUtilization,
+	}
+	for _, g := range m.site {
+		collectors = append(collectors, g)
+	}
+	for _, g := range m.loadpoint {
+		collectors = append(collectors, g)
+	}
+
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// promValue converts the value to a float
+func promValue(val any) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case *float64:
+		if v != nil {
+			return *v, true
+		}
+	}
+
+	return 0, false
+}
+
+// promField returns the named struct field's value
+func promField(val any, name string) any {
+	rv := reflect.Indirect(reflect.ValueOf(val))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if f := rv.FieldByName(name); f.IsValid() && f.CanInterface() {
+		return f.Interface()
+	}
+
+	return nil
+}
+
+// setPhases sets phase gauges from a slice of three values
+func setPhases(g *prometheus.GaugeVec, val any, labels ...string) {
+	if v, ok := val.([]float64); ok && len(v) == 3 {
+		for i, f := range v {
+			g.WithLabelValues(append(labels, strconv.Itoa(i+1))...).Set(f)
+		}
+	}
+}
+
+func (m *Prometheus) updateSite(key string, val any) {
+	switch key {
+	case keys.Grid:
+		if f, ok := promValue(promField(val, "Power")); ok {
+			m.site[keys.Grid].Set(f)
+		}
+		setPhases(m.gridCurrents, promField(val, "Currents"))
+
+	case keys.Circuits:
+		rv := reflect.ValueOf(val)
+		if rv.Kind() != reflect.Map {
+			return
+		}
+
+		for iter := rv.MapRange(); iter.Next(); {
+			name, c := iter.Key().String(), iter.Value().Interface()
+
+			power, _ := promValue(promField(c, "Power"))
+			m.circuitPower.WithLabelValues(name).Set(power)
+
+			current, hasCurrent := promValue(promField(c, "Current"))
+			if hasCurrent {
+				m.circuitCurrent.WithLabelValues(name).Set(current)
+			}
+
+			var utilization float64
+			if maxPower, _ := promValue(promField(c, "MaxPower")); maxPower > 0 {
+				utilization = power / maxPower
+			}
+			if maxCurrent, _ := promValue(promField(c, "MaxCurrent")); maxCurrent > 0 && hasCurrent {
+				utilization = max(utilization, current/maxCurrent)
+			}
+			m.circuitUtilization.WithLabelValues(name).Set(utilization)
+		}
+
+	default:
+		if g, ok := m.site[key]; ok {
+			if f, ok := promValue(val); ok {
+				g.Set(f)
+			}
+		}
+	}
+}
+
+// updateTitle publishes the loadpoint's title, replacing the previous title
+func (m *Prometheus) updateTitle(lp, title string) {
+	if prev, ok := m.titles[lp]; ok {
+		if prev == title {
+			return
+		}
+		m.loadpointInfo.DeleteLabelValues(lp, prev)
+	}
+
+	m.loadpointInfo.WithLabelValues(lp, title).Set(1)
+	m.titles[lp] = title
+}
+
+func (m *Prometheus) updateLoadpoint(lp string, key string, val any) {
+	switch key {
+	case keys.Mode:
+		mode, ok := val.(api.ChargeMode)
+		if !ok {
+			return
+		}
+
+		for _, v := range []api.ChargeMode{api.ModeOff, api.ModeNow, api.ModeMinPV, api.ModePV} {
+			var f float64
+			if v == mode {
+				f = 1
+			}
+			m.mode.WithLabelValues(lp, string(v)).Set(f)
+		}
+
+	case keys.ChargeCurrents:
+		setPhases(m.chargeCurrents, val, lp)
+
+	case keys.ChargeTotalImport:
+		total, ok := promValue(val)
+		if !ok {
+			return
+		}
+
+		// counter follows the meter total, ignoring resets
+		if last, ok := m.totals[lp]; ok && total > last {
+			m.chargeEnergy.WithLabelValues(lp).Add(total - last)
+		} else {
+			m.chargeEnergy.WithLabelValues(lp)
+		}
+		m.totals[lp] = total
+
+	default:
+		if key == keys.Charging {
+			if charging, ok := val.(bool); ok {
+				if charging && !m.charging[lp] {
+					m.chargeSessions.WithLabelValues(lp).Inc()
+				}
+				m.charging[lp] = charging
+			}
+		}
+
+		if g, ok := m.loadpoint[key]; ok {
+			if f, ok := promValue(val); ok {
+				g.WithLabelValues(lp).Set(f)
+			}
+		}
+	}
+}
+
+// Run Prometheus publisher
+func (m *Prometheus) Run(site site.API, in <-chan util.Param) {
+	for param := range in {
+		if param.Loadpoint == nil {
+			m.updateSite(param.Key, param.Val)
+			continue
+		}
+
+		// titles may be empty or repeated
+		if lps := site.Loadpoints(); *param.Loadpoint < len(lps) {
+			id := strconv.Itoa(*param.Loadpoint + 1)
+			m.updateTitle(id, lps[*param.Loadpoint].GetTitle())
+			m.updateLoadpoint(id, param.Key, param.Val)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheus(t *testing.T) {
+	m, err := NewPrometheus(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	// site
+	m.updateSite(keys.PvPower, 5000.0)
+	m.updateSite(keys.TariffGrid, lo.ToPtr(0.3))
+	m.updateSite(keys.Grid, struct {
+		Power    float64
+		Currents []float64
+	}{-1000, []float64{1, 2, 3}})
+
+	assert.Equal(t, 5000.0, testutil.ToFloat64(m.site[keys.PvPower]))
+	assert.Equal(t, 0.3, testutil.ToFloat64(m.site[keys.TariffGrid]))
+	assert.Equal(t, -1000.0, testutil.ToFloat64(m.site[keys.Grid]))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.gridCurrents.WithLabelValues("2")))
+
+	// circuits
+	type circuit struct {
+		Power, MaxPower, MaxCurrent float64
+		Current                     *float64
+	}
+	m.updateSite(keys.Circuits, map[string]circuit{
+		"main": {Power: 5000, MaxPower: 10000, MaxCurrent: 20, Current: lo.ToPtr(15.0)},
+	})
+	assert.Equal(t, 0.75, testutil.ToFloat64(m.circuitUtilization.WithLabelValues("main")))
+
+	// loadpoint
+	m.updateLoadpoint("1", keys.ChargePower, 11000.0)
+	m.updateLoadpoint("1", keys.Mode, api.ModePV)
+	m.updateLoadpoint("1", keys.Charging, true)
+	m.updateLoadpoint("1", keys.Charging, true)
+
+	assert.Equal(t, 11000.0, testutil.ToFloat64(m.loadpoint[keys.ChargePower].WithLabelValues("1")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.mode.WithLabelValues("1", "pv")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.mode.WithLabelValues("1", "now")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.chargeSessions.WithLabelValues("1")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.loadpoint[keys.Charging].WithLabelValues("1")))
+
+	// title info follows title changes
+	m.updateTitle("1", "Garage")
+	m.updateTitle("1", "Carport")
+	assert.Equal(t, 1, testutil.CollectAndCount(m.loadpointInfo))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.loadpointInfo.WithLabelValues("1", "Carport")))
+
+	// energy counter follows meter total
+	m.updateLoadpoint("1", keys.ChargeTotalImport, 100.0)
+	m.updateLoadpoint("1", keys.ChargeTotalImport, 102.5)
+	m.updateLoadpoint("1", keys.ChargeTotalImport, 0.0)
+	assert.Equal(t, 2.5, testutil.ToFloat64(m.chargeEnergy.WithLabelValues("1")))
+}
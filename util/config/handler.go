@@ -3,13 +3,20 @@ package config
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 )
 
 type handler[T any] struct {
 	mu      sync.RWMutex
-	topic   string
 	devices []Device[T]
+	subs    []subscription[T]
+	subId   int
+}
+
+type subscription[T any] struct {
+	id int
+	fn func(Operation, Device[T])
 }
 
 type Operation string
@@ -19,9 +26,33 @@ const (
 	OpDelete Operation = "del"
 )
 
-func (cp *handler[T]) Subscribe(fn func(Operation, Device[T])) {
-	if err := bus.Subscribe(cp.topic, fn); err != nil {
-		panic(err)
+// Subscribe registers a callback for added and deleted devices and returns a function removing the subscription
+func (cp *handler[T]) Subscribe(fn func(Operation, Device[T])) func() {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.subId++
+	id := cp.subId
+	cp.subs = append(cp.subs, subscription[T]{id: id, fn: fn})
+
+	return func() {
+		cp.mu.Lock()
+		defer cp.mu.Unlock()
+
+		cp.subs = slices.DeleteFunc(cp.subs, func(s subscription[T]) bool {
+			return s.id == id
+		})
+	}
+}
+
+// publish notifies subscribers of device changes
+func (cp *handler[T]) publish(op Operation, dev Device[T]) {
+	cp.mu.RLock()
+	subs := slices.Clone(cp.subs)
+	cp.mu.RUnlock()
+
+	for _, s := range subs {
+		s.fn(op, dev)
 	}
 }
 
@@ -49,7 +80,7 @@ func (cp *handler[T]) Add(dev Device[T]) error {
 	cp.devices = append(cp.devices, dev)
 	cp.mu.Unlock()
 
-	bus.Publish(cp.topic, OpAdd, dev)
+	cp.publish(OpAdd, dev)
 
 	return nil
 }
@@ -63,7 +94,7 @@ func (cp *handler[T]) Delete(name string) error {
 			cp.devices = append(cp.devices[:i], cp.devices[i+1:]...)
 			cp.mu.Unlock()
 
-			bus.Publish(cp.topic, OpDelete, dev)
+			cp.publish(OpDelete, dev)
 			return nil
 		}
 	}
@@ -1,13 +1,10 @@
 package config
 
 import (
-	evbus "github.com/asaskevich/EventBus"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
 )
 
-var bus = evbus.New()
-
 var instance struct {
 	meters     *handler[api.Meter]
 	chargers   *handler[api.Charger]
@@ -21,15 +18,15 @@ func init() {
 }
 
 func Reset() {
-	instance.meters = &handler[api.Meter]{topic: "meter"}
-	instance.chargers = &handler[api.Charger]{topic: "charger"}
-	instance.vehicles = &handler[api.Vehicle]{topic: "vehicle"}
-	instance.circuits = &handler[api.Circuit]{topic: "circuit"}
-	instance.loadpoints = &handler[loadpoint.API]{topic: "loadpoint"}
+	instance.meters = &handler[api.Meter]{}
+	instance.chargers = &handler[api.Charger]{}
+	instance.vehicles = &handler[api.Vehicle]{}
+	instance.circuits = &handler[api.Circuit]{}
+	instance.loadpoints = &handler[loadpoint.API]{}
 }
 
 type Handler[T any] interface {
-	Subscribe(fn func(Operation, Device[T]))
+	Subscribe(fn func(Operation, Device[T])) func()
 	Devices() []Device[T]
 	Add(dev Device[T]) error
 	Delete(name string) error
@@ -0,0 +1,167 @@
+package mbus
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/serial"
+)
+
+// Connection is a wired M-Bus master connection via serial device or TCP gateway
+type Connection struct {
+	mu      sync.Mutex
+	log     *util.Logger
+	uri     string
+	serial  *serial.Config
+	timeout time.Duration
+	conn    io.ReadWriteCloser
+	reader  *bufio.Reader
+}
+
+var (
+	connections = make(map[string]*Connection)
+	mu          sync.Mutex
+)
+
+// NewConnection creates a shared M-Bus connection. Meters on the same bus share a connection.
+func NewConnection(uri, device, comset string, baudrate int, timeout time.Duration) (*Connection, error) {
+	if (uri == "") == (device == "") {
+		return nil, errors.New("must have either uri or device")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	key := uri + device
+	if conn, ok := connections[key]; ok {
+		return conn, nil
+	}
+
+	conn := &Connection{
+		log:     util.NewLogger("mbus"),
+		uri:     uri,
+		timeout: timeout,
+	}
+
+	if device != "" {
+		var err error
+		if conn.serial, err = serial.NewConfig(device, comset, baudrate, timeout); err != nil {
+			return nil, err
+		}
+	}
+
+	connections[key] = conn
+
+	return conn, nil
+}
+
+func (c *Connection) connect() error {
+	if c.conn != nil {
+		return nil
+	}
+
+	var err error
+	if c.serial != nil {
+		c.conn, err = serial.Open(c.serial)
+	} else {
+		dialer := net.Dialer{Timeout: request.Timeout}
+		c.conn, err = dialer.Dial("tcp", c.uri)
+	}
+
+	if err == nil {
+		c.reader = bufio.NewReader(c.conn)
+	}
+
+	return err
+}
+
+func (c *Connection) close() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+func (c *Connection) send(b []byte) error {
+	c.log.TRACE.Printf("send: % x", b)
+
+	if conn, ok := c.conn.(net.Conn); ok {
+		if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+			return err
+		}
+	}
+
+	_, err := c.conn.Write(b)
+	return err
+}
+
+// request sends the frame and awaits acknowledge if required
+func (c *Connection) request(b []byte, ack bool) error {
+	if err := c.send(b); err != nil {
+		return err
+	}
+
+	if ack {
+		return readAck(c.reader)
+	}
+
+	return nil
+}
+
+// Read requests the class 2 data of the meter with given primary or secondary address
+func (c *Connection) Read(primary int, secondary string) (*Telegram, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res, err := c.read(primary, secondary)
+	if err != nil {
+		// discard pending input
+		c.close()
+	}
+
+	return res, err
+}
+
+func (c *Connection) read(primary int, secondary string) (*Telegram, error) {
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	addr := byte(primary)
+
+	if secondary != "" {
+		sa, err := SecondaryAddress(secondary)
+		if err != nil {
+			return nil, err
+		}
+
+		// selecting deselects all other meters
+		if err := c.request(longFrame(cSndUd, AddressNetwork, ciSelect, sa), true); err != nil {
+			return nil, fmt.Errorf("select %s: %w", secondary, err)
+		}
+
+		addr = AddressNetwork
+	} else if err := c.request(shortFrame(cSndNke, addr), true); err != nil {
+		return nil, fmt.Errorf("initialize: %w", err)
+	}
+
+	if err := c.request(shortFrame(cReqUd2, addr), false); err != nil {
+		return nil, err
+	}
+
+	a, ci, data, err := readLongFrame(c.reader)
+	if err != nil {
+		return nil, err
+	}
+
+	c.log.TRACE.Printf("recv: address %d ci %02x data % x", a, ci, data)
+
+	return decodeResponse(ci, data)
+}
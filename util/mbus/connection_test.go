@@ -0,0 +1,115 @@
+package mbus
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slave answers the expected requests
+func slave(t *testing.T, conn net.Conn, exchange ...[]byte) {
+	t.Helper()
+
+	for i := 0; i < len(exchange); i += 2 {
+		req := make([]byte, len(exchange[i]))
+		if _, err := io.ReadFull(conn, req); err != nil {
+			t.Error(err)
+			return
+		}
+
+		assert.Equal(t, exchange[i], req)
+
+		if _, err := conn.Write(exchange[i+1]); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+}
+
+func testConnection(t *testing.T) (*Connection, net.Conn) {
+	master, conn := net.Pipe()
+	t.Cleanup(func() { master.Close(); conn.Close() })
+
+	c := &Connection{
+		log:     util.NewLogger("foo"),
+		timeout: time.Second,
+		conn:    master,
+		reader:  bufio.NewReader(master),
+	}
+
+	return c, conn
+}
+
+var response = longFrame(cRspUd, 5, ciResponseLong, []byte{
+	0x78, 0x56, 0x34, 0x12, // id
+	0x93, 0x15, // manufacturer
+	0x01, 0x02, // version, medium
+	0x01, 0x00, 0x00, 0x00, // access, status, signature
+	0x04, 0x06, 0x1a, 0x00, 0x00, 0x00, // energy 26 kWh
+	0x02, 0x2b, 0x64, 0x00, // power 100 W
+})
+
+func TestReadPrimary(t *testing.T) {
+	c, conn := testConnection(t)
+
+	go slave(t, conn,
+		[]byte{0x10, 0x40, 0x05, 0x45, 0x16}, []byte{frameAck},
+		[]byte{0x10, 0x5b, 0x05, 0x60, 0x16}, response,
+	)
+
+	res, err := c.Read(5, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "12345678", res.ID)
+	assert.Equal(t, "ELS", res.Manufacturer)
+	assert.Equal(t, byte(2), res.Medium)
+	assert.Equal(t, []Record{{Quantity: Energy, Value: 26}, {Quantity: Power, Value: 100}}, res.Records)
+}
+
+func TestReadSecondary(t *testing.T) {
+	c, conn := testConnection(t)
+
+	go slave(t, conn,
+		[]byte{0x68, 0x0b, 0x0b, 0x68, 0x53, 0xfd, 0x52, 0x78, 0x56, 0x34, 0x12, 0xff, 0xff, 0xff, 0xff, 0xb2, 0x16}, []byte{frameAck},
+		[]byte{0x10, 0x5b, 0xfd, 0x58, 0x16}, response,
+	)
+
+	res, err := c.Read(0, "12345678")
+	require.NoError(t, err)
+	assert.Len(t, res.Records, 2)
+}
+
+func TestReadChecksum(t *testing.T) {
+	c, conn := testConnection(t)
+
+	invalid := append([]byte(nil), response...)
+	invalid[len(invalid)-2]++
+
+	go slave(t, conn,
+		[]byte{0x10, 0x40, 0x05, 0x45, 0x16}, []byte{frameAck},
+		[]byte{0x10, 0x5b, 0x05, 0x60, 0x16}, invalid,
+	)
+
+	_, err := c.Read(5, "")
+	assert.Error(t, err)
+	assert.Nil(t, c.conn, "connection closed after error")
+}
+
+func TestSecondaryAddress(t *testing.T) {
+	b, err := SecondaryAddress("12345678")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x78, 0x56, 0x34, 0x12, 0xff, 0xff, 0xff, 0xff}, b)
+
+	b, err = SecondaryAddress("1234567815930102")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x78, 0x56, 0x34, 0x12, 0x93, 0x15, 0x01, 0x02}, b)
+
+	_, err = SecondaryAddress("123")
+	assert.Error(t, err)
+}
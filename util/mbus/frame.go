@@ -0,0 +1,117 @@
+package mbus
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// wired link layer
+const (
+	frameAck   = 0xe5
+	frameShort = 0x10
+	frameLong  = 0x68
+	frameStop  = 0x16
+
+	cSndNke = 0x40 // initialize
+	cSndUd  = 0x53 // send user data
+	cReqUd2 = 0x5b // request class 2 data
+	cRspUd  = 0x08 // response user data
+
+	ciSelect = 0x52 // select secondary address
+
+	// AddressNetwork is the primary address of the selected secondary address
+	AddressNetwork = 0xfd
+)
+
+func checksum(b []byte) byte {
+	var res byte
+	for _, c := range b {
+		res += c
+	}
+	return res
+}
+
+func shortFrame(c, a byte) []byte {
+	return []byte{frameShort, c, a, c + a, frameStop}
+}
+
+func longFrame(c, a, ci byte, data []byte) []byte {
+	l := byte(len(data) + 3)
+
+	res := []byte{frameLong, l, l, frameLong, c, a, ci}
+	res = append(res, data...)
+
+	return append(res, checksum(res[4:]), frameStop)
+}
+
+// readAck reads a single character acknowledge
+func readAck(r *bufio.Reader) error {
+	b, err := r.ReadByte()
+	if err == nil && b != frameAck {
+		err = fmt.Errorf("invalid ack: %02x", b)
+	}
+	return err
+}
+
+// readLongFrame reads a long frame and returns its control information and data
+func readLongFrame(r *bufio.Reader) (byte, byte, []byte, error) {
+	head := make([]byte, 4)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return 0, 0, nil, err
+	}
+
+	if head[0] != frameLong || head[3] != frameLong || head[1] != head[2] || head[1] < 3 {
+		return 0, 0, nil, fmt.Errorf("invalid frame header: % x", head)
+	}
+
+	// c, a, ci, data, checksum, stop
+	b := make([]byte, int(head[1])+2)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return 0, 0, nil, err
+	}
+
+	n := len(b) - 2
+	if b[n+1] != frameStop {
+		return 0, 0, nil, errors.New("missing stop byte")
+	}
+
+	if checksum(b[:n]) != b[n] {
+		return 0, 0, nil, errors.New("checksum mismatch")
+	}
+
+	if b[0]&0x4f != cRspUd {
+		return 0, 0, nil, fmt.Errorf("unexpected control field: %02x", b[0])
+	}
+
+	return b[1], b[2], b[3:n], nil
+}
+
+// SecondaryAddress encodes the secondary address given as 8 digit identification number
+// or 16 hex digits of identification number, manufacturer, version and medium. F is wildcard.
+func SecondaryAddress(s string) ([]byte, error) {
+	s = strings.ToUpper(s)
+
+	switch len(s) {
+	case 8:
+		s += "FFFFFFFF"
+	case 16:
+	default:
+		return nil, fmt.Errorf("invalid secondary address: %s", s)
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secondary address: %s", s)
+	}
+
+	// identification number and manufacturer are little endian
+	slices.Reverse(b[0:4])
+	slices.Reverse(b[4:6])
+
+	return b, nil
+}
@@ -0,0 +1,148 @@
+package mbus
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// M-Bus (EN 13757) application layer
+// https://m-bus.com/documentation
+
+const (
+	ciResponseLong  = 0x72 // variable data response with long header
+	ciResponseNone  = 0x78 // variable data response without header
+	ciResponseShort = 0x7a // variable data response with short header
+
+	headerLong  = 12
+	headerShort = 4
+)
+
+// Settings are the M-Bus meter settings
+type Settings struct {
+	URI, Device, Comset string
+	Baudrate            int
+	Wireless            bool          // wM-Bus receiver
+	Primary             int           // primary address
+	Secondary           string        // secondary address, identification number for wM-Bus
+	Key                 string        // wM-Bus AES-128 key
+	Timeout             time.Duration // response timeout, maximum telegram age for wM-Bus
+}
+
+// Reader creates the meter's telegram reader
+func (s Settings) Reader() (func() (*Telegram, error), error) {
+	if !s.Wireless {
+		if s.Primary < 0 || s.Primary >= AddressNetwork {
+			return nil, fmt.Errorf("invalid primary address: %d", s.Primary)
+		}
+
+		if s.Comset == "" {
+			s.Comset = "8E1"
+		}
+		if s.Baudrate == 0 {
+			s.Baudrate = 2400
+		}
+		if s.Timeout == 0 {
+			s.Timeout = 3 * time.Second
+		}
+
+		conn, err := NewConnection(s.URI, s.Device, s.Comset, s.Baudrate, s.Timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() (*Telegram, error) {
+			return conn.Read(s.Primary, s.Secondary)
+		}, nil
+	}
+
+	if len(s.Secondary) != 8 {
+		return nil, fmt.Errorf("invalid identification number: %s", s.Secondary)
+	}
+
+	key, err := hex.DecodeString(s.Key)
+	if err != nil || len(key) != 0 && len(key) != 16 {
+		return nil, errors.New("invalid key: must be 32 hex digits")
+	}
+
+	if s.Comset == "" {
+		s.Comset = "8N1"
+	}
+	if s.Baudrate == 0 {
+		s.Baudrate = 9600
+	}
+	if s.Timeout == 0 {
+		s.Timeout = 15 * time.Minute
+	}
+
+	r, err := NewReceiver(s.URI, s.Device, s.Comset, s.Baudrate)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() (*Telegram, error) {
+		return r.Read(s.Secondary, key, s.Timeout)
+	}, nil
+}
+
+// Telegram is a decoded variable data response
+type Telegram struct {
+	ID           string   `json:"id"` // identification number
+	Manufacturer string   `json:"manufacturer"`
+	Version      byte     `json:"version"`
+	Medium       byte     `json:"medium"`
+	Records      []Record `json:"records"`
+}
+
+// Find returns the index'th instantaneous record of the quantity
+func (t *Telegram) Find(q Quantity, index int) (Record, error) {
+	return Find(t.Records, q, index)
+}
+
+// id formats the little endian bcd identification number
+func id(b []byte) string {
+	return fmt.Sprintf("%02x%02x%02x%02x", b[3], b[2], b[1], b[0])
+}
+
+// manufacturer decodes the manufacturer id into its three letter code
+func manufacturer(b []byte) string {
+	m := uint16(b[1])<<8 | uint16(b[0])
+	return string([]byte{byte(m>>10&0x1f) + 64, byte(m>>5&0x1f) + 64, byte(m&0x1f) + 64})
+}
+
+// decodeLongHeader decodes the long header's identification
+func decodeLongHeader(b []byte) *Telegram {
+	return &Telegram{
+		ID:           id(b[0:4]),
+		Manufacturer: manufacturer(b[4:6]),
+		Version:      b[6],
+		Medium:       b[7],
+	}
+}
+
+// decodeResponse decodes the application layer of a wired response
+func decodeResponse(ci byte, data []byte) (*Telegram, error) {
+	var res *Telegram
+
+	switch ci {
+	case ciResponseLong:
+		if len(data) < headerLong {
+			return nil, errors.New("short header")
+		}
+
+		res = decodeLongHeader(data)
+		data = data[headerLong:]
+
+	case ciResponseNone:
+		res = new(Telegram)
+
+	default:
+		return nil, fmt.Errorf("unsupported ci field: %02x", ci)
+	}
+
+	var err error
+	res.Records, err = Records(data)
+
+	return res, err
+}
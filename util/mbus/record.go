@@ -0,0 +1,385 @@
+package mbus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Quantity is the physical quantity of a data record
+type Quantity string
+
+// Quantities are normalized to kWh, W, m³, m³/h, °C, V and A
+const (
+	Energy            Quantity = "energy"
+	EnergyExport      Quantity = "energyExport" // accumulated backward flow
+	Power             Quantity = "power"
+	Volume            Quantity = "volume"
+	VolumeFlow        Quantity = "volumeFlow"
+	FlowTemperature   Quantity = "flowTemperature"
+	ReturnTemperature Quantity = "returnTemperature"
+	Voltage           Quantity = "voltage"
+	Current           Quantity = "current"
+)
+
+// Function is the data record's function field
+type Function int
+
+const (
+	Instantaneous Function = iota
+	Maximum
+	Minimum
+	ValueDuringError
+)
+
+// Record is a decoded data record. Records of unknown quantities are skipped.
+type Record struct {
+	Quantity Quantity `json:"quantity"`
+	Value    float64  `json:"value"`
+	Function Function `json:"function"`
+	Storage  int      `json:"storage"`
+	Tariff   int      `json:"tariff"`
+	Subunit  int      `json:"subunit"`
+}
+
+// Find returns the index'th instantaneous record of the quantity with current storage and tariff
+func Find(records []Record, q Quantity, index int) (Record, error) {
+	for _, r := range records {
+		if r.Quantity != q || r.Function != Instantaneous || r.Storage != 0 || r.Tariff != 0 {
+			continue
+		}
+
+		if index == 0 {
+			return r, nil
+		}
+		index--
+	}
+
+	return Record{}, fmt.Errorf("record not found: %s", q)
+}
+
+const (
+	difExtension  = 0x80
+	difIdleFiller = 0x2f
+
+	vifExtension = 0x80
+	vifTableFB   = 0xfb
+	vifTableFD   = 0xfd
+	vifPlainText = 0x7c
+	vifeBackward = 0x3c
+)
+
+// ErrTruncated indicates a truncated data record
+var ErrTruncated = errors.New("truncated record")
+
+type reader struct {
+	buf []byte
+	pos int
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.pos >= len(r.buf) {
+		return 0, ErrTruncated
+	}
+
+	b := r.buf[r.pos]
+	r.pos++
+
+	return b, nil
+}
+
+func (r *reader) bytes(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.buf) {
+		return nil, ErrTruncated
+	}
+
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+
+	return b, nil
+}
+
+// Records decodes the variable data records following the data header
+func Records(b []byte) ([]Record, error) {
+	r := &reader{buf: b}
+
+	var res []Record
+
+	for r.pos < len(r.buf) {
+		dif, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		if dif == difIdleFiller {
+			continue
+		}
+
+		// manufacturer specific data or special function
+		if dif&0x0f == 0x0f {
+			return res, nil
+		}
+
+		rec := Record{
+			Function: Function(dif >> 4 & 0x03),
+			Storage:  int(dif >> 6 & 0x01),
+		}
+
+		for i, b := 0, dif; b&difExtension != 0; i++ {
+			if b, err = r.byte(); err != nil {
+				return nil, err
+			}
+
+			// 10 extensions max
+			if i >= 10 {
+				return nil, errors.New("invalid dife")
+			}
+
+			rec.Storage |= int(b&0x0f) << (1 + 4*i)
+			rec.Tariff |= int(b>>4&0x03) << (2 * i)
+			rec.Subunit |= int(b>>6&0x01) << i
+		}
+
+		q, scale, err := r.vif()
+		if err != nil {
+			return nil, err
+		}
+
+		val, ok, err := r.value(dif & 0x0f)
+		if err != nil {
+			return nil, err
+		}
+
+		if q != "" && ok {
+			rec.Quantity = q
+			rec.Value = val * scale
+			res = append(res, rec)
+		}
+	}
+
+	return res, nil
+}
+
+// vif decodes the value information block into quantity and scale of the normalized unit
+func (r *reader) vif() (Quantity, float64, error) {
+	vif, err := r.byte()
+	if err != nil {
+		return "", 0, err
+	}
+
+	var (
+		q     Quantity
+		scale float64
+		last  = vif
+	)
+
+	switch vif {
+	case vifTableFB, vifTableFD:
+		if last, err = r.byte(); err != nil {
+			return "", 0, err
+		}
+
+		if vif == vifTableFB {
+			q, scale = vifFB(last & 0x7f)
+		} else {
+			q, scale = vifFD(last & 0x7f)
+		}
+
+	case vifPlainText, vifPlainText | vifExtension:
+		// unit is ascii text, vife follow the text if extension bit is set
+		var n byte
+		if n, err = r.byte(); err == nil {
+			_, err = r.bytes(int(n))
+		}
+		if err != nil {
+			return "", 0, err
+		}
+
+	default:
+		q, scale = vifPrimary(vif & 0x7f)
+	}
+
+	// extensions
+	for last&vifExtension != 0 {
+		if last, err = r.byte(); err != nil {
+			return "", 0, err
+		}
+
+		if last&0x7f == vifeBackward && q == Energy {
+			q = EnergyExport
+		}
+	}
+
+	return q, scale, nil
+}
+
+// vifPrimary decodes the primary vif
+func vifPrimary(v byte) (Quantity, float64) {
+	n := int(v & 0x07)
+	switch v & 0x78 {
+	case 0x00: // Wh
+		return Energy, math.Pow10(n - 6)
+	case 0x08: // J
+		return Energy, math.Pow10(n) / 3.6e6
+	case 0x10: // m³
+		return Volume, math.Pow10(n - 6)
+	case 0x28: // W
+		return Power, math.Pow10(n - 3)
+	case 0x30: // J/h
+		return Power, math.Pow10(n) / 3600
+	case 0x38: // m³/h
+		return VolumeFlow, math.Pow10(n - 6)
+	}
+
+	n = int(v & 0x03)
+	switch v & 0x7c {
+	case 0x58: // °C
+		return FlowTemperature, math.Pow10(n - 3)
+	case 0x5c: // °C
+		return ReturnTemperature, math.Pow10(n - 3)
+	}
+
+	return "", 0
+}
+
+// vifFB decodes the first extension table
+func vifFB(v byte) (Quantity, float64) {
+	n := int(v & 0x01)
+	switch v & 0x7e {
+	case 0x00: // MWh
+		return Energy, math.Pow10(n + 2)
+	case 0x28: // MW
+		return Power, math.Pow10(n + 5)
+	}
+
+	return "", 0
+}
+
+// vifFD decodes the second extension table
+func vifFD(v byte) (Quantity, float64) {
+	n := int(v & 0x0f)
+	switch v & 0x70 {
+	case 0x40: // V
+		return Voltage, math.Pow10(n - 9)
+	case 0x50: // A
+		return Current, math.Pow10(n - 12)
+	}
+
+	return "", 0
+}
+
+// dataLength is the data length by data field
+var dataLength = [16]int{0, 1, 2, 3, 4, 4, 6, 8, 0, 1, 2, 3, 4, 0, 6, 0}
+
+// value decodes the data field. Non-numeric values are skipped.
+func (r *reader) value(field byte) (float64, bool, error) {
+	switch field {
+	case 0x00, 0x08: // no data, selection for readout
+		return 0, false, nil
+
+	case 0x01, 0x02, 0x03, 0x04, 0x06, 0x07: // integer
+		b, err := r.bytes(dataLength[field])
+		if err != nil {
+			return 0, false, err
+		}
+
+		return float64(integer(b)), true, nil
+
+	case 0x05: // real
+		b, err := r.bytes(dataLength[field])
+		if err != nil {
+			return 0, false, err
+		}
+
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), true, nil
+
+	case 0x09, 0x0a, 0x0b, 0x0c, 0x0e: // bcd
+		b, err := r.bytes(dataLength[field])
+		if err != nil {
+			return 0, false, err
+		}
+
+		v, ok := bcd(b)
+		return v, ok, nil
+
+	case 0x0d: // variable length
+		lvar, err := r.byte()
+		if err != nil {
+			return 0, false, err
+		}
+
+		var n int
+		switch {
+		case lvar <= 0xbf: // ascii
+			n = int(lvar)
+		case lvar <= 0xdf: // positive/negative bcd
+			n = int(lvar & 0x0f)
+		case lvar <= 0xef: // binary
+			n = int(lvar - 0xe0)
+		case lvar <= 0xf4:
+			n = 4 * int(lvar-0xec)
+		case lvar == 0xf5:
+			n = 6
+		case lvar == 0xf6:
+			n = 8
+		default:
+			return 0, false, fmt.Errorf("invalid lvar: %02x", lvar)
+		}
+
+		b, err := r.bytes(n)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if lvar >= 0xc0 && lvar <= 0xdf {
+			v, ok := bcd(b)
+			if lvar >= 0xd0 {
+				v = -v
+			}
+			return v, ok, nil
+		}
+
+		return 0, false, nil
+
+	default:
+		return 0, false, fmt.Errorf("invalid data field: %02x", field)
+	}
+}
+
+// integer decodes a little endian signed integer
+func integer(b []byte) int64 {
+	var res int64
+	for i := len(b) - 1; i >= 0; i-- {
+		res = res<<8 | int64(b[i])
+	}
+
+	// sign extension
+	shift := 64 - 8*len(b)
+	return res << shift >> shift
+}
+
+// bcd decodes little endian bcd with optional sign in the most significant nibble
+func bcd(b []byte) (float64, bool) {
+	var (
+		res  float64
+		sign = 1.0
+	)
+
+	for i := len(b) - 1; i >= 0; i-- {
+		for j, d := range []byte{b[i] >> 4, b[i] & 0x0f} {
+			if d > 9 {
+				// sign in most significant nibble
+				if i == len(b)-1 && j == 0 && d == 0x0f {
+					sign = -1
+					continue
+				}
+				return 0, false
+			}
+
+			res = res*10 + float64(d)
+		}
+	}
+
+	return sign * res, true
+}
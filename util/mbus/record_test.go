@@ -0,0 +1,66 @@
+package mbus
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecords(t *testing.T) {
+	b := []byte{
+		0x04, 0x06, 0x1a, 0x00, 0x00, 0x00, // energy 26 kWh
+		0x04, 0x86, 0x3c, 0x0a, 0x00, 0x00, 0x00, // export energy 10 kWh
+		0x44, 0x06, 0x14, 0x00, 0x00, 0x00, // energy storage 1
+		0x84, 0x10, 0x06, 0x0c, 0x00, 0x00, 0x00, // energy tariff 1
+		0x0a, 0x2b, 0x12, 0xf0, // power -12 W bcd
+		0x0c, 0x13, 0x27, 0x04, 0x85, 0x02, // volume 2850.427 m³
+		0x2f,                         // filler
+		0x02, 0xfd, 0x48, 0xe6, 0x08, // voltage 227.8 V
+		0x02, 0xfd, 0x5a, 0xe8, 0x03, // current 10 A
+		0x05, 0xfd, 0x5c, 0x00, 0x00, 0x20, 0x41, // current 10 A float
+		0x02, 0x7c, 0x03, 'a', 'b', 'c', 0x01, 0x00, // plain text unit
+		0x02, 0x6c, 0x21, 0x2c, // date
+		0x0f, 0x01, 0x02, // manufacturer specific
+	}
+
+	res, err := Records(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Record{
+		{Quantity: Energy, Value: 26},
+		{Quantity: EnergyExport, Value: 10},
+		{Quantity: Energy, Value: 20, Storage: 1},
+		{Quantity: Energy, Value: 12, Tariff: 1},
+		{Quantity: Power, Value: -12},
+		{Quantity: Volume, Value: 2850.427},
+		{Quantity: Voltage, Value: 227.8},
+		{Quantity: Current, Value: 10},
+		{Quantity: Current, Value: 10},
+	}, roundValues(res))
+
+	r, err := Find(res, Energy, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 26.0, r.Value)
+
+	r, err = Find(res, Current, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, r.Value)
+
+	_, err = Find(res, Energy, 1)
+	assert.Error(t, err)
+}
+
+func TestRecordsTruncated(t *testing.T) {
+	_, err := Records([]byte{0x04, 0x06, 0x1a, 0x00})
+	assert.ErrorIs(t, err, ErrTruncated)
+}
+
+// roundValues removes floating point noise from scaling
+func roundValues(res []Record) []Record {
+	for i := range res {
+		res[i].Value = math.Round(res[i].Value*1e6) / 1e6
+	}
+	return res
+}
@@ -0,0 +1,312 @@
+package mbus
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/serial"
+)
+
+// Wireless M-Bus (EN 13757-4) telegrams as hex lines from a receiver like CUL or rtl-wmbus
+
+const (
+	modeNone = 0
+	modeAES  = 5 // AES-128-CBC with static IV
+)
+
+// crc16 is the EN 13757 CRC
+func crc16(b []byte) uint16 {
+	var crc uint16
+
+	for _, c := range b {
+		crc ^= uint16(c) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x3d65
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return ^crc
+}
+
+// stripCrc removes the block CRCs of frame format A if present
+func stripCrc(b []byte) ([]byte, error) {
+	if len(b) < 11 {
+		return nil, errors.New("short frame")
+	}
+
+	n := int(b[0]) + 1
+	if n < 11 {
+		return nil, errors.New("short frame")
+	}
+	if len(b) < n {
+		return nil, errors.New("truncated frame")
+	}
+
+	var res []byte
+	for block, size := b, 10; len(res) < n; size = 16 {
+		size = min(size, n-len(res))
+		if len(block) < size+2 || crc16(block[:size]) != uint16(block[size])<<8|uint16(block[size+1]) {
+			// frame without CRCs
+			return b[:n], nil
+		}
+
+		res = append(res, block[:size]...)
+		block = block[size+2:]
+	}
+
+	return res, nil
+}
+
+// ParseFrame parses a hex encoded frame with optional prefix
+func ParseFrame(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "0x")
+
+	// CUL prefix
+	if len(s)%2 == 1 && strings.HasPrefix(strings.ToLower(s), "b") {
+		s = s[1:]
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return stripCrc(b)
+}
+
+// frameID returns the meter's identification number. The application layer address
+// of the long header takes precedence over the link layer address, e.g. for repeaters.
+func frameID(b []byte) string {
+	if b[10] == ciResponseLong && len(b) >= 11+headerLong {
+		return id(b[11:15])
+	}
+	return id(b[4:8])
+}
+
+// DecodeFrame decodes a wireless link layer frame without CRCs using the optional AES key
+func DecodeFrame(b, key []byte) (*Telegram, error) {
+	if len(b) < 11 {
+		return nil, errors.New("short frame")
+	}
+
+	// link layer address: manufacturer, id, version, medium
+	res := &Telegram{
+		ID:           id(b[4:8]),
+		Manufacturer: manufacturer(b[2:4]),
+		Version:      b[8],
+		Medium:       b[9],
+	}
+
+	ci, data := b[10], b[11:]
+
+	var iv []byte
+
+	switch ci {
+	case ciResponseShort:
+		if len(data) < headerShort {
+			return nil, errors.New("short header")
+		}
+
+		iv = bytes.Clone(b[2:10])
+
+	case ciResponseLong:
+		if len(data) < headerLong {
+			return nil, errors.New("short header")
+		}
+
+		// application layer address takes precedence
+		res = decodeLongHeader(data)
+
+		iv = append(bytes.Clone(data[4:6]), data[0:4]...)
+		iv = append(iv, data[6:8]...)
+		data = data[headerLong-headerShort:]
+
+	case ciResponseNone:
+		var err error
+		res.Records, err = Records(data)
+		return res, err
+
+	default:
+		return nil, fmt.Errorf("unsupported ci field: %02x", ci)
+	}
+
+	// access number, status, configuration
+	access, config := data[0], uint16(data[3])<<8|uint16(data[2])
+	data = data[headerShort:]
+
+	switch mode := config >> 8 & 0x1f; mode {
+	case modeNone:
+
+	case modeAES:
+		if len(key) == 0 {
+			return nil, errors.New("missing key")
+		}
+
+		n := 16 * int(config>>4&0x0f)
+		if n == 0 || n > len(data) {
+			n = len(data) &^ 0x0f
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+
+		iv = append(iv, bytes.Repeat([]byte{access}, 8)...)
+
+		dec := make([]byte, len(data))
+		cipher.NewCBCDecrypter(block, iv).CryptBlocks(dec[:n], data[:n])
+		copy(dec[n:], data[n:])
+
+		if !bytes.HasPrefix(dec, []byte{difIdleFiller, difIdleFiller}) {
+			return nil, errors.New("decryption failed: invalid key")
+		}
+
+		data = dec
+
+	default:
+		return nil, fmt.Errorf("unsupported encryption mode: %d", mode)
+	}
+
+	var err error
+	res.Records, err = Records(data)
+
+	return res, err
+}
+
+type frame struct {
+	data    []byte
+	updated time.Time
+}
+
+// Receiver collects the wireless telegrams of a receiver via serial device or TCP
+type Receiver struct {
+	mu     sync.Mutex
+	log    *util.Logger
+	uri    string
+	serial *serial.Config
+	frames map[string]frame
+}
+
+var receivers = make(map[string]*Receiver)
+
+// NewReceiver creates a shared wM-Bus receiver
+func NewReceiver(uri, device, comset string, baudrate int) (*Receiver, error) {
+	if (uri == "") == (device == "") {
+		return nil, errors.New("must have either uri or device")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	key := uri + device
+	if r, ok := receivers[key]; ok {
+		return r, nil
+	}
+
+	r := &Receiver{
+		log:    util.NewLogger("wmbus"),
+		uri:    uri,
+		frames: make(map[string]frame),
+	}
+
+	if device != "" {
+		var err error
+		if r.serial, err = serial.NewConfig(device, comset, baudrate, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	receivers[key] = r
+
+	go r.run()
+
+	return r, nil
+}
+
+func (r *Receiver) connect() (io.ReadWriteCloser, error) {
+	if r.serial != nil {
+		return serial.Open(r.serial)
+	}
+
+	dialer := net.Dialer{Timeout: request.Timeout}
+
+	return dialer.Dial("tcp", r.uri)
+}
+
+func (r *Receiver) run() {
+	bo := backoff.NewExponentialBackOff(backoff.WithMaxInterval(5 * time.Minute))
+
+	for {
+		conn, err := r.connect()
+		if err != nil {
+			r.log.ERROR.Printf("connect: %v", err)
+			time.Sleep(bo.NextBackOff().Truncate(time.Second))
+			continue
+		}
+
+		reader := bufio.NewReader(conn)
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				r.log.ERROR.Printf("read: %v", err)
+				break
+			}
+
+			b, err := ParseFrame(line)
+			if err != nil {
+				r.log.DEBUG.Printf("ignoring %q: %v", strings.TrimSpace(line), err)
+				continue
+			}
+
+			meter := frameID(b)
+			r.log.TRACE.Printf("recv: %s % x", meter, b)
+
+			r.mu.Lock()
+			r.frames[meter] = frame{data: b, updated: time.Now()}
+			r.mu.Unlock()
+
+			bo.Reset()
+		}
+
+		conn.Close()
+		time.Sleep(bo.NextBackOff().Truncate(time.Second))
+	}
+}
+
+// Read decodes the latest telegram of the meter with given identification number
+func (r *Receiver) Read(id string, key []byte, timeout time.Duration) (*Telegram, error) {
+	r.mu.Lock()
+	f, ok := r.frames[id]
+	r.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no telegram received: %s", id)
+	}
+
+	if time.Since(f.updated) > timeout {
+		return nil, os.ErrDeadlineExceeded
+	}
+
+	return DecodeFrame(f.data, key)
+}
@@ -0,0 +1,98 @@
+package mbus
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var wmbusKey = []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+
+// wmbusFrame creates an encrypted short header frame
+func wmbusFrame(t *testing.T) []byte {
+	t.Helper()
+
+	plain := []byte{
+		0x2f, 0x2f,
+		0x04, 0x06, 0x1a, 0x00, 0x00, 0x00, // energy 26 kWh
+		0x02, 0x2b, 0x64, 0x00, // power 100 W
+		0x2f, 0x2f, 0x2f, 0x2f,
+	}
+
+	// c, manufacturer, id, version, medium
+	link := []byte{0x44, 0x93, 0x15, 0x78, 0x56, 0x34, 0x12, 0x01, 0x02}
+
+	const access = 0x2a
+	iv := append(bytes.Clone(link[1:]), bytes.Repeat([]byte{access}, 8)...)
+
+	block, err := aes.NewCipher(wmbusKey)
+	require.NoError(t, err)
+
+	enc := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(enc, plain)
+
+	// ci, access, status, configuration with mode 5 and one block
+	res := append(link, ciResponseShort, access, 0x00, 0x10, 0x05)
+	res = append(res, enc...)
+
+	return append([]byte{byte(len(res))}, res...)
+}
+
+// withCrc adds frame format A block CRCs
+func withCrc(b []byte) []byte {
+	var res []byte
+	for size := 10; len(b) > 0; size = 16 {
+		size = min(size, len(b))
+		crc := crc16(b[:size])
+		res = append(res, b[:size]...)
+		res = append(res, byte(crc>>8), byte(crc))
+		b = b[size:]
+	}
+	return res
+}
+
+func TestCrc16(t *testing.T) {
+	assert.Equal(t, uint16(0xc2b7), crc16([]byte("123456789")))
+}
+
+func TestDecodeFrame(t *testing.T) {
+	frame := wmbusFrame(t)
+
+	for _, s := range []string{
+		hex.EncodeToString(frame),
+		"b" + strings.ToUpper(hex.EncodeToString(withCrc(frame))) + "\r\n",
+	} {
+		b, err := ParseFrame(s)
+		require.NoError(t, err)
+		require.Equal(t, frame, b)
+
+		res, err := DecodeFrame(b, wmbusKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, "12345678", res.ID)
+		assert.Equal(t, "ELS", res.Manufacturer)
+		assert.Equal(t, []Record{{Quantity: Energy, Value: 26}, {Quantity: Power, Value: 100}}, res.Records)
+	}
+
+	_, err := DecodeFrame(frame, make([]byte, 16))
+	assert.ErrorContains(t, err, "invalid key")
+
+	_, err = DecodeFrame(frame, nil)
+	assert.ErrorContains(t, err, "missing key")
+}
+
+func TestFrameID(t *testing.T) {
+	frame := wmbusFrame(t)
+	assert.Equal(t, "12345678", frameID(frame))
+
+	// long header behind repeater with different link layer address
+	long := append(bytes.Clone(frame[:11]), 0x21, 0x43, 0x65, 0x87, 0x93, 0x15, 0x01, 0x02, 0x2a, 0x00, 0x00, 0x00)
+	long[10] = ciResponseLong
+	assert.Equal(t, "87654321", frameID(long))
+}
@@ -0,0 +1,45 @@
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/grid-x/serial"
+)
+
+// Config is the serial port configuration
+type Config = serial.Config
+
+// NewConfig creates the serial port configuration from comset like 8N1.
+// Zero timeout blocks reads until data is available.
+func NewConfig(device, comset string, baudrate int, timeout time.Duration) (*Config, error) {
+	comset = strings.ToUpper(comset)
+
+	if len(comset) != 3 || !strings.ContainsAny(comset[0:1], "5678") ||
+		!strings.ContainsAny(comset[1:2], "NEO") || !strings.ContainsAny(comset[2:3], "12") {
+		return nil, fmt.Errorf("invalid comset: %s", comset)
+	}
+
+	if baudrate == 0 {
+		return nil, errors.New("missing baudrate")
+	}
+
+	res := &Config{
+		Address:  device,
+		BaudRate: baudrate,
+		DataBits: int(comset[0] - '0'),
+		Parity:   comset[1:2],
+		StopBits: int(comset[2] - '0'),
+		Timeout:  timeout,
+	}
+
+	return res, nil
+}
+
+// Open opens the serial port
+func Open(c *Config) (io.ReadWriteCloser, error) {
+	return serial.Open(c)
+}
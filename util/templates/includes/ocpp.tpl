@@ -21,6 +21,40 @@ meterinterval: {{ .meterinterval }}
 {{- if ne .connecttimeout "5m" }}
 connecttimeout: {{ .connecttimeout }}
 {{- end }}
+{{- if and .schedule (ne .schedule "false") }}
+schedule: {{ .schedule }}
+{{- if ne .failsafecurrent "6" }}
+failsafecurrent: {{ .failsafecurrent }}
+{{- end }}
+{{- end }}
+{{- if or .unknowntags (len .guests) (and .locallist (ne .locallist "false")) }}
+authorization:
+{{- if .unknowntags }}
+  unknown: {{ .unknowntags }}
+{{- if ne .restrictedcurrent "6" }}
+  restrictedcurrent: {{ .restrictedcurrent }}
+{{- end }}
+{{- end }}
+{{- if len .guests }}
+  guests:
+{{- range .guests }}
+  - {{ . }}
+{{- end }}
+{{- end }}
+{{- if and .locallist (ne .locallist "false") }}
+  locallist: {{ .locallist }}
+{{- end }}
+{{- end }}
+{{- if .upstreamuri }}
+upstream:
+  uri: {{ .upstreamuri }}
+{{- if .upstreamstationid }}
+  stationid: {{ .upstreamstationid }}
+{{- end }}
+{{- if .upstreampassword }}
+  password: {{ .upstreampassword }}
+{{- end }}
+{{- end }}
 {{- if and .timeout (ne .timeout "30s") }}
 timeout: {{ .timeout }}
 {{- end }}